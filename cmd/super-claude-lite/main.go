@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
 
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/git"
 	"github.com/dgnsrekt/super-claude-lite/internal/installer"
 )
 
@@ -26,12 +30,22 @@ func main() {
 		Version: fmt.Sprintf("%s (%s)", version, commit),
 	}
 
+	// Prompts fall back to numbered line menus without a capable terminal
+	var prompts installer.PromptOptions
+	rootCmd.PersistentFlags().BoolVarP(&prompts.AssumeYes, "yes", "y", false, "Answer yes to confirmations and take the default for other questions")
+	rootCmd.PersistentFlags().BoolVar(&prompts.NoInput, "no-input", false, "Never prompt; fail with the flag to pass when an answer is needed")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		installer.ConfigurePrompts(prompts)
+	}
+
 	// Add subcommands
 	rootCmd.AddCommand(
 		createInitCommand(),
 		createStatusCommand(),
 		createCleanCommand(),
 		createRollbackCommand(),
+		createLintCommand(),
+		createMCPCommand(),
 	)
 
 	// Use Fang for batteries-included CLI
@@ -48,8 +62,24 @@ func createInitCommand() *cobra.Command {
 		noBackup          bool
 		interactive       bool
 		addRecommendedMCP bool
+		mcpServers        []string
+		mcpConflict       string
+		mcpEnv            []string
+		envFile           string
+		strictMCP         bool
+		mcpTargets        []string
+		pinMCP            bool
+		mcpVersions       string
+		approveMCP        string
+		mcpSettings       string
 		backupDir         string
 		dryRun            bool
+		importInto        string
+		components        installer.ComponentSelection
+		frameworkRef      string
+		wizard            bool
+		configFile        string
+		noProgress        bool
 	)
 
 	cmd := &cobra.Command{
@@ -59,11 +89,17 @@ func createInitCommand() *cobra.Command {
 If no directory is specified, uses the current working directory.
 
 The installer will:
-- Clone SuperClaude Framework at a fixed commit
+- Clone SuperClaude Framework at a fixed commit (or --ref)
 - Copy framework files to .superclaude/
-- Create or merge CLAUDE.md with SuperClaude import
-- Create or merge .mcp.json configuration
-- Backup existing files before modification`,
+- Generate .superclaude/CLAUDE.md importing the selected core files and modes
+- Create or merge CLAUDE.md (or .claude/CLAUDE.md, CLAUDE.local.md) with SuperClaude import
+- Create or merge .mcp.json configuration, and the configs of --mcp-target clients
+- Approve the MCP servers in .claude/settings.local.json with --approve-mcp
+- Backup existing files before modification
+
+With --wizard, a step-by-step setup asks for each option and lists every file it
+will create or modify before installing; its answers can be saved to
+.superclaude-lite/init.json and reused with --config.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Determine target directory
@@ -85,17 +121,107 @@ The installer will:
 				return fmt.Errorf("failed to resolve target directory: %w", err)
 			}
 
+			// Resolve where the SuperClaude import goes (empty keeps an existing location)
+			var importLocation installer.ImportLocation
+			if importInto != "" {
+				importLocation, err = installer.ParseImportLocation(importInto)
+				if err != nil {
+					return err
+				}
+			}
+
+			conflictStrategy, err := installer.ParseMCPConflictStrategy(mcpConflict)
+			if err != nil {
+				return err
+			}
+
+			// Naming servers with --mcp implies --add-mcp, except for --mcp none
+			if cmd.Flags().Changed("mcp") {
+				if !isMCPNone(mcpServers) {
+					addRecommendedMCP = true
+				}
+				if mcpServers == nil {
+					mcpServers = []string{}
+				}
+			} else {
+				mcpServers = nil
+			}
+
+			envValues, err := installer.ParseMCPEnvValues(mcpEnv)
+			if err != nil {
+				return err
+			}
+
+			// Writing servers for other clients implies --add-mcp
+			targets, err := installer.ParseMCPTargets(mcpTargets)
+			if err != nil {
+				return err
+			}
+			if len(targets) > 0 {
+				addRecommendedMCP = true
+			}
+
+			// Approving servers in Claude Code's settings implies --add-mcp
+			approvalMode, err := installer.ParseMCPApprovalMode(approveMCP)
+			if err != nil {
+				return err
+			}
+			if approvalMode != installer.MCPApprovalNone {
+				addRecommendedMCP = true
+			}
+			settingsScope, err := installer.ParseMCPSettingsScope(mcpSettings)
+			if err != nil {
+				return err
+			}
+
 			// Create installation config
-			config := &installer.InstallConfig{
+			installConfig := &installer.InstallConfig{
 				Force:             force,
 				NoBackup:          noBackup,
 				Interactive:       interactive,
 				AddRecommendedMCP: addRecommendedMCP,
 				BackupDir:         backupDir,
+				ImportInto:        importLocation,
+				Components:        components,
+				MCPServers:        mcpServers,
+				MCPConflict:       conflictStrategy,
+				MCPEnv:            installer.MCPEnvOptions{Values: envValues, EnvFile: envFile},
+				StrictMCP:         strictMCP,
+				MCPTargets:        targets,
+				PinMCP:            pinMCP || mcpVersions != "",
+				MCPVersionsFile:   mcpVersions,
+				ApproveMCP:        approvalMode,
+				MCPSettings:       settingsScope,
+				FrameworkRef:      frameworkRef,
+			}
+
+			switch {
+			case wizard:
+				result, cleanup, err := runInitWizard(targetDir, installConfig, configFile)
+				defer cleanup()
+				if err != nil {
+					return err
+				}
+				targetDir = result.TargetDir
+				result.Profile.Override(installConfig)
+				installConfig.FrameworkDir = result.FrameworkDir
+				if result.Save && !dryRun {
+					path, err := result.Profile.Save(targetDir)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("💾 Saved answers to %s\n", path)
+				}
+			case configFile != "":
+				profile, err := installer.LoadInitProfile(configFile)
+				if err != nil {
+					return err
+				}
+				profile.Apply(installConfig)
 			}
 
 			// Create installer
-			inst, err := installer.NewInstaller(targetDir, config)
+			inst, err := installer.NewInstaller(targetDir, installConfig)
 			if err != nil {
 				return fmt.Errorf("failed to create installer: %w", err)
 			}
@@ -103,6 +229,10 @@ The installer will:
 			// Set dry run mode
 			inst.GetContext().DryRun = dryRun
 
+			// Animate the steps on a capable terminal, one line per step otherwise
+			live := !noProgress && installer.LiveOutput()
+			inst.SetObserver(installer.NewProgressDisplay(os.Stdout, live).Observe)
+
 			fmt.Printf("Installing SuperClaude Framework to: %s\n", targetDir)
 
 			if dryRun {
@@ -134,20 +264,108 @@ The installer will:
 	cmd.Flags().StringVarP(&targetDir, "path", "p", "", "Target directory for installation (default: current directory)")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force installation without prompts")
 	cmd.Flags().BoolVar(&noBackup, "no-backup", false, "Skip creating backups of existing files")
-	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Ask for confirmation on each conflict")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Show a diff and ask whether to merge, overwrite or skip each existing file SuperClaude would change")
 	cmd.Flags().BoolVar(&addRecommendedMCP, "add-mcp", false, "Add recommended MCP servers to .mcp.json")
+	cmd.Flags().StringSliceVar(&mcpServers, "mcp", nil, "MCP servers to install without the selector, e.g. context7,serena, or all/none (implies --add-mcp)")
+	cmd.Flags().StringVar(&mcpConflict, "mcp-conflict", string(installer.MCPConflictKeep), "How to handle existing .mcp.json entries that differ from the SuperClaude config: keep, overwrite, rename or prompt")
+	cmd.Flags().StringSliceVar(&mcpTargets, "mcp-target", nil,
+		"Also write the selected MCP servers for these clients: "+strings.Join(installer.MCPTargetNames(), ", ")+" (comma-separated or repeated)")
+	cmd.Flags().BoolVar(&pinMCP, "pin-mcp", false, "Rewrite @latest and unpinned git+https MCP package specs to exact versions")
+	cmd.Flags().StringVar(&mcpVersions, "mcp-versions", "", "JSON file with the MCP package versions to pin to, overriding the built-in ones (implies --pin-mcp)")
+	cmd.Flags().StringVar(&approveMCP, "approve-mcp", "", "Pre-approve project MCP servers in Claude Code's settings: selected (enabledMcpjsonServers) or all (enableAllProjectMcpServers) (implies --add-mcp)")
+	cmd.Flags().StringVar(&mcpSettings, "mcp-settings", string(installer.MCPSettingsLocal), "Settings file for --approve-mcp: local (.claude/settings.local.json) or project (.claude/settings.json)")
+	cmd.Flags().BoolVar(&strictMCP, "strict-mcp", false, "Fail when a selected MCP server's runtime (node/npx, uv/uvx, git) is missing or too old")
+	cmd.Flags().StringArrayVar(&mcpEnv, "env", nil, "Value for a secret an MCP server needs, as VAR=value (repeatable)")
+	cmd.Flags().StringVar(&envFile, "env-file", "", "Read MCP server secrets from this dotenv file (default: .env in the project, if present)")
 	cmd.Flags().StringVarP(&backupDir, "backup-dir", "b", "", "Custom backup directory")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&noProgress, "no-progress", false, "Print a line per installation step instead of the live progress display")
+	cmd.Flags().StringSliceVar(&components.Modes, "modes", nil, "Modes to import, e.g. brainstorming,task_management (default: previous selection, else all)")
+	cmd.Flags().StringSliceVar(&components.ExcludeMode, "exclude-mode", nil, "Modes to leave out of .superclaude/CLAUDE.md")
+	cmd.Flags().StringSliceVar(&components.Core, "core", nil, "Core files to import, e.g. flags,rules,principles (default: previous selection, else all)")
+	cmd.Flags().StringSliceVar(&components.ExcludeCore, "exclude-core", nil, "Core files to leave out of .superclaude/CLAUDE.md")
+	cmd.Flags().BoolVar(&components.Interactive, "select-components", false, "Choose core files and modes interactively")
+	cmd.Flags().StringVar(&frameworkRef, "ref", "", "SuperClaude Framework branch, tag or commit to install (default: the tested commit)")
+	cmd.Flags().BoolVar(&wizard, "wizard", false, "Set up step by step and review the changes before installing")
+	cmd.Flags().StringVar(&configFile, "config", "", "Apply answers saved by --wizard (e.g. .superclaude-lite/init.json); flags take precedence")
+	cmd.Flags().StringVar(&importInto, "import-into", "", "Where to write the SuperClaude import: project (CLAUDE.md), claude-dir (.claude/CLAUDE.md) or local (CLAUDE.local.md) (default: existing location, else project)")
 
 	return cmd
 }
 
+// runInitWizard runs init --wizard, defaulting to the answers in configFile or the
+// project's saved init.json. The returned cleanup removes the framework checkouts.
+func runInitWizard(targetDir string, cfg *installer.InstallConfig, configFile string) (*installer.InitWizardResult, func(), error) {
+	// Fetches run on the wizard's goroutines and may outlive a cancelled wizard
+	var (
+		mu        sync.Mutex
+		checkouts []string
+	)
+	cleanup := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, dir := range checkouts {
+			_ = git.CleanupTempDir(dir)
+		}
+	}
+
+	if configFile == "" {
+		path := installer.InitProfilePath(targetDir)
+		if _, err := os.Stat(path); err == nil {
+			configFile = path
+		}
+	}
+	var saved *installer.InitProfile
+	if configFile != "" {
+		profile, err := installer.LoadInitProfile(configFile)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		saved = profile
+	}
+
+	if err := git.ValidateGitInstalled(); err != nil {
+		return nil, cleanup, err
+	}
+	fetch := func(ref string) (string, error) {
+		dir, err := git.GetTempCloneDir()
+		if err != nil {
+			return "", err
+		}
+		mu.Lock()
+		checkouts = append(checkouts, dir)
+		mu.Unlock()
+		return dir, git.CloneRepositoryAt(dir, ref)
+	}
+
+	result, err := installer.ShowInitWizard(targetDir, *cfg, saved, fetch)
+	return result, cleanup, err
+}
+
+// isMCPNone reports whether --mcp explicitly asked for no servers
+func isMCPNone(names []string) bool {
+	for _, name := range names {
+		if value := strings.ToLower(strings.TrimSpace(name)); value != "" && value != "none" {
+			return false
+		}
+	}
+	return true
+}
+
 func createStatusCommand() *cobra.Command {
+	var (
+		showContext bool
+		maxTokens   int
+	)
+
 	cmd := &cobra.Command{
 		Use:   "status [directory]",
 		Short: "Check SuperClaude installation status",
-		Long:  "Check if SuperClaude framework is installed and show status information.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Check if SuperClaude framework is installed and show status information.
+
+With --context, walk the effective @ import tree from CLAUDE.md and estimate the
+characters and approximate tokens it adds per file and per component.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Determine target directory
 			targetDir := "."
@@ -160,10 +378,21 @@ func createStatusCommand() *cobra.Command {
 				return fmt.Errorf("failed to resolve directory: %w", err)
 			}
 
-			return checkInstallationStatus(targetDir)
+			if err := checkInstallationStatus(targetDir); err != nil {
+				return err
+			}
+
+			if showContext {
+				return reportContextBudget(targetDir, maxTokens)
+			}
+
+			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&showContext, "context", false, "Estimate the context size added by the import tree")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", config.DefaultContextTokenBudget, "Token budget to flag with --context (0 disables the check)")
+
 	return cmd
 }
 
@@ -220,13 +449,73 @@ func createRollbackCommand() *cobra.Command {
 	return cmd
 }
 
+func createLintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [directory]",
+		Short: "Check CLAUDE.md @imports and .mcp.json for problems",
+		Long: `Parse CLAUDE.md, .claude/CLAUDE.md and CLAUDE.local.md and follow @ imports
+recursively using the same relative-path rules and depth limit as Claude Code.
+
+Reports missing import targets, import cycles, duplicate imports and imports that
+exceed the maximum depth, and validates .mcp.json against the MCP server schema.
+Exits with a non-zero status when problems are found.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Determine target directory
+			targetDir := "."
+			if len(args) > 0 {
+				targetDir = args[0]
+			}
+
+			targetDir, err := filepath.Abs(targetDir)
+			if err != nil {
+				return fmt.Errorf("failed to resolve directory: %w", err)
+			}
+
+			return lintProject(targetDir)
+		},
+	}
+
+	return cmd
+}
+
+// lintProject reports @ import and .mcp.json problems and fails if any are found
+func lintProject(targetDir string) error {
+	issues, checked, err := installer.LintImports(targetDir)
+	if err != nil {
+		return err
+	}
+	problems, err := installer.ValidateMCPConfigFile(targetDir)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		fmt.Println(issue.String())
+	}
+	for _, problem := range problems {
+		fmt.Println(problem.Error())
+	}
+
+	switch {
+	case len(issues) > 0 && len(problems) > 0:
+		return fmt.Errorf("found %d import problems in %d files and %d problems in .mcp.json", len(issues), checked, len(problems))
+	case len(issues) > 0:
+		return fmt.Errorf("found %d import problems in %d files", len(issues), checked)
+	case len(problems) > 0:
+		return fmt.Errorf("found %d problems in .mcp.json", len(problems))
+	}
+
+	fmt.Printf("✅ No import problems found (%d files checked)\n", checked)
+	return nil
+}
+
 // checkInstallationStatus checks if SuperClaude is installed
 func checkInstallationStatus(targetDir string) error {
 	fmt.Printf("Checking SuperClaude installation status in: %s\n\n", targetDir)
 
 	requiredFiles := map[string]string{
 		filepath.Join(targetDir, ".superclaude"): "Framework directory",
-		filepath.Join(targetDir, "CLAUDE.md"):    "Main configuration",
 		filepath.Join(targetDir, ".claude"):      "Claude directory",
 	}
 
@@ -246,6 +535,18 @@ func checkInstallationStatus(targetDir string) error {
 		}
 	}
 
+	imports := installer.FindSuperClaudeImports(targetDir)
+	if len(imports) == 0 {
+		fmt.Printf("❌ SuperClaude import: not found in %s, %s or %s\n",
+			installer.ImportIntoProject.RelPath(), installer.ImportIntoClaudeDir.RelPath(), installer.ImportIntoLocal.RelPath())
+	}
+	for _, location := range imports {
+		fmt.Printf("✅ SuperClaude import (%s): %s\n", location, location.Path(targetDir))
+	}
+	if len(imports) > 1 {
+		fmt.Printf("⚠️  SuperClaude is imported from %d files; re-run init with --import-into to keep one\n", len(imports))
+	}
+
 	fmt.Printf("\nOptional files:\n")
 	for path, description := range optionalFiles {
 		if _, err := os.Stat(path); err == nil {
@@ -255,6 +556,53 @@ func checkInstallationStatus(targetDir string) error {
 		}
 	}
 
+	findings, err := installer.DetectLiteralSecrets(filepath.Join(targetDir, ".mcp.json"))
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	for _, finding := range findings {
+		fmt.Printf("⚠️  %s (use a ${VAR} reference)\n", finding)
+	}
+
+	unexported, err := installer.UnexportedMCPSecrets(targetDir)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	if len(unexported) > 0 {
+		fmt.Printf("⚠️  MCP secrets %s are stored but not exported; Claude Code reads them from its environment: %s\n",
+			strings.Join(unexported, ", "), installer.MCPSecretsLoadCommand())
+	}
+
+	problems, err := installer.ValidateMCPConfigFile(targetDir)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	for _, problem := range problems {
+		fmt.Printf("⚠️  %s\n", problem.Error())
+	}
+
+	versions, err := installer.LoadMCPVersions(targetDir, "")
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	pins, err := installer.CheckMCPPins(targetDir, versions)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	for _, pin := range pins {
+		if !pin.Package.Pinned() {
+			fmt.Printf("⚠️  MCP server %s is not pinned: %s (use init --pin-mcp)\n", pin.Server, pin.Package)
+		}
+	}
+
+	issues, err := installer.CheckConfiguredMCPRuntimes(targetDir)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	for _, issue := range issues {
+		fmt.Printf("⚠️  MCP runtime: %s\n", issue)
+	}
+
 	fmt.Printf("\nStatus: ")
 	if installed {
 		fmt.Printf("✅ SuperClaude is installed\n")
@@ -266,21 +614,33 @@ func checkInstallationStatus(targetDir string) error {
 	return nil
 }
 
+// reportContextBudget prints the estimated context size of the import tree
+func reportContextBudget(targetDir string, maxTokens int) error {
+	budget, err := installer.EstimateContextBudget(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to estimate context size: %w", err)
+	}
+
+	fmt.Println()
+	budget.PrintReport(maxTokens)
+	return nil
+}
+
 // cleanInstallation removes SuperClaude files
 func cleanInstallation(targetDir string, force bool) error {
 	if !force {
 		fmt.Printf("This will remove SuperClaude framework files from: %s\n", targetDir)
 		fmt.Printf("Files to be removed:\n")
 		fmt.Printf("  - .superclaude/ (entire directory)\n")
-		fmt.Printf("  - SuperClaude import from CLAUDE.md (if present)\n")
-		fmt.Printf("\nContinue? (y/N): ")
+		fmt.Printf("  - SuperClaude import from CLAUDE.md, .claude/CLAUDE.md and CLAUDE.local.md (if present)\n")
+		fmt.Printf("  - MCP approvals added by init to .claude/settings*.json (if any)\n")
+		fmt.Printf("\n")
 
-		var response string
-		if _, err := fmt.Scanln(&response); err != nil {
-			fmt.Printf("Input error, cancelling operation.\n")
-			return nil
+		confirmed, err := installer.PromptConfirm("Continue?", "use --force or --yes")
+		if err != nil {
+			return err
 		}
-		if response != "y" && response != "Y" {
+		if !confirmed {
 			fmt.Printf("Cancelled.\n")
 			return nil
 		}
@@ -294,12 +654,36 @@ func cleanInstallation(targetDir string, force bool) error {
 		}
 	}
 
+	// Keep the manifest's record of the import section; it goes with .superclaude
+	manifest, err := installer.LoadInstallManifest(targetDir)
+	if err != nil {
+		fmt.Printf("Warning: %v; removing the import as the current templates render it\n", err)
+	}
+
+	// Revoke MCP approvals before the manifest recording them goes with .superclaude
+	settings, err := installer.RevokeMCPApprovals(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove MCP approvals: %w", err)
+	}
+	for _, file := range settings {
+		fmt.Printf("✅ Removed MCP approvals from %s\n", file)
+	}
+
 	// Remove .superclaude directory
 	superClaudeDir := filepath.Join(targetDir, ".superclaude")
 	if err := os.RemoveAll(superClaudeDir); err != nil {
 		return fmt.Errorf("failed to remove .superclaude directory: %w", err)
 	}
 
+	// Remove the import from whichever memory files carry it
+	removed, err := installer.RemoveSuperClaudeImports(targetDir, manifest)
+	if err != nil {
+		return fmt.Errorf("failed to remove SuperClaude import: %w", err)
+	}
+	for _, location := range removed {
+		fmt.Printf("✅ Removed SuperClaude import from %s\n", location.RelPath())
+	}
+
 	fmt.Printf("✅ Removed SuperClaude framework files\n")
 	return nil
 }
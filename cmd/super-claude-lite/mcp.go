@@ -0,0 +1,386 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/git"
+	"github.com/dgnsrekt/super-claude-lite/internal/installer"
+)
+
+func createMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "List, add, remove, check and update MCP servers in an existing installation",
+		Long: `Manage MCP servers without re-running init.
+
+Changes keep .mcp.json, the .superclaude/MCP/*.md files and the *MCP_INTEGRATIONS*
+imports in .superclaude/CLAUDE.md consistent. Every subcommand works on the
+project in --path, e.g. mcp add context7 --path ../app; it defaults to the current
+directory.`,
+	}
+
+	var projectDir string
+	cmd.PersistentFlags().StringVarP(&projectDir, "path", "p", "", "Project directory (default: current directory)")
+
+	var (
+		conflict string
+		env      []string
+		envFile  string
+		clients  []string
+		pin      bool
+		versions string
+	)
+	addCmd := &cobra.Command{
+		Use:   "add <name...>",
+		Short: "Add MCP servers to .mcp.json and .superclaude",
+		Args:  mcpNameArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			strategy, err := installer.ParseMCPConflictStrategy(conflict)
+			if err != nil {
+				return err
+			}
+			values, err := installer.ParseMCPEnvValues(env)
+			if err != nil {
+				return err
+			}
+			targets, err := installer.ParseMCPTargets(clients)
+			if err != nil {
+				return err
+			}
+
+			return withMCPManager(mcpProjectDir(projectDir), func(manager *installer.MCPManager) error {
+				if pin || versions != "" {
+					pins, err := installer.LoadMCPVersions(manager.TargetDir, versions)
+					if err != nil {
+						return err
+					}
+					manager.Versions = &pins
+				}
+				manager.ConflictStrategy = strategy
+				manager.Env = installer.MCPEnvOptions{Values: values, EnvFile: envFile}
+				manager.Targets = targets
+				changes, err := manager.Add(args)
+				if err != nil {
+					return err
+				}
+				printMCPChanges(changes)
+				return nil
+			})
+		},
+	}
+	addCmd.Flags().StringVar(&conflict, "mcp-conflict", string(installer.MCPConflictKeep),
+		"How to handle existing .mcp.json entries that differ: keep, overwrite, rename or prompt")
+	addCmd.Flags().StringArrayVar(&env, "env", nil, "Value for a secret the server needs, as VAR=value (repeatable)")
+	addCmd.Flags().StringVar(&envFile, "env-file", "", "Read server secrets from this dotenv file (default: .env in the project, if present)")
+	addCmd.Flags().StringSliceVar(&clients, "mcp-target", nil,
+		"Also write the servers to these clients' configs: "+strings.Join(installer.MCPTargetNames(), ", "))
+	addCmd.Flags().BoolVar(&pin, "pin-mcp", false, "Rewrite @latest and unpinned git+https package specs to exact versions")
+	addCmd.Flags().StringVar(&versions, "mcp-versions", "", "JSON file with the package versions to pin to (implies --pin-mcp)")
+
+	var timeout time.Duration
+	checkCmd := &cobra.Command{
+		Use:   "check [name...]",
+		Short: "Connect to configured MCP servers and list their tools",
+		Long: `Launch each stdio server from .mcp.json with its configured command, args and
+env, or connect to each http and sse server's url with its headers, perform the MCP
+initialize handshake and tools/list, and report the server version and tool count or
+the startup error. Checks every server when no names are given.`,
+		Args: mcpNameArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := filepath.Abs(mcpProjectDir(projectDir))
+			if err != nil {
+				return fmt.Errorf("failed to resolve directory: %w", err)
+			}
+			return checkMCPServers(dir, args, timeout)
+		},
+	}
+	checkCmd.Flags().DurationVar(&timeout, "timeout", installer.DefaultMCPCheckTimeout, "How long to wait for each server to answer")
+
+	cmd.AddCommand(
+		checkCmd,
+		createMCPListCommand(&projectDir),
+		addCmd,
+		createMCPRemoveCommand(&projectDir),
+		createMCPUpdateCommand(&projectDir),
+	)
+
+	return cmd
+}
+
+// createMCPListCommand creates the mcp list command
+func createMCPListCommand(projectDir *string) *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show available, configured and imported MCP servers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withInstalledMCPManager(mcpProjectDir(*projectDir), func(manager *installer.MCPManager) error {
+				return listMCPServers(manager, asJSON)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the servers and their metadata as JSON")
+	return cmd
+}
+
+// createMCPRemoveCommand creates the mcp remove command
+func createMCPRemoveCommand(projectDir *string) *cobra.Command {
+	var clients []string
+	cmd := &cobra.Command{
+		Use:     "remove <name...>",
+		Aliases: []string{"rm"},
+		Short:   "Remove MCP servers from .mcp.json and .superclaude",
+		Args:    mcpNameArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := installer.ParseMCPTargets(clients)
+			if err != nil {
+				return err
+			}
+			return withInstalledMCPManager(mcpProjectDir(*projectDir), func(manager *installer.MCPManager) error {
+				manager.Targets = targets
+				removed, err := manager.Remove(args)
+				if err != nil {
+					return err
+				}
+				for _, key := range removed {
+					fmt.Printf("🗑️  Removed %s from .mcp.json\n", key)
+				}
+				fmt.Printf("Updated MCP imports in .superclaude/CLAUDE.md\n")
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringSliceVar(&clients, "mcp-target", nil,
+		"Also remove the servers from these clients' configs: "+strings.Join(installer.MCPTargetNames(), ", "))
+	return cmd
+}
+
+// createMCPUpdateCommand creates the mcp update command
+func createMCPUpdateCommand(projectDir *string) *cobra.Command {
+	var versionsFile string
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Bump pinned MCP package versions in .mcp.json",
+		Long: `Compare the pinned npx and uvx package versions in .mcp.json with the built-in
+versions, mcp-versions.json in the user config dir and .superclaude-lite, and
+--mcp-versions, then offer to bump the pins that are older. Newer pins, pins
+that can't be ordered such as commit SHAs, and unpinned servers are left alone.
+Checks every server when no names are given.`,
+		Args: mcpNameArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := filepath.Abs(mcpProjectDir(*projectDir))
+			if err != nil {
+				return fmt.Errorf("failed to resolve directory: %w", err)
+			}
+			versions, err := installer.LoadMCPVersions(dir, versionsFile)
+			if err != nil {
+				return err
+			}
+
+			bumped, err := installer.UpdateMCPPins(dir, args, versions)
+			if errors.Is(err, installer.ErrMCPPinBumpDeclined) {
+				fmt.Printf("Bump declined; MCP pins left unchanged\n")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if len(bumped) == 0 {
+				fmt.Printf("MCP pins are up to date\n")
+				return nil
+			}
+			for _, bump := range bumped {
+				fmt.Printf("📌 Bumped %s to %s\n", bump.Server, bump.Available)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&versionsFile, "mcp-versions", "", "JSON file with the package versions to pin to, overriding the built-in ones")
+	return cmd
+}
+
+// mcpNameArgs requires at least min server names, which may follow an optional --.
+// A path to an existing directory points at --path instead of failing as an unknown
+// server.
+func mcpNameArgs(minNames int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		for _, arg := range args {
+			looksLikePath := arg == "." || arg == ".." || strings.ContainsAny(arg, `/\`)
+			if info, err := os.Stat(arg); looksLikePath && err == nil && info.IsDir() {
+				return fmt.Errorf("%q is a directory, not a server name; pass the project directory with --path", arg)
+			}
+		}
+		if len(args) < minNames {
+			return fmt.Errorf("requires at least %d server name(s), got %d", minNames, len(args))
+		}
+		return nil
+	}
+}
+
+// mcpProjectDir returns the --path directory, defaulting to the current directory
+func mcpProjectDir(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+// withMCPManager clones the framework at the revision the project was installed from,
+// as recorded in its manifest, and runs fn with a manager for the project directory.
+// Only add needs the clone; list and remove use withInstalledMCPManager.
+func withMCPManager(targetDir string, fn func(*installer.MCPManager) error) error {
+	targetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	if err := git.ValidateGitInstalled(); err != nil {
+		return err
+	}
+
+	tempDir, err := git.GetTempCloneDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := git.CleanupTempDir(tempDir); err != nil {
+			fmt.Printf("Warning: failed to remove %s: %v\n", tempDir, err)
+		}
+	}()
+
+	// Docs and configs must come from the installed framework commit, even if init --ref named a branch
+	var ref string
+	manifest, err := installer.LoadInstallManifest(targetDir)
+	if err != nil {
+		fmt.Printf("Warning: using the default framework commit: %v\n", err)
+	} else if manifest != nil {
+		ref = manifest.FrameworkCommit
+	}
+	if err := git.CloneRepositoryAt(tempDir, ref); err != nil {
+		return err
+	}
+
+	manager, err := installer.NewMCPManager(targetDir, tempDir)
+	if err != nil {
+		return err
+	}
+
+	return fn(manager)
+}
+
+// withInstalledMCPManager runs fn with a manager that reads the servers from the
+// project's manifest and .superclaude/MCP docs, without cloning the framework
+func withInstalledMCPManager(targetDir string, fn func(*installer.MCPManager) error) error {
+	targetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	manager, err := installer.NewInstalledMCPManager(targetDir)
+	if err != nil {
+		return err
+	}
+
+	return fn(manager)
+}
+
+func listMCPServers(manager *installer.MCPManager, asJSON bool) error {
+	statuses, err := manager.List()
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if statuses == nil {
+			statuses = []installer.MCPServerStatus{}
+		}
+		data, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode MCP servers: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(statuses) == 0 {
+		fmt.Printf("No MCP servers available or configured\n")
+		return nil
+	}
+
+	mark := func(value bool) string {
+		if value {
+			return "✅"
+		}
+		return "➖"
+	}
+
+	fmt.Printf("%-20s %-10s %-11s %-9s %-10s %-10s %-16s %s\n", "Server", "Available", "Configured", "Imported", "Transport", "Source", "Runtime", "Description")
+	for _, status := range statuses {
+		fmt.Printf("%-20s %-10s %-11s %-9s %-10s %-10s %-16s %s\n", status.Name, mark(status.Available), mark(status.Configured), mark(status.Imported),
+			status.Transport, installer.MCPSourceLabel(status.Source), status.Runtime, status.Description)
+	}
+
+	return nil
+}
+
+func checkMCPServers(targetDir string, names []string, timeout time.Duration) error {
+	results, err := installer.CheckMCPServers(targetDir, names, timeout)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Printf("No MCP servers configured in .mcp.json\n")
+		return nil
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.OK() {
+			failed++
+			fmt.Printf("❌ %s (%s): %v\n", result.Server, result.Transport, result.Err)
+			for _, line := range strings.Split(result.Stderr, "\n") {
+				if line != "" {
+					fmt.Printf("   %s\n", line)
+				}
+			}
+			continue
+		}
+
+		server := result.ServerName
+		if result.ServerVersion != "" {
+			server += " " + result.ServerVersion
+		}
+		fmt.Printf("✅ %s (%s): %s, %d tools (%s)\n", result.Server, result.Transport, server, result.Tools, result.Duration.Round(time.Millisecond))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d MCP servers failed the check", failed, len(results))
+	}
+	return nil
+}
+
+func printMCPChanges(changes []installer.MCPServerChange) {
+	for _, change := range changes {
+		switch change.Action {
+		case installer.MCPServerAdded:
+			fmt.Printf("✅ Added %s to .mcp.json\n", change.Key)
+		case installer.MCPServerUnchanged:
+			fmt.Printf("✅ %s is already configured in .mcp.json\n", change.Key)
+		case installer.MCPServerKept:
+			fmt.Printf("➖ Kept existing %s in .mcp.json (use --mcp-conflict overwrite or rename to update it)\n", change.Key)
+		case installer.MCPServerReplaced:
+			fmt.Printf("🔄 Replaced %s in .mcp.json\n", change.Key)
+		case installer.MCPServerRenamed:
+			fmt.Printf("✅ Added %s to .mcp.json next to the existing %s\n", change.RenamedTo, change.Key)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "encoding/json"
+
 const (
 	// Repository information
 	RepoURL     = "https://github.com/SuperClaude-Org/SuperClaude_Framework.git"
@@ -7,10 +9,12 @@ const (
 	Branch      = "master"
 
 	// Directory names
-	SuperClaudeDir = ".superclaude"
-	ClaudeDir      = ".claude"
-	MCPConfigFile  = ".mcp.json"
-	CLAUDEFile     = "CLAUDE.md"
+	SuperClaudeDir  = ".superclaude"
+	ClaudeDir       = ".claude"
+	MCPConfigFile   = ".mcp.json"
+	CLAUDEFile      = "CLAUDE.md"
+	ClaudeLocalFile = "CLAUDE.local.md"
+	ManifestFile    = "manifest.json" // Install choices recorded inside .superclaude/
 
 	// Framework paths within the repository
 	CoreSourcePath     = "SuperClaude/Core"
@@ -18,40 +22,63 @@ const (
 	AgentsSourcePath   = "SuperClaude/Agents"
 	ModesSourcePath    = "SuperClaude/Modes"
 
+	// Tool configuration: <project>/.superclaude-lite and <user config dir>/super-claude-lite
+	ToolConfigDir     = ".superclaude-lite"
+	UserConfigDirName = "super-claude-lite"
+	TemplatesDir      = "templates"
+	SecretsFile       = "secrets.env"       // MCP secret values, gitignored inside ToolConfigDir
+	MCPRegistryFile   = "mcp-servers.json"  // Extra MCP servers, in ToolConfigDir or the user config dir
+	MCPVersionsFile   = "mcp-versions.json" // MCP package pins for --pin-mcp, in ToolConfigDir or the user config dir
+	InitProfileFile   = "init.json"         // Answers saved by init --wizard, in ToolConfigDir
+
 	// Backup directory prefix
 	BackupDirPrefix = ".superclaude-backup"
-)
 
-// SuperClaude import directive for CLAUDE.md
-const SuperClaudeImport = `## SuperClaude Instructions
+	// Maximum number of recursive @ import hops Claude Code follows from a memory file
+	MaxImportDepth = 5
 
-**Import SuperClaude Core, treat as if import is in the main CLAUDE.md file.**
-@./.superclaude/CLAUDE.md`
+	// Default token budget for the context loaded through CLAUDE.md imports
+	DefaultContextTokenBudget = 20000
+)
+
+// MCPCatalogEntry is a built-in MCP server, offered when the framework checkout has no
+// SuperClaude/MCP directory
+type MCPCatalogEntry struct {
+	Name        string          // Name accepted by --mcp, matching the framework's MCP_<Name>.md
+	Description string          // Shown in the MCP selector
+	Config      json.RawMessage // .mcp.json definition
+}
 
-// Default MCP servers to recommend
-var RecommendedMCPServers = map[string]interface{}{
-	"sequential-thinking": map[string]interface{}{
-		"command": "npx",
-		"args":    []string{"-y", "@modelcontextprotocol/server-sequential-thinking"},
+// RecommendedMCPServers is the built-in MCP catalog, keyed by .mcp.json server key
+var RecommendedMCPServers = map[string]MCPCatalogEntry{
+	"sequential-thinking": {
+		Name:        "Sequential",
+		Description: "Sequential Thinking - multi-step reasoning for complex analysis",
+		Config:      json.RawMessage(`{"command": "npx", "args": ["-y", "@modelcontextprotocol/server-sequential-thinking"]}`),
 	},
-	"context7": map[string]interface{}{
-		"command": "npx",
-		"args":    []string{"-y", "@upstash/context7-mcp@latest"},
+	"context7": {
+		Name:        "Context7",
+		Description: "Context7 - official library documentation lookup",
+		Config:      json.RawMessage(`{"command": "npx", "args": ["-y", "@upstash/context7-mcp@latest"]}`),
 	},
-	"serena": map[string]interface{}{
-		"command": "uvx",
-		"args":    []string{"--from", "git+https://github.com/oraios/serena", "serena", "start-mcp-server"},
+	"serena": {
+		Name:        "Serena",
+		Description: "Serena - semantic code understanding and project memory",
+		Config:      json.RawMessage(`{"command": "uvx", "args": ["--from", "git+https://github.com/oraios/serena", "serena", "start-mcp-server"]}`),
 	},
-	"playwright": map[string]interface{}{
-		"command": "npx",
-		"args":    []string{"@playwright/mcp@latest"},
+	"playwright": {
+		Name:        "Playwright",
+		Description: "Playwright - browser automation and E2E testing",
+		Config:      json.RawMessage(`{"command": "npx", "args": ["@playwright/mcp@latest"]}`),
 	},
-	"magic": map[string]interface{}{
-		"command": "npx",
-		"args":    []string{"-y", "@21st-dev/magic-mcp@latest"},
+	"magic": {
+		Name:        "Magic",
+		Description: "Magic - UI components from 21st.dev (needs TWENTYFIRST_API_KEY)",
+		Config:      json.RawMessage(`{"command": "npx", "args": ["-y", "@21st-dev/magic-mcp@latest"], "env": {"TWENTYFIRST_API_KEY": ""}}`),
 	},
-	"morphllm": map[string]interface{}{
-		"command": "npx",
-		"args":    []string{"-y", "@morphllm/mcp@latest"},
+	"morphllm": {
+		Name:        "Morphllm",
+		Description: "Morphllm - pattern-based bulk code edits (needs MORPH_API_KEY)",
+		Config:      json.RawMessage(`{"command": "npx", "args": ["-y", "@morphllm/mcp@latest"], "env": {"MORPH_API_KEY": ""}}`),
 	},
 }
@@ -5,28 +5,53 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/dgnsrekt/super-claude-lite/internal/config"
 )
 
 // CloneRepository clones the SuperClaude repository to a temporary directory at the fixed commit
 func CloneRepository(tempDir string) error {
+	return CloneRepositoryAt(tempDir, "")
+}
+
+// CloneRepositoryAt clones the SuperClaude repository and checks out ref, a branch,
+// tag or commit; an empty ref checks out the fixed commit
+func CloneRepositoryAt(tempDir, ref string) error {
+	if ref == "" {
+		ref = config.FixedCommit
+	}
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid framework ref %q", ref)
+	}
+
 	// Clone the repository
 	cloneCmd := exec.Command("git", "clone", config.RepoURL, tempDir)
 	if err := cloneCmd.Run(); err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
 
-	// Change to the cloned directory and checkout the specific commit
-	checkoutCmd := exec.Command("git", "checkout", config.FixedCommit)
+	// Change to the cloned directory and checkout the requested ref
+	checkoutCmd := exec.Command("git", "checkout", ref) // #nosec G204 -- ref is a single argument that cannot be a flag
 	checkoutCmd.Dir = tempDir
 	if err := checkoutCmd.Run(); err != nil {
-		return fmt.Errorf("failed to checkout commit %s: %w", config.FixedCommit, err)
+		return fmt.Errorf("failed to checkout %s: %w", ref, err)
 	}
 
 	return nil
 }
 
+// HeadCommit returns the commit checked out in repoDir
+func HeadCommit(repoDir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the checked out commit: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // ValidateGitInstalled checks if git is available on the system
 func ValidateGitInstalled() error {
 	_, err := exec.LookPath("git")
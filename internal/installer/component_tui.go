@@ -0,0 +1,199 @@
+package installer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ComponentSelectorModel represents the TUI state for core file and mode selection
+type ComponentSelectorModel struct {
+	components []Component
+	cursor     int
+	selected   map[int]bool
+	quitting   bool
+	confirmed  bool
+}
+
+// NewComponentSelector creates a new component selector TUI model with the
+// preselected components already checked
+func NewComponentSelector(components, preselected []Component) ComponentSelectorModel {
+	selected := make(map[int]bool)
+	for i, component := range components {
+		for _, pre := range preselected {
+			if pre.Name == component.Name && pre.Kind == component.Kind {
+				selected[i] = true
+				break
+			}
+		}
+	}
+
+	return ComponentSelectorModel{
+		components: components,
+		cursor:     0,
+		selected:   selected,
+		quitting:   false,
+		confirmed:  false,
+	}
+}
+
+func (m ComponentSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ComponentSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, keys.quit):
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, keys.down):
+			if m.cursor < len(m.components)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, keys.space):
+			// Toggle selection
+			m.selected[m.cursor] = !m.selected[m.cursor]
+
+		case key.Matches(msg, keys.enter):
+			// Confirm selections
+			m.confirmed = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m ComponentSelectorModel) View() string {
+	if m.quitting {
+		return "\nCancelled component selection.\n"
+	}
+
+	if m.confirmed {
+		return fmt.Sprintf("\nSelected %d components for .superclaude/CLAUDE.md.\n", len(m.GetSelectedComponents()))
+	}
+
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render("Select Core Files and Modes"))
+	b.WriteString("\n\n")
+
+	// Instructions
+	b.WriteString(helpStyle.Render("Use ↑/↓ to navigate, space to select/deselect, enter to confirm"))
+	b.WriteString("\n\n")
+
+	// Component list, grouped by kind
+	var lastKind ComponentKind
+	for i, component := range m.components {
+		if component.Kind != lastKind {
+			if lastKind != "" {
+				b.WriteString("\n")
+			}
+			heading := "Core files"
+			if component.Kind == ComponentMode {
+				heading = "Modes"
+			}
+			b.WriteString(helpStyle.Render(heading))
+			b.WriteString("\n")
+			lastKind = component.Kind
+		}
+
+		checkbox := checkboxUnchecked
+		if m.selected[i] {
+			checkbox = checkboxChecked
+		}
+
+		style := unselectedStyle
+		if i == m.cursor {
+			style = selectedStyle
+		}
+
+		line := fmt.Sprintf("%s %s", checkbox, component.File)
+		if component.Description != "" {
+			line += " - " + component.Description
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	// Footer help
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press 'q' to quit, 'enter' to proceed with selection"))
+
+	return b.String()
+}
+
+// GetSelectedComponents returns the checked components in display order
+func (m ComponentSelectorModel) GetSelectedComponents() []Component {
+	var selected []Component
+	for i, component := range m.components {
+		if m.selected[i] {
+			selected = append(selected, component)
+		}
+	}
+	return selected
+}
+
+// ShowComponentSelector displays the TUI and returns the selected components, or asks
+// with a numbered list without a capable terminal
+func ShowComponentSelector(components, preselected []Component) ([]Component, error) {
+	if !useTUI() {
+		return selectComponentsByNumber(components, preselected)
+	}
+
+	model := NewComponentSelector(components, preselected)
+
+	program := tea.NewProgram(model)
+	finalModel, err := program.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run component selector: %w", err)
+	}
+
+	// Extract results from final model
+	final := finalModel.(ComponentSelectorModel)
+
+	if final.quitting {
+		return nil, fmt.Errorf("user cancelled component selection")
+	}
+
+	return final.GetSelectedComponents(), nil
+}
+
+// selectComponentsByNumber asks for the components with the line-based checklist
+func selectComponentsByNumber(components, preselected []Component) ([]Component, error) {
+	selector := NewComponentSelector(components, preselected)
+	items := make([]string, len(components))
+	checked := make([]bool, len(components))
+	for i, component := range components {
+		items[i] = fmt.Sprintf("%s (%s)", component.File, component.Kind)
+		if component.Description != "" {
+			items[i] += " - " + component.Description
+		}
+		checked[i] = selector.selected[i]
+	}
+
+	chosen, err := promptMultiSelect("Select core files and modes:", items, checked,
+		"pass --core and --modes instead of --select-components")
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Component
+	for i, component := range components {
+		if chosen[i] {
+			selected = append(selected, component)
+		}
+	}
+	return selected, nil
+}
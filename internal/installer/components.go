@@ -0,0 +1,216 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// ComponentKind distinguishes the framework files imported by .superclaude/CLAUDE.md
+type ComponentKind string
+
+const (
+	// ComponentCore is a core file such as FLAGS.md, imported from the .superclaude root
+	ComponentCore ComponentKind = "core"
+	// ComponentMode is a behavioral mode such as MODE_Brainstorming.md, imported from Modes/
+	ComponentMode ComponentKind = "mode"
+)
+
+// Component is a selectable framework file imported by .superclaude/CLAUDE.md
+type Component struct {
+	Name        string        `json:"name"`        // e.g., "task_management"
+	Kind        ComponentKind `json:"kind"`        // core or mode
+	File        string        `json:"file"`        // e.g., "MODE_Task_Management.md"
+	Description string        `json:"description"` // e.g., "Task orchestration mode"
+}
+
+// ImportPath returns the @ path relative to .superclaude/CLAUDE.md
+func (c Component) ImportPath() string {
+	if c.Kind == ComponentMode {
+		return "Modes/" + c.File
+	}
+	return c.File
+}
+
+// Known components in the order the framework expects them, with their descriptions
+var knownComponents = []Component{
+	{Name: "flags", Kind: ComponentCore, Description: "Flag definitions and triggers"},
+	{Name: "rules", Kind: ComponentCore, Description: "Core behavioral rules"},
+	{Name: "principles", Kind: ComponentCore, Description: "Guiding principles"},
+	{Name: "brainstorming", Kind: ComponentMode, Description: "Collaborative discovery mode"},
+	{Name: "introspection", Kind: ComponentMode, Description: "Transparent reasoning mode"},
+	{Name: "task_management", Kind: ComponentMode, Description: "Task orchestration mode"},
+	{Name: "orchestration", Kind: ComponentMode, Description: "Tool coordination mode"},
+	{Name: "token_efficiency", Kind: ComponentMode, Description: "Compressed communication mode"},
+}
+
+// ComponentSelection holds the requested core files and modes for .superclaude/CLAUDE.md.
+// Empty include lists mean "keep the recorded selection, or everything on first install",
+// and "none" selects nothing.
+type ComponentSelection struct {
+	Core        []string
+	ExcludeCore []string
+	Modes       []string
+	ExcludeMode []string
+	Interactive bool // Show the component picker
+}
+
+// DiscoverCoreFiles returns the core files available in the cloned repository
+func DiscoverCoreFiles(repoPath string) ([]Component, error) {
+	return discoverComponents(filepath.Join(repoPath, config.CoreSourcePath), ComponentCore)
+}
+
+// DiscoverModes returns the MODE_*.md files available in the cloned repository
+func DiscoverModes(repoPath string) ([]Component, error) {
+	return discoverComponents(filepath.Join(repoPath, config.ModesSourcePath), ComponentMode)
+}
+
+func discoverComponents(dir string, kind ComponentKind) ([]Component, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", kind, err)
+	}
+
+	var components []Component
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".md") {
+			continue
+		}
+
+		// The installer generates .superclaude/CLAUDE.md itself
+		if kind == ComponentCore && fileName == config.CLAUDEFile {
+			continue
+		}
+		if kind == ComponentMode && !strings.HasPrefix(fileName, "MODE_") {
+			continue
+		}
+
+		component := Component{
+			Name: normalizeComponentName(fileName),
+			Kind: kind,
+			File: fileName,
+		}
+		if known, ok := findKnownComponent(component.Name, kind); ok {
+			component.Description = known.Description
+		}
+
+		components = append(components, component)
+	}
+
+	sortComponents(components)
+	return components, nil
+}
+
+// normalizeComponentName maps user input and file names to a canonical component name:
+// "MODE_Task_Management.md", "task-management" and "Task_Management" become "task_management"
+func normalizeComponentName(value string) string {
+	name := strings.ToLower(strings.TrimSpace(value))
+	name = strings.TrimSuffix(name, ".md")
+	name = strings.TrimPrefix(name, "mode_")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func findKnownComponent(name string, kind ComponentKind) (Component, bool) {
+	for _, known := range knownComponents {
+		if known.Name == name && known.Kind == kind {
+			return known, true
+		}
+	}
+	return Component{}, false
+}
+
+// sortComponents orders known components as the framework lists them, then the rest by name
+func sortComponents(components []Component) {
+	rank := func(c Component) int {
+		for i, known := range knownComponents {
+			if known.Name == c.Name && known.Kind == c.Kind {
+				return i
+			}
+		}
+		return len(knownComponents)
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		ri, rj := rank(components[i]), rank(components[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return components[i].Name < components[j].Name
+	})
+}
+
+// resolveComponents applies include/exclude lists to the available components. With no
+// include list the previously recorded selection is kept, or everything is selected;
+// an include list of "none" selects nothing.
+func resolveComponents(available []Component, include, exclude, previous []string, kind ComponentKind) ([]Component, error) {
+	byName := make(map[string]Component, len(available))
+	for _, component := range available {
+		byName[component.Name] = component
+	}
+
+	selected := make(map[string]bool)
+	switch {
+	case isNoneSelection(include):
+		// Nothing selected
+	case len(include) > 0:
+		for _, name := range include {
+			component, ok := byName[normalizeComponentName(name)]
+			if !ok {
+				return nil, unknownComponentError(name, kind, available)
+			}
+			selected[component.Name] = true
+		}
+	case previous != nil:
+		// Recorded components that no longer exist upstream are dropped silently
+		for _, name := range previous {
+			selected[normalizeComponentName(name)] = true
+		}
+	default:
+		for _, component := range available {
+			selected[component.Name] = true
+		}
+	}
+
+	for _, name := range exclude {
+		component, ok := byName[normalizeComponentName(name)]
+		if !ok {
+			return nil, unknownComponentError(name, kind, available)
+		}
+		delete(selected, component.Name)
+	}
+
+	var result []Component
+	for _, component := range available {
+		if selected[component.Name] {
+			result = append(result, component)
+		}
+	}
+	return result, nil
+}
+
+// isNoneSelection reports whether a list of names is the single word "none"
+func isNoneSelection(names []string) bool {
+	return len(names) == 1 && strings.EqualFold(strings.TrimSpace(names[0]), "none")
+}
+
+func unknownComponentError(name string, kind ComponentKind, available []Component) error {
+	names := make([]string, 0, len(available))
+	for _, component := range available {
+		names = append(names, component.Name)
+	}
+	return fmt.Errorf("unknown %s %q (available: %s)", kind, name, strings.Join(names, ", "))
+}
+
+// componentNames returns the canonical names of the given components
+func componentNames(components []Component) []string {
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+	return names
+}
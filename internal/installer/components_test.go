@@ -0,0 +1,203 @@
+package installer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/git"
+)
+
+// createTestFrameworkRepo builds a minimal SuperClaude repository layout for tests
+func createTestFrameworkRepo(t *testing.T) string {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"SuperClaude/Core/FLAGS.md":                  "# Flags\n",
+		"SuperClaude/Core/RULES.md":                  "# Rules\n",
+		"SuperClaude/Core/PRINCIPLES.md":             "# Principles\n",
+		"SuperClaude/Commands/help.md":               "# Help\n",
+		"SuperClaude/Modes/MODE_Brainstorming.md":    "# Brainstorming\n",
+		"SuperClaude/Modes/MODE_Introspection.md":    "# Introspection\n",
+		"SuperClaude/Modes/MODE_Task_Management.md":  "# Task Management\n",
+		"SuperClaude/Modes/MODE_Orchestration.md":    "# Orchestration\n",
+		"SuperClaude/Modes/MODE_Token_Efficiency.md": "# Token Efficiency\n",
+		"SuperClaude/MCP/MCP_Context7.md":            "# Context7\n",
+		"SuperClaude/MCP/configs/context7.json":      `{"context7": {"command": "npx", "args": ["-y", "@upstash/context7-mcp@latest"]}}`,
+		"SuperClaude/MCP/MCP_Serena.md":              "# Serena\n",
+		"SuperClaude/MCP/configs/serena.json":        `{"serena": {"command": "uvx", "args": ["--from", "git+https://github.com/oraios/serena", "serena", "start-mcp-server"]}}`,
+	}
+
+	writeTestFiles(t, repoPath, files)
+
+	return repoPath
+}
+
+// TestResolveComponents validates include, exclude and recorded selections
+func TestResolveComponents(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	modes, err := DiscoverModes(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverModes failed: %v", err)
+	}
+
+	expectedOrder := []string{"brainstorming", "introspection", "task_management", "orchestration", "token_efficiency"}
+	if got := componentNames(modes); !reflect.DeepEqual(got, expectedOrder) {
+		t.Fatalf("Expected modes %v, got %v", expectedOrder, got)
+	}
+
+	testCases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		previous []string
+		expected []string
+	}{
+		{"default_all", nil, nil, nil, expectedOrder},
+		{"include_normalized", []string{"Task-Management", "MODE_Brainstorming.md"}, nil, nil, []string{"brainstorming", "task_management"}},
+		{"exclude", nil, []string{"token_efficiency"}, nil, expectedOrder[:4]},
+		{"previous_kept", nil, nil, []string{"introspection"}, []string{"introspection"}},
+		{"previous_empty_kept", nil, nil, []string{}, []string{}},
+		{"include_overrides_previous", []string{"orchestration"}, nil, []string{"introspection"}, []string{"orchestration"}},
+		{"include_none", []string{"none"}, nil, []string{"introspection"}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := resolveComponents(modes, tc.include, tc.exclude, tc.previous, ComponentMode)
+			if err != nil {
+				t.Fatalf("resolveComponents failed: %v", err)
+			}
+			if got := componentNames(resolved); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	_, err = resolveComponents(modes, []string{"telepathy"}, nil, nil, ComponentMode)
+	if err == nil || !strings.Contains(err.Error(), "brainstorming") {
+		t.Errorf("Expected unknown mode error listing available modes, got: %v", err)
+	}
+}
+
+// TestCopyCoreFilesSelection validates generation of .superclaude/CLAUDE.md and the recorded selection
+func TestCopyCoreFilesSelection(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	targetDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(targetDir, ".superclaude"), 0o755); err != nil {
+		t.Fatalf("Failed to create .superclaude: %v", err)
+	}
+
+	config := &InstallConfig{
+		NoBackup: true,
+		Components: ComponentSelection{
+			Modes:       []string{"brainstorming", "task_management"},
+			ExcludeCore: []string{"principles"},
+		},
+	}
+	ctx, err := NewInstallContext(targetDir, config)
+	if err != nil {
+		t.Fatalf("Failed to create install context: %v", err)
+	}
+	ctx.RepoPath = repoPath
+
+	if err := copyCoreFiles(ctx); err != nil {
+		t.Fatalf("copyCoreFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, ".superclaude", "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("Failed to read generated CLAUDE.md: %v", err)
+	}
+	content := string(data)
+
+	for _, expected := range []string{"@FLAGS.md", "@RULES.md", "@Modes/MODE_Brainstorming.md", "@Modes/MODE_Task_Management.md"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected generated CLAUDE.md to import %s:\n%s", expected, content)
+		}
+	}
+	for _, unexpected := range []string{"@PRINCIPLES.md", "@Modes/MODE_Introspection.md"} {
+		if strings.Contains(content, unexpected) {
+			t.Errorf("Expected generated CLAUDE.md not to import %s:\n%s", unexpected, content)
+		}
+	}
+
+	// The manifest records the selection for later runs
+	if err := saveInstallManifest(ctx); err != nil {
+		t.Fatalf("saveInstallManifest failed: %v", err)
+	}
+	manifest, err := LoadInstallManifest(targetDir)
+	if err != nil || manifest == nil {
+		t.Fatalf("Failed to load install manifest: %v", err)
+	}
+	if !reflect.DeepEqual(manifest.Modes, []string{"brainstorming", "task_management"}) {
+		t.Errorf("Unexpected recorded modes: %v", manifest.Modes)
+	}
+
+	// A re-run without flags keeps the recorded selection
+	rerun, err := NewInstallContext(targetDir, &InstallConfig{NoBackup: true})
+	if err != nil {
+		t.Fatalf("Failed to create install context: %v", err)
+	}
+	if err := rerun.ScanExistingFiles(); err != nil {
+		t.Fatalf("Failed to scan existing files: %v", err)
+	}
+	rerun.RepoPath = repoPath
+	if err := copyCoreFiles(rerun); err != nil {
+		t.Fatalf("copyCoreFiles re-run failed: %v", err)
+	}
+	if got := componentNames(rerun.SelectedModes); !reflect.DeepEqual(got, manifest.Modes) {
+		t.Errorf("Expected re-run to keep modes %v, got %v", manifest.Modes, got)
+	}
+	if got := componentNames(rerun.SelectedCore); !reflect.DeepEqual(got, []string{"flags", "rules"}) {
+		t.Errorf("Expected re-run to keep core files [flags rules], got %v", got)
+	}
+}
+
+// TestFrameworkCommitRecorded validates that the manifest records the commit a branch
+// pointed at during installation, not the branch name
+func TestFrameworkCommitRecorded(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "framework"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	head, err := git.HeadCommit(repoPath)
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+
+	targetDir := t.TempDir()
+	ctx, err := NewInstallContext(targetDir, &InstallConfig{FrameworkDir: repoPath, FrameworkRef: "main", NoBackup: true})
+	if err != nil {
+		t.Fatalf("Failed to create install context: %v", err)
+	}
+	if err := cloneRepository(ctx); err != nil {
+		t.Fatalf("cloneRepository failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(targetDir, ".superclaude"), 0o755); err != nil {
+		t.Fatalf("Failed to create .superclaude: %v", err)
+	}
+	if err := saveInstallManifest(ctx); err != nil {
+		t.Fatalf("saveInstallManifest failed: %v", err)
+	}
+
+	manifest, err := LoadInstallManifest(targetDir)
+	if err != nil || manifest == nil {
+		t.Fatalf("Failed to load install manifest: %v", err)
+	}
+	if manifest.FrameworkCommit != head || manifest.FrameworkRef != "main" {
+		t.Errorf("Expected commit %s for ref main, got %q for %q", head, manifest.FrameworkCommit, manifest.FrameworkRef)
+	}
+}
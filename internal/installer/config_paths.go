@@ -0,0 +1,26 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// ProjectConfigDir returns the project-level tool configuration directory
+// (<project>/.superclaude-lite). Unlike .superclaude it is owned by the user and is
+// never removed by clean.
+func ProjectConfigDir(targetDir string) string {
+	return filepath.Join(targetDir, config.ToolConfigDir)
+}
+
+// UserConfigDir returns the user-level tool configuration directory, e.g.
+// ~/.config/super-claude-lite on Linux
+func UserConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(dir, config.UserConfigDirName), nil
+}
@@ -0,0 +1,482 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/git"
+)
+
+// ConflictAction is how an existing user file that the installation would change is
+// resolved under --interactive
+type ConflictAction string
+
+const (
+	// ConflictMerge adds SuperClaude's content and keeps the user's
+	ConflictMerge ConflictAction = "merge"
+	// ConflictOverwrite replaces the existing file with SuperClaude's
+	ConflictOverwrite ConflictAction = "overwrite"
+	// ConflictSkip leaves the existing file unchanged
+	ConflictSkip ConflictAction = "skip"
+	// ConflictAbort stops the installation
+	ConflictAbort ConflictAction = "abort"
+)
+
+// ConflictDecision records what was chosen for one existing file
+type ConflictDecision struct {
+	Path   string // Relative to the target directory
+	Action ConflictAction
+}
+
+// describe formats the decision for the installation summary
+func (d ConflictDecision) describe() string {
+	switch d.Action {
+	case ConflictMerge:
+		return fmt.Sprintf("%s: merged", d.Path)
+	case ConflictOverwrite:
+		return fmt.Sprintf("%s: overwritten", d.Path)
+	default:
+		return fmt.Sprintf("%s: skipped, left unchanged", d.Path)
+	}
+}
+
+// conflictOption is one way to resolve a conflict, as offered in the prompt
+type conflictOption struct {
+	Action      ConflictAction
+	Description string
+}
+
+// errInstallAborted is returned when abort is chosen for a conflict
+var errInstallAborted = errors.New("installation aborted")
+
+// conflictListLimit caps the files listed for a directory conflict
+const conflictListLimit = 20
+
+// promptConflict shows the diff for an existing file and asks how to resolve it; the
+// first option is the default and the answer with --yes. Overridden in tests.
+var promptConflict = func(relPath, diff string, options []conflictOption) (ConflictAction, error) {
+	if promptOptions.AssumeYes {
+		return options[0].Action, nil
+	}
+	question := fmt.Sprintf("How should %s be resolved?", relPath)
+	hint := "run without --interactive, or pass --yes to take the default"
+	if currentPromptMode() == promptNone {
+		return "", promptUnavailable(question, hint)
+	}
+
+	fmt.Fprintf(promptOutput, "\n%s already exists; SuperClaude would change it:\n%s", relPath, diff)
+
+	descriptions := make([]string, len(options))
+	for i, option := range options {
+		descriptions[i] = fmt.Sprintf("%s (%s)", option.Action, option.Description)
+	}
+	choice, err := promptChoice(question, descriptions, hint)
+	if err != nil {
+		return "", err
+	}
+	return options[choice].Action, nil
+}
+
+// resolveConflict decides what happens to an existing file the step would change as
+// diff shows. Without --interactive, or when nothing would change, it returns the
+// first option, which is the behavior without the flag; otherwise it asks, offering
+// abort as well, and records the choice for the summary.
+func (ctx *InstallContext) resolveConflict(relPath, diff string, options ...conflictOption) (ConflictAction, error) {
+	if !ctx.Config.Interactive || diff == "" {
+		return options[0].Action, nil
+	}
+
+	options = append(options, conflictOption{ConflictAbort, "stop the installation"})
+	resume := ctx.pauseProgress.pause()
+	action, err := promptConflict(relPath, diff, options)
+	resume()
+	if err != nil {
+		return "", err
+	}
+	if action == ConflictAbort {
+		return "", fmt.Errorf("%w at %s", errInstallAborted, relPath)
+	}
+
+	ctx.ConflictDecisions = append(ctx.ConflictDecisions, ConflictDecision{Path: relPath, Action: action})
+	return action, nil
+}
+
+// resolveImportFileConflict asks about adding the import to an existing memory file
+func (ctx *InstallContext) resolveImportFileConflict(path string, location ImportLocation, importBlock string) (ConflictAction, error) {
+	if !ctx.Config.Interactive {
+		return ConflictMerge, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read existing %s: %w", location.RelPath(), err)
+	}
+
+	var diff string
+	if !location.HasSuperClaudeImport(string(content)) {
+		diff = diffLines(string(content), mergedCLAUDEmd(string(content), importBlock))
+	}
+
+	return ctx.resolveConflict(location.RelPath(), diff,
+		conflictOption{ConflictMerge, "add the SuperClaude import, shown above"},
+		conflictOption{ConflictOverwrite, "replace it with the SuperClaude template"},
+		conflictOption{ConflictSkip, "leave it unchanged, without the import"})
+}
+
+// resolveMCPConfigConflict asks about adding the selected servers to an existing .mcp.json
+func (ctx *InstallContext) resolveMCPConfigConflict(mcpPath string, selected []MCPServer) (ConflictAction, error) {
+	if !ctx.Config.Interactive {
+		return ConflictMerge, nil
+	}
+
+	diff, err := diffMCPConfig(mcpPath, selected, ctx.RepoPath)
+	if err != nil {
+		return "", err
+	}
+
+	return ctx.resolveConflict(config.MCPConfigFile, diff,
+		conflictOption{ConflictMerge, "add the servers; differing entries follow --mcp-conflict"},
+		conflictOption{ConflictOverwrite, "replace it with only the selected servers"},
+		conflictOption{ConflictSkip, "leave it unchanged"})
+}
+
+// resolveSuperClaudeDir asks about an existing .superclaude directory before the first
+// step writes to it. Overwriting empties the directory right away.
+func (ctx *InstallContext) resolveSuperClaudeDir() error {
+	if !ctx.Config.Interactive || !ctx.ExistingFiles.SuperClaudeDir || ctx.superClaudeAction != "" {
+		return nil
+	}
+
+	superClaudeDir := filepath.Join(ctx.TargetDir, config.SuperClaudeDir)
+	diff, err := diffSuperClaudeDir(ctx.RepoPath, superClaudeDir)
+	if err != nil {
+		return err
+	}
+
+	action, err := ctx.resolveConflict(config.SuperClaudeDir+"/", diff,
+		conflictOption{ConflictMerge, "update the framework files, keep files you added"},
+		conflictOption{ConflictOverwrite, "replace the directory with the framework files"},
+		conflictOption{ConflictSkip, "leave it unchanged"})
+	if err != nil {
+		return err
+	}
+	ctx.superClaudeAction = action
+
+	if action != ConflictOverwrite {
+		return nil
+	}
+	if err := os.RemoveAll(superClaudeDir); err != nil {
+		return fmt.Errorf("failed to remove existing %s: %w", config.SuperClaudeDir, err)
+	}
+	return os.MkdirAll(filepath.Join(superClaudeDir, "Commands"), 0o750)
+}
+
+// skipSuperClaudeDir reports whether the existing .superclaude directory is left unchanged
+func (ctx *InstallContext) skipSuperClaudeDir() bool {
+	return ctx.superClaudeAction == ConflictSkip
+}
+
+// resolveLinkConflict asks about what is at the path of a framework symlink, unless it
+// already links to relPath. Under --interactive a directory defaults to skip so --yes
+// never deletes it; without the flag the step replaces the path as it always has.
+func (ctx *InstallContext) resolveLinkConflict(targetPath, relPath string, info os.FileInfo) (ConflictAction, error) {
+	existing := "file"
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(targetPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read existing symlink: %w", err)
+		}
+		existing = "symlink to " + link
+	case info.IsDir():
+		entries, err := os.ReadDir(targetPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read existing directory: %w", err)
+		}
+		existing = fmt.Sprintf("directory with %d entries", len(entries))
+	}
+
+	var diff string
+	if existing != "symlink to "+relPath {
+		diff = fmt.Sprintf("    - %s\n    + symlink to %s\n", existing, relPath)
+	}
+
+	overwrite := conflictOption{ConflictOverwrite, "replace it with the symlink"}
+	skip := conflictOption{ConflictSkip, "leave it unchanged"}
+	if info.IsDir() && ctx.Config.Interactive {
+		overwrite.Description = "delete it and create the symlink"
+		return ctx.resolveConflict(relativeToTarget(ctx.TargetDir, targetPath), diff, skip, overwrite)
+	}
+	return ctx.resolveConflict(relativeToTarget(ctx.TargetDir, targetPath), diff, overwrite, skip)
+}
+
+// diffMCPConfig lists the entries of the selected servers that merging would add to an
+// existing .mcp.json or finds different there, and the entries overwriting would drop
+func diffMCPConfig(mcpPath string, selected []MCPServer, repoPath string) (string, error) {
+	data, err := os.ReadFile(mcpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read existing %s: %w", config.MCPConfigFile, err)
+	}
+	doc, problems := parseMCPDocument(data, config.MCPConfigFile)
+	if problems != nil {
+		return "", fmt.Errorf("failed to parse existing %s: %w", config.MCPConfigFile, problems)
+	}
+
+	servers := make(map[string]interface{})
+	if raw, ok := doc.Raw(claudeCodeClient.ServersKey); ok {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return "", fmt.Errorf("failed to parse %s in %s: %w", claudeCodeClient.ServersKey, config.MCPConfigFile, err)
+		}
+	}
+
+	var b strings.Builder
+	incomingKeys := make(map[string]bool)
+	for _, server := range selected {
+		entries, err := server.LoadConfigRaw(repoPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to load MCP config for %s: %w", server.Name, err)
+		}
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			raw, _, err := referenceMCPEnv(key, entries[key], readMCPDoc(repoPath, server))
+			if err != nil {
+				return "", err
+			}
+			var incoming interface{}
+			if err := json.Unmarshal(raw, &incoming); err != nil {
+				return "", fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+			}
+			incomingKeys[key] = true
+
+			existing, exists := servers[key]
+			if !exists {
+				fmt.Fprintf(&b, "    + %s\n", key)
+			} else if diff := diffMCPServer(existing, incoming); len(diff) > 0 {
+				fmt.Fprintf(&b, "    ~ %s\n%s", key, formatMCPDiff(diff))
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "", nil
+	}
+
+	var dropped []string
+	for key := range servers {
+		if !incomingKeys[key] {
+			dropped = append(dropped, key)
+		}
+	}
+	if len(dropped) > 0 {
+		sort.Strings(dropped)
+		fmt.Fprintf(&b, "    Overwriting would also drop: %s\n", strings.Join(dropped, ", "))
+	}
+	return b.String(), nil
+}
+
+// diffSuperClaudeDir lists the framework files that are new or differ in an existing
+// .superclaude directory, and, when there are any, the files found only there. Files
+// the installer generates (CLAUDE.md, the manifest and MCP docs) aren't compared.
+func diffSuperClaudeDir(repoPath, superClaudeDir string) (string, error) {
+	corePath, commandsPath := git.GetSourcePaths(repoPath)
+	sources := []struct{ src, dst string }{
+		{corePath, ""},
+		{commandsPath, "Commands"},
+		{filepath.Join(repoPath, config.AgentsSourcePath), "Agents"},
+		{filepath.Join(repoPath, config.ModesSourcePath), "Modes"},
+	}
+
+	var changes []string
+	framework := make(map[string]bool)
+	for _, source := range sources {
+		err := filepath.Walk(source.src, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(source.src, path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(filepath.Join(source.dst, rel))
+			framework[rel] = true
+
+			existing, err := os.ReadFile(filepath.Join(superClaudeDir, rel))
+			if os.IsNotExist(err) {
+				changes = append(changes, fmt.Sprintf("    + %s (new)", rel))
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			incoming, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if string(existing) != string(incoming) {
+				changes = append(changes, fmt.Sprintf("    ~ %s (changed)", rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to compare %s: %w", config.SuperClaudeDir, err)
+		}
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	err := filepath.Walk(superClaudeDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(superClaudeDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			if rel == "MCP" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !framework[rel] && rel != config.CLAUDEFile && rel != config.ManifestFile {
+			changes = append(changes, fmt.Sprintf("    ? %s (not from SuperClaude; merge keeps it)", rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compare %s: %w", config.SuperClaudeDir, err)
+	}
+
+	if len(changes) > conflictListLimit {
+		more := len(changes) - conflictListLimit
+		changes = append(changes[:conflictListLimit], fmt.Sprintf("    ... and %d more", more))
+	}
+	return strings.Join(changes, "\n") + "\n", nil
+}
+
+// diffContextLines is how many unchanged lines are shown around each change
+const diffContextLines = 2
+
+// maxDiffCells caps the LCS table diffLines builds for the lines between the common
+// prefix and suffix; larger changes are summarized as a line count instead
+const maxDiffCells = 1 << 20
+
+// diffLine is one line of a diffLines result: ' ' unchanged, - removed, + added or
+// ~ for a summary of lines too many to compare
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines renders a line diff from existing to proposed, with - for removed and +
+// for added lines, shortening unchanged runs to the lines around each change
+func diffLines(existing, proposed string) string {
+	if existing == proposed {
+		return ""
+	}
+	a := strings.Split(strings.TrimSuffix(existing, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(proposed, "\n"), "\n")
+
+	// Only the lines between the common prefix and suffix need comparing
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+
+	shown := make([]bool, len(lines))
+	for k, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		for n := max(0, k-diffContextLines); n <= min(len(lines)-1, k+diffContextLines); n++ {
+			shown[n] = true
+		}
+	}
+
+	var out strings.Builder
+	elided := false
+	for k, line := range lines {
+		if !shown[k] {
+			if !elided {
+				out.WriteString("      ...\n")
+				elided = true
+			}
+			continue
+		}
+		elided = false
+		out.WriteString(strings.TrimRight(fmt.Sprintf("    %c %s", line.op, line.text), " ") + "\n")
+	}
+	return out.String()
+}
+
+// diffMiddle diffs the lines that differ between the common prefix and suffix by their
+// longest common subsequence, or summarizes them when the table would exceed maxDiffCells
+func diffMiddle(a, b []string) []diffLine {
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		return []diffLine{{'~', fmt.Sprintf("%d existing and %d proposed lines differ, too many to compare", len(a), len(b))}}
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
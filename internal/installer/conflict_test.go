@@ -0,0 +1,289 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestInteractiveConflicts validates the diffs shown for existing files under
+// --interactive, each resolution and the decisions recorded for the summary
+func TestInteractiveConflicts(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{"SuperClaude/Agents/system-architect.md": "# System Architect\n"})
+
+	originalPrompt := promptConflict
+	defer func() { promptConflict = originalPrompt }()
+
+	// answer resolves the conflicts by path and records the diffs shown
+	answer := func(answers map[string]ConflictAction) map[string]string {
+		diffs := make(map[string]string)
+		promptConflict = func(relPath, diff string, options []conflictOption) (ConflictAction, error) {
+			diffs[relPath] = diff
+			if options[len(options)-1].Action != ConflictAbort {
+				t.Errorf("Expected abort to be offered for %s", relPath)
+			}
+			return answers[relPath], nil
+		}
+		return diffs
+	}
+
+	install := func(t *testing.T, targetDir string, interactive bool) (*Installer, error) {
+		t.Helper()
+		cfg := &InstallConfig{
+			FrameworkDir:      repoPath,
+			Interactive:       interactive,
+			NoBackup:          true,
+			AddRecommendedMCP: true,
+			MCPServers:        []string{"context7"},
+		}
+		installer, err := NewInstaller(targetDir, cfg)
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		return installer, installer.Install()
+	}
+
+	t.Run("Resolved", func(t *testing.T) {
+		targetDir := t.TempDir()
+		mcpConfig := `{"mcpServers": {"context7": {"command": "node", "args": ["old"]}, "mine": {"command": "mine"}}}`
+		writeTestFiles(t, targetDir, map[string]string{
+			"CLAUDE.md":                     "# My project\n",
+			".mcp.json":                     mcpConfig,
+			".superclaude/RULES.md":         "# My rules\n",
+			".superclaude/notes.md":         "# Notes\n",
+			".claude/commands/sc/custom.md": "# Custom\n",
+		})
+
+		diffs := answer(map[string]ConflictAction{
+			"CLAUDE.md":           ConflictMerge,
+			".mcp.json":           ConflictSkip,
+			".superclaude/":       ConflictOverwrite,
+			".claude/commands/sc": ConflictOverwrite,
+		})
+		installer, err := install(t, targetDir, true)
+		if err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		expectedDiffs := map[string][]string{
+			"CLAUDE.md":           {"      # My project\n", "    + ## SuperClaude Instructions\n", "    + @./.superclaude/CLAUDE.md\n"},
+			".mcp.json":           {"    ~ context7\n", "      - \"node\"\n", "Overwriting would also drop: mine"},
+			".superclaude/":       {"    ~ RULES.md (changed)\n", "    + FLAGS.md (new)\n", "    ? notes.md (not from SuperClaude"},
+			".claude/commands/sc": {"    - directory with 1 entries\n    + symlink to ../../.superclaude/Commands\n"},
+		}
+		for path, fragments := range expectedDiffs {
+			for _, fragment := range fragments {
+				if !strings.Contains(diffs[path], fragment) {
+					t.Errorf("Expected %q in the diff for %s, got:\n%s", fragment, path, diffs[path])
+				}
+			}
+		}
+		if len(diffs) != len(expectedDiffs) {
+			t.Errorf("Expected only the four existing files to be asked about, got %v", diffs)
+		}
+
+		claudeMd, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.md"))
+		if !strings.HasPrefix(string(claudeMd), "# My project\n") || !strings.Contains(string(claudeMd), "@./.superclaude/CLAUDE.md") {
+			t.Errorf("Expected the import merged into CLAUDE.md, got:\n%s", claudeMd)
+		}
+		if data, _ := os.ReadFile(filepath.Join(targetDir, ".mcp.json")); string(data) != mcpConfig {
+			t.Errorf("Expected a skipped .mcp.json to be left unchanged, got:\n%s", data)
+		}
+		if fileExists(filepath.Join(targetDir, ".superclaude", "notes.md")) {
+			t.Errorf("Expected an overwritten .superclaude to drop files not from SuperClaude")
+		}
+		if rules, _ := os.ReadFile(filepath.Join(targetDir, ".superclaude", "RULES.md")); string(rules) != "# Rules\n" {
+			t.Errorf("Expected the framework's RULES.md, got %q", rules)
+		}
+		if link, err := os.Readlink(filepath.Join(targetDir, ".claude", "commands", "sc")); err != nil || link != "../../.superclaude/Commands" {
+			t.Errorf("Expected the directory to be replaced by the symlink, got %q %v", link, err)
+		}
+
+		summary := installer.GetInstallationSummary()
+		var recorded []string
+		for _, decision := range summary.Conflicts {
+			recorded = append(recorded, decision.describe())
+		}
+		sort.Strings(recorded)
+		expected := []string{
+			".claude/commands/sc: overwritten",
+			".mcp.json: skipped, left unchanged",
+			".superclaude/: overwritten",
+			"CLAUDE.md: merged",
+		}
+		if !reflect.DeepEqual(recorded, expected) {
+			t.Errorf("Expected the decisions %q, got %q", expected, recorded)
+		}
+	})
+
+	t.Run("SkipSuperClaudeDir", func(t *testing.T) {
+		targetDir := t.TempDir()
+		answer(nil)
+		if _, err := install(t, targetDir, false); err != nil {
+			t.Fatalf("First install failed: %v", err)
+		}
+		manifest, _ := os.ReadFile(ManifestPath(targetDir))
+		writeTestFiles(t, targetDir, map[string]string{".superclaude/RULES.md": "# My rules\n"})
+
+		diffs := answer(map[string]ConflictAction{".superclaude/": ConflictSkip})
+		if _, err := install(t, targetDir, true); err != nil {
+			t.Fatalf("Second install failed: %v", err)
+		}
+
+		if len(diffs) != 1 || diffs[".superclaude/"] != "    ~ RULES.md (changed)\n" {
+			t.Errorf("Expected to be asked only about the changed RULES.md, got %q", diffs)
+		}
+		if rules, _ := os.ReadFile(filepath.Join(targetDir, ".superclaude", "RULES.md")); string(rules) != "# My rules\n" {
+			t.Errorf("Expected a skipped .superclaude to keep RULES.md, got %q", rules)
+		}
+		if data, _ := os.ReadFile(ManifestPath(targetDir)); !bytes.Equal(data, manifest) {
+			t.Errorf("Expected a skipped .superclaude to keep its manifest")
+		}
+	})
+
+	t.Run("DirectoryWithoutInteractive", func(t *testing.T) {
+		// The symlink steps run in either order, so each directory gets its own install
+		emptyDir, nonEmptyDir := t.TempDir(), t.TempDir()
+		if err := os.MkdirAll(filepath.Join(emptyDir, ".claude", "commands", "sc"), 0o755); err != nil {
+			t.Fatalf("Failed to create .claude/commands/sc: %v", err)
+		}
+		writeTestFiles(t, nonEmptyDir, map[string]string{".claude/agents/sc/mine.md": "# Mine\n"})
+		diffs := answer(nil)
+
+		if _, err := install(t, emptyDir, false); err != nil {
+			t.Fatalf("Install over an empty directory failed: %v", err)
+		}
+		if link, err := os.Readlink(filepath.Join(emptyDir, ".claude", "commands", "sc")); err != nil || link != "../../.superclaude/Commands" {
+			t.Errorf("Expected an empty directory to be replaced by the symlink, got %q %v", link, err)
+		}
+
+		_, err := install(t, nonEmptyDir, false)
+		if err == nil || !strings.Contains(err.Error(), "failed to remove existing agent symlink") {
+			t.Errorf("Expected a non-empty directory to fail the install, got %v", err)
+		}
+		if !fileExists(filepath.Join(nonEmptyDir, ".claude", "agents", "sc", "mine.md")) {
+			t.Errorf("Expected the non-empty directory to be kept")
+		}
+		if len(diffs) != 0 {
+			t.Errorf("Expected no questions without --interactive, got %v", diffs)
+		}
+	})
+
+	t.Run("LinkedDirectory", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{"my-agents/reviewer.md": "# Reviewer\n"})
+		if err := os.MkdirAll(filepath.Join(targetDir, ".claude", "agents"), 0o755); err != nil {
+			t.Fatalf("Failed to create .claude/agents: %v", err)
+		}
+		if err := os.Symlink("../../my-agents", filepath.Join(targetDir, ".claude", "agents", "sc")); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+
+		diffs := answer(map[string]ConflictAction{".claude/agents/sc": ConflictOverwrite})
+		if _, err := install(t, targetDir, true); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		if diffs[".claude/agents/sc"] != "    - symlink to ../../my-agents\n    + symlink to ../../.superclaude/Agents\n" {
+			t.Errorf("Expected the symlink targets in the diff, got %q", diffs[".claude/agents/sc"])
+		}
+		if link, err := os.Readlink(filepath.Join(targetDir, ".claude", "agents", "sc")); err != nil || link != "../../.superclaude/Agents" {
+			t.Errorf("Expected the symlink to be replaced, got %q %v", link, err)
+		}
+		if !fileExists(filepath.Join(targetDir, "my-agents", "reviewer.md")) {
+			t.Errorf("Expected overwriting the symlink to keep the directory it pointed to")
+		}
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{"CLAUDE.md": "# My project\n"})
+		answer(map[string]ConflictAction{"CLAUDE.md": ConflictAbort})
+
+		_, err := install(t, targetDir, true)
+		if !errors.Is(err, errInstallAborted) || !strings.Contains(err.Error(), "aborted at CLAUDE.md") {
+			t.Errorf("Expected the installation to abort at CLAUDE.md, got %v", err)
+		}
+		if data, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.md")); string(data) != "# My project\n" {
+			t.Errorf("Expected CLAUDE.md to be left unchanged, got:\n%s", data)
+		}
+	})
+
+	t.Run("Prompt", func(t *testing.T) {
+		originalOptions, originalInput, originalOutput, originalStdin := promptOptions, promptInput, promptOutput, stdinIsTerminal
+		defer func() {
+			promptOptions, promptInput, promptOutput, stdinIsTerminal = originalOptions, originalInput, originalOutput, originalStdin
+		}()
+		promptConflict = originalPrompt
+
+		t.Setenv("CI", "")
+		stdinIsTerminal = func() bool { return false }
+		promptInput = bufio.NewReader(strings.NewReader("s\n"))
+		output := &bytes.Buffer{}
+		promptOutput = output
+		ConfigurePrompts(PromptOptions{})
+
+		ctx := &InstallContext{Config: &InstallConfig{Interactive: true}}
+		action, err := ctx.resolveConflict("CLAUDE.md", "    + @.superclaude/CLAUDE.md\n",
+			conflictOption{ConflictMerge, "add the SuperClaude import"},
+			conflictOption{ConflictSkip, "leave it unchanged"})
+		if err != nil || action != ConflictSkip {
+			t.Errorf("Expected s to choose skip, got %q %v", action, err)
+		}
+		for _, expected := range []string{"CLAUDE.md already exists", "    + @.superclaude/CLAUDE.md\n", "  1) merge (add the SuperClaude import) (default)", "  3) abort (stop the installation)"} {
+			if !strings.Contains(output.String(), expected) {
+				t.Errorf("Expected %q in the prompt, got:\n%s", expected, output)
+			}
+		}
+
+		ConfigurePrompts(PromptOptions{AssumeYes: true})
+		if action, err := ctx.resolveConflict("CLAUDE.md", "diff", conflictOption{ConflictMerge, ""}); action != ConflictMerge || err != nil {
+			t.Errorf("Expected --yes to take the default, got %q %v", action, err)
+		}
+		if len(ctx.ConflictDecisions) != 2 {
+			t.Errorf("Expected both decisions to be recorded, got %+v", ctx.ConflictDecisions)
+		}
+	})
+
+	t.Run("DiffLines", func(t *testing.T) {
+		expected := "      ...\n      b\n      c\n    - d\n    + X\n      e\n      f\n      ...\n"
+		if got := diffLines("a\nb\nc\nd\ne\nf\ng\n", "a\nb\nc\nX\ne\nf\ng\n"); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+		if got := diffLines("same\n", "same\n"); got != "" {
+			t.Errorf("Expected no diff for equal content, got %q", got)
+		}
+	})
+
+	t.Run("DiffLinesLarge", func(t *testing.T) {
+		var existing, proposed, rewritten strings.Builder
+		for n := 0; n < 50000; n++ {
+			fmt.Fprintf(&existing, "line %d\n", n)
+			fmt.Fprintf(&rewritten, "other %d\n", n)
+			if n == 25000 {
+				proposed.WriteString("inserted\n")
+			}
+			fmt.Fprintf(&proposed, "line %d\n", n)
+		}
+
+		// One change in a large file is found past the common prefix and suffix
+		expected := "      ...\n      line 24998\n      line 24999\n    + inserted\n      line 25000\n      line 25001\n      ...\n"
+		if got := diffLines(existing.String(), proposed.String()); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+
+		// A rewrite too large to compare is summarized
+		expected = "    ~ 50000 existing and 50000 proposed lines differ, too many to compare\n"
+		if got := diffLines(existing.String(), rewritten.String()); got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
 )
 
 // InstallContext holds the state of the installation process
@@ -19,17 +21,47 @@ type InstallContext struct {
 	Config             *InstallConfig
 	ExistingFiles      *ExistingFiles
 	SelectedMCPServers []MCPServer
+	MCPChanges         []MCPServerChange  // Outcome of merging the selected servers into .mcp.json
+	MCPRuntimeIssues   []MCPRuntimeIssue  // Runtimes the selected servers need but that are missing
+	MCPClientFiles     []string           // Config files of other MCP clients the servers were written to
+	mcpClientChanges   []MCPServerChange  // Outcome of merging the selected servers into MCPClientFiles
+	MCPApproval        *MCPApproval       // What this run added to Claude Code's settings, if anything
+	ConflictDecisions  []ConflictDecision // Choices made for existing files under --interactive
+	SelectedCore       []Component
+	SelectedModes      []Component
+	PreviousManifest   *InstallManifest // Choices recorded by an earlier installation, if any
+	FrameworkCommit    string           // Commit checked out for the requested ref
+	ImportLocation     ImportLocation
+	ImportBlock        string // Import section in the memory file, recorded so clean removes exactly it
+	ImportSkeleton     string // Content the installer generated around the import, if it created the file
+	Templates          *TemplateRenderer
 	SkipClaudeDir      bool
 	DryRun             bool
+	copiedFiles        int            // Files copied by the running step, for its progress event
+	pauseProgress      progressPause  // Pauses the running step's progress around prompts
+	superClaudeAction  ConflictAction // Choice for an existing .superclaude, once asked
 }
 
 // InstallConfig holds installation configuration options
 type InstallConfig struct {
 	Force             bool
 	NoBackup          bool
-	Interactive       bool
+	Interactive       bool // Show a diff and ask before changing each existing user file
 	AddRecommendedMCP bool
 	BackupDir         string
+	ImportInto        ImportLocation // Empty keeps an existing import location, defaulting to project
+	Components        ComponentSelection
+	MCPServers        []string            // Servers to install by name, "all" or "none"; nil shows the selector
+	MCPConflict       MCPConflictStrategy // How to resolve existing .mcp.json entries that differ; empty keeps them
+	MCPEnv            MCPEnvOptions       // Values for secrets the selected servers need
+	StrictMCP         bool                // Fail when a selected server's runtime is missing or too old
+	MCPTargets        []MCPTarget         // Other MCP clients to write the selected servers for
+	PinMCP            bool                // Rewrite @latest and unpinned git specs to exact versions
+	MCPVersionsFile   string              // Versions file overriding the built-in and configured pins
+	ApproveMCP        MCPApprovalMode     // Pre-approve the selected servers in Claude Code's settings
+	MCPSettings       MCPSettingsScope    // Settings file receiving the approvals
+	FrameworkRef      string              // Branch, tag or commit to install; empty uses the fixed commit
+	FrameworkDir      string              // Existing framework checkout to install from instead of cloning
 }
 
 // ExistingFiles tracks what files already exist before installation
@@ -38,6 +70,7 @@ type ExistingFiles struct {
 	MCPConfig      bool
 	SuperClaudeDir bool
 	ClaudeDir      bool
+	ImportFile     bool // Memory file at the resolved import location
 }
 
 // BackupManager handles backing up existing files
@@ -67,17 +100,40 @@ func NewInstallContext(targetDir string, config *InstallConfig) (*InstallContext
 	}
 
 	ctx := &InstallContext{
-		TargetDir:     targetDir,
-		BackupDir:     backupDir,
-		BackupManager: backupManager,
-		Completed:     make([]string, 0),
-		Config:        config,
-		ExistingFiles: &ExistingFiles{},
+		TargetDir:      targetDir,
+		BackupDir:      backupDir,
+		BackupManager:  backupManager,
+		Completed:      make([]string, 0),
+		Config:         config,
+		ExistingFiles:  &ExistingFiles{},
+		ImportLocation: config.ImportInto,
+		Templates:      NewTemplateRenderer(targetDir),
+	}
+
+	if ctx.ImportLocation == "" {
+		ctx.ImportLocation = ImportIntoProject
 	}
 
 	return ctx, nil
 }
 
+// frameworkCommit returns the commit of the framework checkout, falling back to the
+// requested ref before the checkout exists or when it isn't a git repository
+func (ctx *InstallContext) frameworkCommit() string {
+	if ctx.FrameworkCommit != "" {
+		return ctx.FrameworkCommit
+	}
+	return ctx.frameworkRef()
+}
+
+// frameworkRef returns the framework branch, tag or commit requested for installation
+func (ctx *InstallContext) frameworkRef() string {
+	if ctx.Config.FrameworkRef != "" {
+		return ctx.Config.FrameworkRef
+	}
+	return config.FixedCommit
+}
+
 // ScanExistingFiles checks what files already exist in the target directory
 func (ctx *InstallContext) ScanExistingFiles() error {
 	claudePath := filepath.Join(ctx.TargetDir, "CLAUDE.md")
@@ -90,9 +146,32 @@ func (ctx *InstallContext) ScanExistingFiles() error {
 	ctx.ExistingFiles.SuperClaudeDir = fileExists(superClaudePath)
 	ctx.ExistingFiles.ClaudeDir = fileExists(claudeDirPath)
 
+	manifest, err := LoadInstallManifest(ctx.TargetDir)
+	if err != nil {
+		log.Printf("Ignoring previous install manifest: %v", err)
+	}
+	ctx.PreviousManifest = manifest
+
+	ctx.ImportLocation = ctx.resolveImportLocation()
+	ctx.ExistingFiles.ImportFile = fileExists(ctx.ImportLocation.Path(ctx.TargetDir))
+
 	return nil
 }
 
+// resolveImportLocation picks the memory file for the SuperClaude import: the configured
+// location, else the location of an existing import, else the project CLAUDE.md
+func (ctx *InstallContext) resolveImportLocation() ImportLocation {
+	if ctx.Config.ImportInto != "" {
+		return ctx.Config.ImportInto
+	}
+
+	if existing := FindSuperClaudeImports(ctx.TargetDir); len(existing) > 0 {
+		return existing[0]
+	}
+
+	return ImportIntoProject
+}
+
 // CreateBackupDir creates the backup directory if it doesn't exist
 func (bm *BackupManager) CreateBackupDir() error {
 	if bm.BackupDir == "" {
@@ -120,6 +199,14 @@ func (bm *BackupManager) BackupFile(filePath string) error {
 	fileName := filepath.Base(filePath)
 	backupPath := filepath.Join(bm.BackupDir, fileName)
 
+	// Files from different directories can share a name, e.g. .cursor/mcp.json and .vscode/mcp.json
+	for _, taken := range bm.Files {
+		if taken == backupPath {
+			backupPath = filepath.Join(bm.BackupDir, filepath.Base(filepath.Dir(filePath))+"_"+fileName)
+			break
+		}
+	}
+
 	// Handle subdirectories (like .superclaude)
 	if stat, err := os.Stat(filePath); err == nil && stat.IsDir() {
 		return copyDir(filePath, backupPath)
@@ -0,0 +1,151 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// charsPerToken is the rough characters-per-token ratio used for English markdown
+const charsPerToken = 4
+
+// ContextComponent groups imported files by the part of the installation they belong to
+type ContextComponent string
+
+const (
+	// ContextProject covers the project memory files and anything they import outside .superclaude
+	ContextProject ContextComponent = "project"
+	// ContextCore covers .superclaude/CLAUDE.md and the core files next to it
+	ContextCore ContextComponent = "core"
+	// ContextModes covers .superclaude/Modes
+	ContextModes ContextComponent = "modes"
+	// ContextMCP covers the MCP documentation in .superclaude/MCP
+	ContextMCP ContextComponent = "mcp"
+)
+
+// contextComponents lists the components in report order
+var contextComponents = []ContextComponent{ContextProject, ContextCore, ContextModes, ContextMCP}
+
+// FileBudget is the estimated context cost of a single imported file
+type FileBudget struct {
+	Path      string // Relative to the project directory
+	Component ContextComponent
+	Chars     int
+	Tokens    int
+}
+
+// ComponentBudget is the estimated context cost of all files in a component
+type ComponentBudget struct {
+	Component ContextComponent
+	Files     int
+	Chars     int
+	Tokens    int
+}
+
+// ContextBudget estimates how much context the effective import tree adds
+type ContextBudget struct {
+	Files       []FileBudget
+	Components  []ComponentBudget
+	TotalChars  int
+	TotalTokens int
+}
+
+// EstimateContextBudget walks the import tree from the project memory files and
+// estimates characters and approximate tokens per file and per component
+func EstimateContextBudget(targetDir string) (*ContextBudget, error) {
+	g, err := BuildImportGraph(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &ContextBudget{}
+	totals := make(map[ContextComponent]*ComponentBudget)
+
+	for _, file := range g.Files {
+		relPath := g.relPath(file.Path)
+		entry := FileBudget{
+			Path:      relPath,
+			Component: classifyContextFile(relPath),
+			Chars:     file.Chars,
+			Tokens:    estimateTokens(file.Chars),
+		}
+		budget.Files = append(budget.Files, entry)
+
+		total, ok := totals[entry.Component]
+		if !ok {
+			total = &ComponentBudget{Component: entry.Component}
+			totals[entry.Component] = total
+		}
+		total.Files++
+		total.Chars += entry.Chars
+		total.Tokens += entry.Tokens
+
+		budget.TotalChars += entry.Chars
+		budget.TotalTokens += entry.Tokens
+	}
+
+	for _, component := range contextComponents {
+		if total, ok := totals[component]; ok {
+			budget.Components = append(budget.Components, *total)
+		}
+	}
+
+	// Largest files first so the expensive imports stand out
+	sort.SliceStable(budget.Files, func(i, j int) bool {
+		return budget.Files[i].Tokens > budget.Files[j].Tokens
+	})
+
+	return budget, nil
+}
+
+// classifyContextFile maps a project-relative path to its context component
+func classifyContextFile(relPath string) ContextComponent {
+	superClaudePrefix := config.SuperClaudeDir + "/"
+	switch {
+	case strings.HasPrefix(relPath, path.Join(config.SuperClaudeDir, "Modes")+"/"):
+		return ContextModes
+	case strings.HasPrefix(relPath, path.Join(config.SuperClaudeDir, "MCP")+"/"):
+		return ContextMCP
+	case strings.HasPrefix(relPath, superClaudePrefix):
+		return ContextCore
+	default:
+		return ContextProject
+	}
+}
+
+// estimateTokens approximates the token count for the given number of characters
+func estimateTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
+
+// ExceedsBudget reports whether the estimated total is above maxTokens
+func (b *ContextBudget) ExceedsBudget(maxTokens int) bool {
+	return maxTokens > 0 && b.TotalTokens > maxTokens
+}
+
+// PrintReport displays the per-component and per-file context estimate
+func (b *ContextBudget) PrintReport(maxTokens int) {
+	fmt.Printf("Context budget (approx. %d characters per token):\n\n", charsPerToken)
+
+	fmt.Printf("  %-10s %6s %10s %10s\n", "Component", "Files", "Chars", "~Tokens")
+	for _, component := range b.Components {
+		fmt.Printf("  %-10s %6d %10d %10d\n", component.Component, component.Files, component.Chars, component.Tokens)
+	}
+	fmt.Printf("  %-10s %6d %10d %10d\n", "total", len(b.Files), b.TotalChars, b.TotalTokens)
+
+	fmt.Printf("\nFiles:\n")
+	for _, file := range b.Files {
+		fmt.Printf("  %8d ~tokens  %-8s %s\n", file.Tokens, file.Component, file.Path)
+	}
+
+	fmt.Printf("\n")
+	if b.ExceedsBudget(maxTokens) {
+		fmt.Printf("⚠️  Estimated context of ~%d tokens exceeds the budget of %d tokens\n", b.TotalTokens, maxTokens)
+		fmt.Printf("   Consider dropping modes (init --exclude-mode) or MCP servers to reduce it\n")
+	} else if maxTokens > 0 {
+		fmt.Printf("✅ Estimated context of ~%d tokens is within the budget of %d tokens\n", b.TotalTokens, maxTokens)
+	}
+}
@@ -0,0 +1,54 @@
+package installer
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestEstimateContextBudget validates per-component totals and the budget threshold
+func TestEstimateContextBudget(t *testing.T) {
+	targetDir := t.TempDir()
+	writeTestFiles(t, targetDir, map[string]string{
+		"CLAUDE.md":                                "# Project\n@./.superclaude/CLAUDE.md\n",
+		".superclaude/CLAUDE.md":                   "@FLAGS.md\n@Modes/MODE_Brainstorming.md\n@MCP/MCP_Context7.md\n",
+		".superclaude/FLAGS.md":                    strings.Repeat("f", 400),
+		".superclaude/Modes/MODE_Brainstorming.md": strings.Repeat("b", 800),
+		".superclaude/MCP/MCP_Context7.md":         strings.Repeat("ü", 200), // counted as characters, not bytes
+		".superclaude/Modes/MODE_Unused.md":        strings.Repeat("x", 10000),
+	})
+
+	budget, err := EstimateContextBudget(targetDir)
+	if err != nil {
+		t.Fatalf("EstimateContextBudget failed: %v", err)
+	}
+
+	expected := map[ContextComponent]ComponentBudget{
+		ContextProject: {Component: ContextProject, Files: 1, Chars: 36, Tokens: 9},
+		ContextCore:    {Component: ContextCore, Files: 2, Chars: 460, Tokens: 115},
+		ContextModes:   {Component: ContextModes, Files: 1, Chars: 800, Tokens: 200},
+		ContextMCP:     {Component: ContextMCP, Files: 1, Chars: 200, Tokens: 50},
+	}
+
+	if len(budget.Components) != len(expected) {
+		t.Fatalf("Expected %d components, got %+v", len(expected), budget.Components)
+	}
+	for _, component := range budget.Components {
+		if component != expected[component.Component] {
+			t.Errorf("Component %s: expected %+v, got %+v", component.Component, expected[component.Component], component)
+		}
+	}
+
+	if budget.Files[0].Path != ".superclaude/Modes/MODE_Brainstorming.md" {
+		t.Errorf("Expected largest file first, got %s", budget.Files[0].Path)
+	}
+	if budget.TotalTokens != 374 {
+		t.Errorf("Expected 374 total tokens, got %d", budget.TotalTokens)
+	}
+
+	if !budget.ExceedsBudget(300) {
+		t.Errorf("Expected budget of 300 tokens to be exceeded")
+	}
+	if budget.ExceedsBudget(0) || budget.ExceedsBudget(1000) {
+		t.Errorf("Expected disabled or larger budgets not to be exceeded")
+	}
+}
@@ -0,0 +1,247 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// importPattern matches an @ import the way Claude Code does: an @ at the start of the
+// line or after whitespace, followed by a path that may contain escaped spaces
+var importPattern = regexp.MustCompile(`(?:^|\s)@((?:[^\s\\]|\\ )+)`)
+
+// inlineCodePattern matches markdown code spans, where imports are not evaluated
+var inlineCodePattern = regexp.MustCompile("`[^`]*`")
+
+// ImportRef is a single @ import found in a memory file
+type ImportRef struct {
+	File   string // Absolute path of the importing file
+	Line   int    // 1-based line number of the import
+	Path   string // Import path as written, e.g. "Modes/MODE_Brainstorming.md"
+	Target string // Absolute path the import resolves to
+}
+
+// ImportedFile is a memory file reached while walking the import graph
+type ImportedFile struct {
+	Path       string     // Absolute path of the file
+	Depth      int        // Number of import hops from the project memory file
+	Size       int        // File size in bytes
+	Chars      int        // Number of characters (runes)
+	ImportedBy *ImportRef // Import that first reached the file; nil for project memory files
+}
+
+// LintIssue is a problem found in the import graph, located at the offending import
+type LintIssue struct {
+	File    string // Path relative to the project directory
+	Line    int
+	Message string
+}
+
+// String formats the issue as file:line: message
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+// ImportGraph is the @ import tree reachable from a project's memory files
+type ImportGraph struct {
+	TargetDir string
+	Files     []*ImportedFile // Every reachable file once, in visit order
+	Issues    []LintIssue
+
+	visited map[string]*ImportedFile
+}
+
+// BuildImportGraph parses the project memory files (CLAUDE.md, .claude/CLAUDE.md and
+// CLAUDE.local.md) and follows @ imports recursively using Claude Code's rules:
+// relative paths resolve from the importing file, imports inside code are ignored and
+// recursion stops after config.MaxImportDepth hops.
+func BuildImportGraph(targetDir string) (*ImportGraph, error) {
+	g := &ImportGraph{
+		TargetDir: targetDir,
+		visited:   make(map[string]*ImportedFile),
+	}
+
+	roots := 0
+	for _, location := range ImportLocations() {
+		rootPath := location.Path(targetDir)
+		if !fileExists(rootPath) {
+			continue
+		}
+		roots++
+		if err := g.visit(rootPath, 0, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if roots == 0 {
+		return nil, fmt.Errorf("no memory file found in %s (expected %s, %s or %s)", targetDir,
+			ImportIntoProject.RelPath(), ImportIntoClaudeDir.RelPath(), ImportIntoLocal.RelPath())
+	}
+
+	return g, nil
+}
+
+// visit records a file and walks its imports; stack holds the files on the current path
+func (g *ImportGraph) visit(path string, depth int, importedBy *ImportRef, stack []string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", g.relPath(path), err)
+	}
+
+	file := &ImportedFile{
+		Path:       path,
+		Depth:      depth,
+		Size:       len(content),
+		Chars:      utf8.RuneCount(content),
+		ImportedBy: importedBy,
+	}
+	g.Files = append(g.Files, file)
+	g.visited[path] = file
+	stack = append(stack, path)
+
+	for _, ref := range parseImports(path, string(content)) {
+		info, err := os.Stat(ref.Target)
+		if err != nil {
+			g.addIssue(ref, fmt.Sprintf("import target not found: %s", ref.Path))
+			continue
+		}
+		if info.IsDir() {
+			g.addIssue(ref, fmt.Sprintf("import target is a directory: %s", ref.Path))
+			continue
+		}
+
+		if cycle := cyclePath(stack, ref.Target); cycle != nil {
+			parts := make([]string, 0, len(cycle)+1)
+			for _, p := range cycle {
+				parts = append(parts, g.relPath(p))
+			}
+			parts = append(parts, g.relPath(ref.Target))
+			g.addIssue(ref, fmt.Sprintf("import cycle: %s", strings.Join(parts, " → ")))
+			continue
+		}
+
+		if previous, ok := g.visited[ref.Target]; ok {
+			g.addIssue(ref, fmt.Sprintf("duplicate import of %s (%s)", g.relPath(ref.Target), g.describeOrigin(previous)))
+			continue
+		}
+
+		if depth+1 > config.MaxImportDepth {
+			g.addIssue(ref, fmt.Sprintf("import of %s exceeds the maximum depth of %d hops and will not be loaded",
+				ref.Path, config.MaxImportDepth))
+			continue
+		}
+
+		if err := g.visit(ref.Target, depth+1, &ref, stack); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *ImportGraph) addIssue(ref ImportRef, message string) {
+	g.Issues = append(g.Issues, LintIssue{File: g.relPath(ref.File), Line: ref.Line, Message: message})
+}
+
+func (g *ImportGraph) describeOrigin(file *ImportedFile) string {
+	if file.ImportedBy == nil {
+		return "project memory file"
+	}
+	return fmt.Sprintf("already imported at %s:%d", g.relPath(file.ImportedBy.File), file.ImportedBy.Line)
+}
+
+// relPath returns path relative to the project directory for display
+func (g *ImportGraph) relPath(path string) string {
+	rel, err := filepath.Rel(g.TargetDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// cyclePath returns the part of the stack starting at target, or nil if target is not on it
+func cyclePath(stack []string, target string) []string {
+	for i, path := range stack {
+		if path == target {
+			return stack[i:]
+		}
+	}
+	return nil
+}
+
+// parseImports extracts @ imports from a memory file, skipping fenced code blocks and
+// code spans. Tokens without a "." or "/" (such as @mentions) are not treated as paths.
+func parseImports(filePath, content string) []ImportRef {
+	var refs []ImportRef
+	inFence := false
+	fenceMarker := ""
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if marker := codeFenceMarker(trimmed); marker != "" {
+			switch {
+			case !inFence:
+				inFence, fenceMarker = true, marker
+			case marker == fenceMarker:
+				inFence, fenceMarker = false, ""
+			}
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		line = inlineCodePattern.ReplaceAllString(line, "")
+		for _, match := range importPattern.FindAllStringSubmatch(line, -1) {
+			importPath := strings.ReplaceAll(match[1], `\ `, " ")
+			if !strings.ContainsAny(importPath, "./") {
+				continue
+			}
+			refs = append(refs, ImportRef{
+				File:   filePath,
+				Line:   i + 1,
+				Path:   importPath,
+				Target: resolveImportTarget(filePath, importPath),
+			})
+		}
+	}
+
+	return refs
+}
+
+func codeFenceMarker(line string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// resolveImportTarget resolves an import path relative to the importing file
+func resolveImportTarget(fromFile, importPath string) string {
+	if strings.HasPrefix(importPath, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, filepath.FromSlash(importPath[2:]))
+		}
+	}
+	if filepath.IsAbs(importPath) {
+		return filepath.Clean(importPath)
+	}
+	return filepath.Join(filepath.Dir(fromFile), filepath.FromSlash(importPath))
+}
+
+// LintImports builds the import graph for a project and returns the problems found:
+// missing targets, cycles, duplicate imports and imports beyond the maximum depth
+func LintImports(targetDir string) ([]LintIssue, int, error) {
+	g, err := BuildImportGraph(targetDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.Issues, len(g.Files), nil
+}
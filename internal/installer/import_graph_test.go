@@ -0,0 +1,143 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestFiles creates files relative to dir from a path -> content map
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
+
+// TestParseImports validates Claude Code import syntax handling
+func TestParseImports(t *testing.T) {
+	content := strings.Join([]string{
+		"# Project",
+		"@./.superclaude/CLAUDE.md",
+		"@FLAGS.md # Flag definitions",
+		"Contact admin@example.com or ping @alice",
+		"Inline `@ignored.md` code span",
+		"```",
+		"@also-ignored.md",
+		"```",
+		"See @docs/guide.md and @My\\ Notes.md",
+	}, "\n")
+
+	refs := parseImports("/project/CLAUDE.md", content)
+
+	expected := []struct {
+		line int
+		path string
+	}{
+		{2, "./.superclaude/CLAUDE.md"},
+		{3, "FLAGS.md"},
+		{9, "docs/guide.md"},
+		{9, "My Notes.md"},
+	}
+
+	if len(refs) != len(expected) {
+		t.Fatalf("Expected %d imports, got %d: %+v", len(expected), len(refs), refs)
+	}
+	for i, exp := range expected {
+		if refs[i].Line != exp.line || refs[i].Path != exp.path {
+			t.Errorf("Import %d: expected %s at line %d, got %s at line %d", i, exp.path, exp.line, refs[i].Path, refs[i].Line)
+		}
+	}
+
+	if refs[0].Target != filepath.FromSlash("/project/.superclaude/CLAUDE.md") {
+		t.Errorf("Expected import to resolve relative to the importing file, got %s", refs[0].Target)
+	}
+}
+
+// TestLintImports validates detection of missing, cyclic, duplicate and too-deep imports
+func TestLintImports(t *testing.T) {
+	t.Run("Clean_installation", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{
+			"CLAUDE.md":                                "# Project\n\n" + builtinImportBlock(t, ImportIntoProject) + "\n",
+			".superclaude/CLAUDE.md":                   "@FLAGS.md\n@Modes/MODE_Brainstorming.md\n",
+			".superclaude/FLAGS.md":                    "# Flags\n",
+			".superclaude/Modes/MODE_Brainstorming.md": "# Brainstorming\n",
+		})
+
+		issues, checked, err := LintImports(targetDir)
+		if err != nil {
+			t.Fatalf("LintImports failed: %v", err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("Expected no issues, got %v", issues)
+		}
+		if checked != 4 {
+			t.Errorf("Expected 4 files checked, got %d", checked)
+		}
+	})
+
+	t.Run("Broken_imports", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{
+			"CLAUDE.md":              "# Project\n@./.superclaude/CLAUDE.md\n@./.superclaude/RULES.md\n",
+			".superclaude/CLAUDE.md": "@Modes/MODE_Missing.md\n@RULES.md\n",
+			".superclaude/RULES.md":  "@CLAUDE.md\n",
+		})
+
+		issues, _, err := LintImports(targetDir)
+		if err != nil {
+			t.Fatalf("LintImports failed: %v", err)
+		}
+
+		expected := []string{
+			".superclaude/CLAUDE.md:1: import target not found: Modes/MODE_Missing.md",
+			".superclaude/RULES.md:1: import cycle: .superclaude/CLAUDE.md → .superclaude/RULES.md → .superclaude/CLAUDE.md",
+			"CLAUDE.md:3: duplicate import of .superclaude/RULES.md (already imported at .superclaude/CLAUDE.md:2)",
+		}
+		if len(issues) != len(expected) {
+			t.Fatalf("Expected %d issues, got %d: %v", len(expected), len(issues), issues)
+		}
+		for i, exp := range expected {
+			if issues[i].String() != exp {
+				t.Errorf("Issue %d: expected %q, got %q", i, exp, issues[i].String())
+			}
+		}
+	})
+
+	t.Run("Maximum_depth", func(t *testing.T) {
+		targetDir := t.TempDir()
+		files := map[string]string{"CLAUDE.md": "@level1.md\n"}
+		for i := 1; i <= 6; i++ {
+			files[fmt.Sprintf("level%d.md", i)] = fmt.Sprintf("@level%d.md\n", i+1)
+		}
+		files["level7.md"] = "# Too deep\n"
+		writeTestFiles(t, targetDir, files)
+
+		issues, checked, err := LintImports(targetDir)
+		if err != nil {
+			t.Fatalf("LintImports failed: %v", err)
+		}
+		if checked != 6 {
+			t.Errorf("Expected CLAUDE.md plus 5 hops to be loaded, got %d files", checked)
+		}
+		if len(issues) != 1 || issues[0].File != "level5.md" || !strings.Contains(issues[0].Message, "maximum depth") {
+			t.Errorf("Expected a single depth issue at level5.md, got %v", issues)
+		}
+	})
+
+	t.Run("No_memory_file", func(t *testing.T) {
+		if _, _, err := LintImports(t.TempDir()); err == nil {
+			t.Errorf("Expected error when no memory file exists")
+		}
+	})
+}
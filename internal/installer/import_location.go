@@ -0,0 +1,250 @@
+package installer
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// ImportLocation identifies the memory file that carries the SuperClaude import
+type ImportLocation string
+
+const (
+	// ImportIntoProject writes the import into the shared <project>/CLAUDE.md
+	ImportIntoProject ImportLocation = "project"
+	// ImportIntoClaudeDir writes the import into <project>/.claude/CLAUDE.md
+	ImportIntoClaudeDir ImportLocation = "claude-dir"
+	// ImportIntoLocal writes the import into the personal, gitignored <project>/CLAUDE.local.md
+	ImportIntoLocal ImportLocation = "local"
+)
+
+// ImportLocations returns all supported import locations in display order
+func ImportLocations() []ImportLocation {
+	return []ImportLocation{ImportIntoProject, ImportIntoClaudeDir, ImportIntoLocal}
+}
+
+// ParseImportLocation converts a --import-into value into an ImportLocation
+func ParseImportLocation(value string) (ImportLocation, error) {
+	for _, location := range ImportLocations() {
+		if string(location) == value {
+			return location, nil
+		}
+	}
+
+	names := make([]string, 0, len(ImportLocations()))
+	for _, location := range ImportLocations() {
+		names = append(names, string(location))
+	}
+	return "", fmt.Errorf("unknown import location %q (expected one of: %s)", value, strings.Join(names, ", "))
+}
+
+// RelPath returns the memory file path relative to the project root, using forward slashes
+func (l ImportLocation) RelPath() string {
+	switch l {
+	case ImportIntoClaudeDir:
+		return path.Join(config.ClaudeDir, config.CLAUDEFile)
+	case ImportIntoLocal:
+		return config.ClaudeLocalFile
+	default:
+		return config.CLAUDEFile
+	}
+}
+
+// Path returns the absolute memory file path for the given project directory
+func (l ImportLocation) Path(targetDir string) string {
+	return filepath.Join(targetDir, filepath.FromSlash(l.RelPath()))
+}
+
+// ImportPath returns the @ path to .superclaude/CLAUDE.md relative to the memory file.
+// Claude Code resolves relative imports from the directory of the importing file.
+func (l ImportLocation) ImportPath() string {
+	superClaudeFile := path.Join(config.SuperClaudeDir, config.CLAUDEFile)
+
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(l.RelPath())), filepath.FromSlash(superClaudeFile))
+	if err != nil {
+		return "./" + superClaudeFile
+	}
+
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// templateData returns the template variables describing this location, for imports
+// written without a record in the install manifest
+func (l ImportLocation) templateData(targetDir string) TemplateData {
+	return TemplateData{
+		ProjectName:     filepath.Base(targetDir),
+		FrameworkRepo:   config.RepoURL,
+		FrameworkCommit: config.FixedCommit,
+		ImportFile:      l.RelPath(),
+		ImportPath:      l.ImportPath(),
+	}
+}
+
+// HasSuperClaudeImport reports whether the memory file content already imports SuperClaude
+func (l ImportLocation) HasSuperClaudeImport(content string) bool {
+	return findImportLine(strings.Split(content, "\n"), l.ImportPath()) >= 0
+}
+
+// FindSuperClaudeImports returns every location whose memory file imports SuperClaude
+func FindSuperClaudeImports(targetDir string) []ImportLocation {
+	var found []ImportLocation
+	for _, location := range ImportLocations() {
+		content, err := os.ReadFile(location.Path(targetDir))
+		if err != nil {
+			continue
+		}
+		if location.HasSuperClaudeImport(string(content)) {
+			found = append(found, location)
+		}
+	}
+	return found
+}
+
+// RemoveSuperClaudeImport strips the SuperClaude import section from the memory file at
+// the given location. The section recorded in manifest is removed exactly; without a
+// record it is rendered again from the current templates. A file left with nothing but
+// what the installer generated around the import is deleted. Returns true if the file
+// was modified.
+func RemoveSuperClaudeImport(targetDir string, location ImportLocation, manifest *InstallManifest) (bool, error) {
+	filePath := location.Path(targetDir)
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", location.RelPath(), err)
+	}
+
+	block, skeleton := manifest.importRemoval(location)
+	if block == "" {
+		templates := NewTemplateRenderer(targetDir)
+		data := location.templateData(targetDir)
+
+		// A template that no longer renders (e.g. it was edited since install) only
+		// disables exact block removal; the import line is still removed
+		if block, err = templates.RenderImportBlock(data); err != nil {
+			block = ""
+		}
+		skeleton = generatedSkeleton(templates, data)
+	}
+
+	updated, removed := removeImportSection(string(content), block, location.ImportPath())
+	if !removed {
+		return false, nil
+	}
+
+	remaining := strings.TrimSpace(updated)
+	if remaining == "" || (skeleton != "" && remaining == skeleton) {
+		if err := os.Remove(filePath); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", location.RelPath(), err)
+		}
+		return true, nil
+	}
+
+	if err := os.WriteFile(filePath, []byte(updated), 0o600); err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", location.RelPath(), err)
+	}
+	return true, nil
+}
+
+// RemoveSuperClaudeImports strips the SuperClaude import from every supported location
+// and returns the locations that were modified. manifest is the project's install
+// manifest, loaded before .superclaude is removed; nil re-renders the import sections.
+func RemoveSuperClaudeImports(targetDir string, manifest *InstallManifest) ([]ImportLocation, error) {
+	var removed []ImportLocation
+	for _, location := range FindSuperClaudeImports(targetDir) {
+		changed, err := RemoveSuperClaudeImport(targetDir, location, manifest)
+		if err != nil {
+			return removed, err
+		}
+		if changed {
+			removed = append(removed, location)
+		}
+	}
+	return removed, nil
+}
+
+// generatedSkeleton renders a new memory file without the import section, i.e. the
+// content the installer adds around the import when it creates the file
+func generatedSkeleton(templates *TemplateRenderer, data TemplateData) string {
+	data.ImportBlock = ""
+	content, err := templates.RenderFile(ProjectClaudeTemplate, data)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(content)
+}
+
+// removeImportSection removes the import block written by the installer. If the block was
+// edited by hand, only the @ import line itself is removed.
+func removeImportSection(content, block, importPath string) (string, bool) {
+	if idx := strings.Index(content, block); block != "" && idx >= 0 {
+		before := strings.TrimRight(content[:idx], "\n")
+		after := strings.TrimLeft(content[idx+len(block):], "\n")
+
+		switch {
+		case before == "":
+			return after, true
+		case after == "":
+			return before + "\n", true
+		default:
+			return before + "\n\n" + after, true
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	lineIdx := findImportLine(lines, importPath)
+	if lineIdx < 0 {
+		return content, false
+	}
+
+	lines = append(lines[:lineIdx], lines[lineIdx+1:]...)
+	return strings.Join(lines, "\n"), true
+}
+
+// findImportLine returns the index of the line importing importPath, or -1
+func findImportLine(lines []string, importPath string) int {
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "@"+importPath {
+			return i
+		}
+	}
+	return -1
+}
+
+// isGitignored performs a best-effort check whether .gitignore in targetDir lists fileName
+func isGitignored(targetDir, fileName string) bool {
+	file, err := os.Open(filepath.Join(targetDir, ".gitignore"))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("failed to close .gitignore: %v", err)
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		pattern := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "/")
+		if pattern == fileName {
+			return true
+		}
+		if matched, err := filepath.Match(pattern, fileName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
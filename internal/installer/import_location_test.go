@@ -0,0 +1,228 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestImportLocationPaths validates the memory file and relative @ path for each location
+func TestImportLocationPaths(t *testing.T) {
+	testCases := []struct {
+		location   ImportLocation
+		relPath    string
+		importPath string
+	}{
+		{ImportIntoProject, "CLAUDE.md", "./.superclaude/CLAUDE.md"},
+		{ImportIntoClaudeDir, ".claude/CLAUDE.md", "../.superclaude/CLAUDE.md"},
+		{ImportIntoLocal, "CLAUDE.local.md", "./.superclaude/CLAUDE.md"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.location), func(t *testing.T) {
+			if got := tc.location.RelPath(); got != tc.relPath {
+				t.Errorf("Expected RelPath %s, got %s", tc.relPath, got)
+			}
+			if got := tc.location.ImportPath(); got != tc.importPath {
+				t.Errorf("Expected ImportPath %s, got %s", tc.importPath, got)
+			}
+			block := builtinImportBlock(t, tc.location)
+			if !strings.Contains(block, "@"+tc.importPath) {
+				t.Errorf("Expected import block to contain @%s, got:\n%s", tc.importPath, block)
+			}
+		})
+	}
+
+	if _, err := ParseImportLocation("home"); err == nil {
+		t.Errorf("Expected error for unknown import location")
+	}
+}
+
+// TestImportLocationMove validates that installing into a new location moves the import
+func TestImportLocationMove(t *testing.T) {
+	targetDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(targetDir, ".superclaude"), 0o755); err != nil {
+		t.Fatalf("Failed to create .superclaude: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, ".superclaude", "CLAUDE.md"), []byte("@FLAGS.md\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create .superclaude/CLAUDE.md: %v", err)
+	}
+
+	// Existing project CLAUDE.md with user content and the SuperClaude import
+	projectContent := "# My Project\n\nUse tabs.\n\n" + builtinImportBlock(t, ImportIntoProject) + "\n"
+	if err := os.WriteFile(ImportIntoProject.Path(targetDir), []byte(projectContent), 0o644); err != nil {
+		t.Fatalf("Failed to create CLAUDE.md: %v", err)
+	}
+
+	ctx, err := NewInstallContext(targetDir, &InstallConfig{NoBackup: true, ImportInto: ImportIntoClaudeDir})
+	if err != nil {
+		t.Fatalf("Failed to create install context: %v", err)
+	}
+	if err := ctx.ScanExistingFiles(); err != nil {
+		t.Fatalf("Failed to scan existing files: %v", err)
+	}
+
+	if err := mergeOrCreateCLAUDEmd(ctx); err != nil {
+		t.Fatalf("mergeOrCreateCLAUDEmd failed: %v", err)
+	}
+
+	found := FindSuperClaudeImports(targetDir)
+	if len(found) != 1 || found[0] != ImportIntoClaudeDir {
+		t.Fatalf("Expected import only in claude-dir, got %v", found)
+	}
+
+	projectData, err := os.ReadFile(ImportIntoProject.Path(targetDir))
+	if err != nil {
+		t.Fatalf("Expected project CLAUDE.md to be kept: %v", err)
+	}
+	if string(projectData) != "# My Project\n\nUse tabs.\n" {
+		t.Errorf("Expected user content to be preserved, got:\n%q", string(projectData))
+	}
+
+	// A re-run without --import-into keeps the existing location
+	rerun, err := NewInstallContext(targetDir, &InstallConfig{NoBackup: true})
+	if err != nil {
+		t.Fatalf("Failed to create install context: %v", err)
+	}
+	if err := rerun.ScanExistingFiles(); err != nil {
+		t.Fatalf("Failed to scan existing files: %v", err)
+	}
+	if rerun.ImportLocation != ImportIntoClaudeDir {
+		t.Errorf("Expected existing location claude-dir to be reused, got %s", rerun.ImportLocation)
+	}
+}
+
+// TestRemoveSuperClaudeImports validates clean behavior for all import locations
+func TestRemoveSuperClaudeImports(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	targetDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(targetDir, ".claude"), 0o755); err != nil {
+		t.Fatalf("Failed to create .claude: %v", err)
+	}
+
+	// File created by the installer: removed entirely
+	localData := ImportIntoLocal.templateData(targetDir)
+	localData.ImportBlock = builtinImportBlock(t, ImportIntoLocal)
+	content, err := (&TemplateRenderer{}).RenderFile(ProjectClaudeTemplate, localData)
+	if err != nil {
+		t.Fatalf("Failed to render CLAUDE.local.md: %v", err)
+	}
+	if err := createCLAUDEmd(ImportIntoLocal.Path(targetDir), ImportIntoLocal, content); err != nil {
+		t.Fatalf("Failed to create CLAUDE.local.md: %v", err)
+	}
+	// Hand-edited import: only the import line is removed
+	edited := "# Team notes\n\n@../.superclaude/CLAUDE.md\nKeep this.\n"
+	if err := os.WriteFile(ImportIntoClaudeDir.Path(targetDir), []byte(edited), 0o644); err != nil {
+		t.Fatalf("Failed to create .claude/CLAUDE.md: %v", err)
+	}
+
+	removed, err := RemoveSuperClaudeImports(targetDir, nil)
+	if err != nil {
+		t.Fatalf("RemoveSuperClaudeImports failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Expected 2 locations cleaned, got %v", removed)
+	}
+
+	if fileExists(ImportIntoLocal.Path(targetDir)) {
+		t.Errorf("Expected installer-created CLAUDE.local.md to be removed")
+	}
+
+	data, err := os.ReadFile(ImportIntoClaudeDir.Path(targetDir))
+	if err != nil {
+		t.Fatalf("Expected .claude/CLAUDE.md to be kept: %v", err)
+	}
+	if string(data) != "# Team notes\n\nKeep this.\n" {
+		t.Errorf("Unexpected .claude/CLAUDE.md content after clean:\n%q", string(data))
+	}
+}
+
+// TestRemoveRecordedSuperClaudeImport validates that clean removes the import section
+// recorded at install, even when the current templates would render it differently
+func TestRemoveRecordedSuperClaudeImport(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{"SuperClaude/Agents/system-architect.md": "# System Architect\n"})
+
+	install := func(t *testing.T, targetDir string, location ImportLocation) {
+		t.Helper()
+		installer, err := NewInstaller(targetDir, &InstallConfig{
+			FrameworkDir: repoPath,
+			FrameworkRef: "v9.9.9",
+			ImportInto:   location,
+			NoBackup:     true,
+		})
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		if err := installer.Install(); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+	}
+
+	// clean loads the manifest before removing .superclaude
+	clean := func(t *testing.T, targetDir string) {
+		t.Helper()
+		manifest, err := LoadInstallManifest(targetDir)
+		if err != nil || manifest == nil || manifest.ImportBlock == "" {
+			t.Fatalf("Expected the import section in the manifest, got %+v %v", manifest, err)
+		}
+		if err := os.RemoveAll(filepath.Join(targetDir, ".superclaude")); err != nil {
+			t.Fatalf("Failed to remove .superclaude: %v", err)
+		}
+		if _, err := RemoveSuperClaudeImports(targetDir, manifest); err != nil {
+			t.Fatalf("RemoveSuperClaudeImports failed: %v", err)
+		}
+	}
+
+	// Templates using data that clean cannot derive from the location alone
+	templates := map[string]string{
+		".superclaude-lite/templates/import.md.tmpl":      "## SuperClaude {{ .FrameworkCommit }}\n\nCore:{{ range .Core }} {{ .Name }}{{ end }}\n@{{ .ImportPath }}\n",
+		".superclaude-lite/templates/claude.md.tmpl":      "# {{ .ProjectName }} ({{ len .Modes }} modes)\n\n{{ .ImportBlock }}\n",
+		".superclaude-lite/templates/superclaude.md.tmpl": "# SuperClaude\n",
+	}
+
+	t.Run("Merged", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, templates)
+		writeTestFiles(t, targetDir, map[string]string{"CLAUDE.md": "# My project\n"})
+		install(t, targetDir, ImportIntoProject)
+
+		if data, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.md")); !strings.Contains(string(data), "## SuperClaude v9.9.9") {
+			t.Fatalf("Expected the overridden import section, got:\n%s", data)
+		}
+		clean(t, targetDir)
+
+		if data, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.md")); string(data) != "# My project\n" {
+			t.Errorf("Expected the import section removed with its heading, got:\n%q", data)
+		}
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, templates)
+		install(t, targetDir, ImportIntoLocal)
+		clean(t, targetDir)
+
+		if fileExists(ImportIntoLocal.Path(targetDir)) {
+			data, _ := os.ReadFile(ImportIntoLocal.Path(targetDir))
+			t.Errorf("Expected the installer-created CLAUDE.local.md to be removed, got:\n%q", data)
+		}
+	})
+
+	t.Run("Moved", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, templates)
+		writeTestFiles(t, targetDir, map[string]string{"CLAUDE.md": "# My project\n"})
+		install(t, targetDir, ImportIntoProject)
+		install(t, targetDir, ImportIntoLocal)
+
+		if data, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.md")); string(data) != "# My project\n" {
+			t.Errorf("Expected moving the import to remove its whole section, got:\n%q", data)
+		}
+		if manifest, _ := LoadInstallManifest(targetDir); manifest == nil || manifest.ImportInto != ImportIntoLocal {
+			t.Errorf("Expected the manifest to record the new location, got %+v", manifest)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// InitProfile holds reusable init answers. init --wizard saves them to
+// .superclaude-lite/init.json and init --config applies them to a later run.
+type InitProfile struct {
+	ImportInto   ImportLocation `json:"importInto,omitempty"`
+	FrameworkRef string         `json:"frameworkRef,omitempty"` // Empty uses the fixed commit
+	Core         []string       `json:"core,omitempty"`         // Core files, or "none"
+	Modes        []string       `json:"modes,omitempty"`        // Modes, or "none"
+	MCPServers   []string       `json:"mcpServers,omitempty"`   // Server names, or "none"
+	NoBackup     bool           `json:"noBackup,omitempty"`
+	BackupDir    string         `json:"backupDir,omitempty"`
+}
+
+// InitProfilePath returns the project's saved init answers file
+func InitProfilePath(targetDir string) string {
+	return filepath.Join(ProjectConfigDir(targetDir), config.InitProfileFile)
+}
+
+// LoadInitProfile reads saved init answers
+func LoadInitProfile(path string) (*InitProfile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- file named with --config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read init config: %w", err)
+	}
+
+	var profile InitProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse init config %s: %w", path, err)
+	}
+	if profile.ImportInto != "" {
+		if _, err := ParseImportLocation(string(profile.ImportInto)); err != nil {
+			return nil, fmt.Errorf("invalid init config %s: %w", path, err)
+		}
+	}
+
+	return &profile, nil
+}
+
+// Save writes the answers to the project's .superclaude-lite/init.json and returns its path
+func (p *InitProfile) Save(targetDir string) (string, error) {
+	path := InitProfilePath(targetDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	output, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal init config: %w", err)
+	}
+	if err := os.WriteFile(path, append(output, '\n'), 0o644); err != nil { // #nosec G306 -- shared project config without secrets
+		return "", fmt.Errorf("failed to write init config: %w", err)
+	}
+
+	return path, nil
+}
+
+// Apply fills the options the command line left unset from the saved answers, so
+// flags always win
+func (p *InitProfile) Apply(cfg *InstallConfig) {
+	if cfg.ImportInto == "" {
+		cfg.ImportInto = p.ImportInto
+	}
+	if cfg.FrameworkRef == "" {
+		cfg.FrameworkRef = p.FrameworkRef
+	}
+	if !cfg.Components.Interactive {
+		if len(cfg.Components.Core) == 0 {
+			cfg.Components.Core = p.Core
+		}
+		if len(cfg.Components.Modes) == 0 {
+			cfg.Components.Modes = p.Modes
+		}
+	}
+	if cfg.MCPServers == nil && p.MCPServers != nil {
+		cfg.MCPServers = p.MCPServers
+		if !isNoneSelection(p.MCPServers) {
+			cfg.AddRecommendedMCP = true
+		}
+	}
+	if p.NoBackup {
+		cfg.NoBackup = true
+	}
+	if cfg.BackupDir == "" {
+		cfg.BackupDir = p.BackupDir
+	}
+}
+
+// Override sets every option the wizard asks about from its answers. The screens start
+// from the command line options, so answers replace flags rather than fill gaps.
+func (p *InitProfile) Override(cfg *InstallConfig) {
+	cfg.ImportInto = p.ImportInto
+	cfg.FrameworkRef = p.FrameworkRef
+	cfg.Components = ComponentSelection{Core: p.Core, Modes: p.Modes}
+	cfg.MCPServers = p.MCPServers
+	if !isNoneSelection(p.MCPServers) {
+		cfg.AddRecommendedMCP = true
+	}
+	cfg.NoBackup = p.NoBackup
+	cfg.BackupDir = p.BackupDir
+}
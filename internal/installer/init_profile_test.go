@@ -0,0 +1,215 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestInitProfile validates saving, loading and applying wizard answers
+func TestInitProfile(t *testing.T) {
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		targetDir := t.TempDir()
+		profile := &InitProfile{
+			ImportInto:   ImportIntoLocal,
+			FrameworkRef: "v4.1.0",
+			Core:         []string{"flags", "rules"},
+			Modes:        []string{"none"},
+			MCPServers:   []string{"context7"},
+			NoBackup:     true,
+		}
+
+		path, err := profile.Save(targetDir)
+		if err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if path != filepath.Join(targetDir, ".superclaude-lite", "init.json") {
+			t.Errorf("Expected the project's init.json, got %s", path)
+		}
+
+		loaded, err := LoadInitProfile(path)
+		if err != nil {
+			t.Fatalf("LoadInitProfile failed: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, profile) {
+			t.Errorf("Expected %+v, got %+v", profile, loaded)
+		}
+	})
+
+	t.Run("InvalidImportLocation", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "init.json")
+		if err := os.WriteFile(path, []byte(`{"importInto": "home"}`), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		if _, err := LoadInitProfile(path); err == nil {
+			t.Errorf("Expected an error for an unknown import location")
+		}
+	})
+
+	t.Run("FlagsWin", func(t *testing.T) {
+		profile := &InitProfile{
+			ImportInto:   ImportIntoLocal,
+			FrameworkRef: "main",
+			Core:         []string{"flags"},
+			Modes:        []string{"none"},
+			MCPServers:   []string{"serena"},
+			BackupDir:    "backups",
+		}
+		cfg := &InstallConfig{
+			ImportInto: ImportIntoProject,
+			Components: ComponentSelection{Core: []string{"rules"}},
+		}
+		profile.Apply(cfg)
+
+		if cfg.ImportInto != ImportIntoProject || !reflect.DeepEqual(cfg.Components.Core, []string{"rules"}) {
+			t.Errorf("Expected flags to override the saved answers, got %+v", cfg)
+		}
+		if cfg.FrameworkRef != "main" || !reflect.DeepEqual(cfg.Components.Modes, []string{"none"}) || cfg.BackupDir != "backups" {
+			t.Errorf("Expected the saved answers for unset options, got %+v", cfg)
+		}
+		if !cfg.AddRecommendedMCP || !reflect.DeepEqual(cfg.MCPServers, []string{"serena"}) {
+			t.Errorf("Expected saved MCP servers to enable MCP, got %+v", cfg)
+		}
+	})
+
+	t.Run("WizardAnswersWin", func(t *testing.T) {
+		cfg := &InstallConfig{
+			ImportInto: ImportIntoLocal,
+			Components: ComponentSelection{Modes: []string{"brainstorming"}, ExcludeCore: []string{"rules"}},
+			MCPServers: []string{"context7"},
+			NoBackup:   true,
+		}
+		(&InitProfile{
+			ImportInto: ImportIntoProject,
+			Core:       []string{"flags", "rules"},
+			Modes:      []string{"none"},
+			MCPServers: []string{"serena"},
+		}).Override(cfg)
+
+		expected := &InstallConfig{
+			ImportInto:        ImportIntoProject,
+			Components:        ComponentSelection{Core: []string{"flags", "rules"}, Modes: []string{"none"}},
+			MCPServers:        []string{"serena"},
+			AddRecommendedMCP: true,
+		}
+		if !reflect.DeepEqual(cfg, expected) {
+			t.Errorf("Expected the answers to replace the flags, got %+v", cfg)
+		}
+	})
+
+	t.Run("NoMCPServers", func(t *testing.T) {
+		cfg := &InstallConfig{}
+		(&InitProfile{MCPServers: []string{"none"}}).Apply(cfg)
+		if cfg.AddRecommendedMCP || !isNoneSelection(cfg.MCPServers) {
+			t.Errorf("Expected none to skip the MCP selector without adding servers, got %+v", cfg)
+		}
+	})
+}
+
+// TestPlanInstallation validates that the plan lists the files init writes, marks the
+// existing ones and matches an actual installation
+func TestPlanInstallation(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	targetDir := t.TempDir()
+	writeTestFiles(t, repoPath, map[string]string{
+		"SuperClaude/Agents/system-architect.md": "# System Architect\n",
+		"SuperClaude/MCP/MCP_Magic.md":           "# Magic\n",
+		"SuperClaude/MCP/configs/magic.json":     `{"magic": {"command": "npx", "args": ["-y", "@21st-dev/magic-mcp@latest"], "env": {"TWENTYFIRST_API_KEY": ""}}}`,
+	})
+	writeTestFiles(t, targetDir, map[string]string{
+		"CLAUDE.md":                   "# My project\n",
+		"CLAUDE.local.md":             "# Mine\n\n@./.superclaude/CLAUDE.md\n",
+		".claude/settings.local.json": "{}\n",
+	})
+
+	originalPrompt := promptSecret
+	defer func() { promptSecret = originalPrompt }()
+	promptSecret = func(requirement MCPEnvRequirement) (string, error) { return "tf-secret", nil }
+	t.Setenv("TWENTYFIRST_API_KEY", "")
+
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+	selected, err := ResolveMCPServers(servers, []string{"context7", "magic"})
+	if err != nil {
+		t.Fatalf("ResolveMCPServers failed: %v", err)
+	}
+
+	cfg := &InstallConfig{
+		AddRecommendedMCP: true,
+		MCPServers:        []string{"context7", "magic"},
+		ImportInto:        ImportIntoProject,
+		ApproveMCP:        MCPApprovalSelected,
+		Components:        ComponentSelection{Modes: []string{"none"}},
+		FrameworkDir:      repoPath,
+	}
+	plan, err := PlanInstallation(targetDir, repoPath, cfg, selected)
+	if err != nil {
+		t.Fatalf("PlanInstallation failed: %v", err)
+	}
+
+	planned := make(map[string]bool)
+	for _, file := range plan.Files {
+		planned[file.Path] = file.Exists
+	}
+	expected := map[string]bool{
+		".superclaude/FLAGS.md":                    false,
+		".superclaude/Commands/help.md":            false,
+		".superclaude/Agents/system-architect.md":  false,
+		".superclaude/Modes/MODE_Brainstorming.md": false,
+		".superclaude/MCP/MCP_Context7.md":         false,
+		".superclaude/CLAUDE.md":                   false,
+		"CLAUDE.md":                                true,
+		".mcp.json":                                false,
+		".claude/commands/sc":                      false,
+		"CLAUDE.local.md":                          true,
+		".superclaude-lite/secrets.env":            false,
+		".superclaude-lite/.gitignore":             false,
+		".claude/settings.local.json":              true,
+	}
+	for path, exists := range expected {
+		if got, ok := planned[path]; !ok || got != exists {
+			t.Errorf("Expected %s in the plan (exists %v), got %v %v", path, exists, ok, got)
+		}
+	}
+	if _, ok := planned[".superclaude/MCP/MCP_Serena.md"]; ok {
+		t.Errorf("Expected unselected servers' docs to be left out")
+	}
+	if plan.BackupDir == "" || !reflect.DeepEqual(plan.Backups, []string{"CLAUDE.md", "CLAUDE.local.md", ".claude"}) {
+		t.Errorf("Expected the existing files to be backed up, got %q %v", plan.BackupDir, plan.Backups)
+	}
+
+	// The installation from the same checkout writes what was planned
+	installer, err := NewInstaller(targetDir, cfg)
+	if err != nil {
+		t.Fatalf("NewInstaller failed: %v", err)
+	}
+	if err := installer.Install(); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	for _, file := range plan.Files {
+		if _, err := os.Lstat(filepath.Join(targetDir, file.Path)); err != nil {
+			t.Errorf("Expected planned %s to be written: %v", file.Path, err)
+		}
+	}
+	if _, err := os.Stat(repoPath); err != nil {
+		t.Errorf("Expected the framework checkout to be left for its owner: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(targetDir, "CLAUDE.local.md")); strings.TrimSpace(string(data)) != "# Mine" {
+		t.Errorf("Expected the moved import to be removed from CLAUDE.local.md, got %q", data)
+	}
+
+	// Stored secrets are neither asked for nor written again
+	plan, err = PlanInstallation(targetDir, repoPath, cfg, selected)
+	if err != nil {
+		t.Fatalf("PlanInstallation failed: %v", err)
+	}
+	for _, file := range plan.Files {
+		if strings.HasPrefix(file.Path, ".superclaude-lite/") || file.Path == "CLAUDE.local.md" {
+			t.Errorf("Expected %s to be left out once nothing changes there", file.Path)
+		}
+	}
+}
@@ -0,0 +1,719 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// wizardStep is a screen of the init wizard
+type wizardStep int
+
+const (
+	wizardTarget wizardStep = iota
+	wizardRef
+	wizardFetching
+	wizardComponents
+	wizardMCP
+	wizardBackup
+	wizardReview
+)
+
+// wizardStepTitles names the screens in the progress line; fetching counts as the ref step
+var wizardStepTitles = []string{"Project", "Framework version", "Framework version", "Core files and modes", "MCP servers", "Backups", "Review"}
+
+// wizardStepCount is the number of screens the progress line counts
+const wizardStepCount = 6
+
+// wizardImportChoices are the import locations offered, the first keeping an existing one
+var wizardImportChoices = []struct {
+	location ImportLocation
+	label    string
+}{
+	{"", "Keep an existing import, else CLAUDE.md"},
+	{ImportIntoProject, "CLAUDE.md (shared with the team)"},
+	{ImportIntoClaudeDir, ".claude/CLAUDE.md"},
+	{ImportIntoLocal, "CLAUDE.local.md (personal, not committed)"},
+}
+
+// Backup choices of the wizard
+const (
+	wizardBackupDefault = iota
+	wizardBackupCustom
+	wizardBackupNone
+)
+
+var wizardBackupChoices = []string{
+	"Back up changed files to .superclaude-backup-<timestamp>",
+	"Back up changed files to a directory of my choice",
+	"Don't back up",
+}
+
+// FrameworkFetcher checks out the framework at ref ("" for the fixed commit) and
+// returns the checkout path
+type FrameworkFetcher func(ref string) (string, error)
+
+// frameworkFetchedMsg reports a finished FrameworkFetcher call
+type frameworkFetchedMsg struct {
+	ref  string
+	path string
+	err  error
+}
+
+// InitWizardResult holds the answers of a completed wizard
+type InitWizardResult struct {
+	TargetDir    string
+	Profile      InitProfile
+	FrameworkDir string // Checkout fetched for Profile.FrameworkRef
+	Save         bool   // Save the answers to the project's init.json
+}
+
+// InitWizardModel is the multi-step init wizard. It embeds the component and MCP
+// selectors, treating their confirm as next and their quit as back.
+type InitWizardModel struct {
+	step  wizardStep
+	base  InstallConfig // Options from the command line, applied under the answers
+	saved *InitProfile  // Previous answers used as defaults
+	fetch FrameworkFetcher
+
+	targetInput  textinput.Model
+	importCursor int
+	refInput     textinput.Model
+
+	repoPath   string // Checkout of fetchedRef
+	fetchedRef string
+	loadedFor  string // Target the selectors were preselected for
+	err        error  // Shown on the current screen
+
+	components ComponentSelectorModel
+	mcp        MCPSelectorModel
+
+	backupCursor int
+	backupInput  textinput.Model
+
+	plan   *InstallPlan
+	review viewport.Model
+	save   bool
+
+	width, height int
+	done          bool
+	cancelled     bool
+}
+
+// NewInitWizard creates the wizard for targetDir with the command line options in base,
+// defaulting the answers to saved when a previous run stored them
+func NewInitWizard(targetDir string, base InstallConfig, saved *InitProfile, fetch FrameworkFetcher) InitWizardModel {
+	m := InitWizardModel{
+		base:   base,
+		saved:  saved,
+		fetch:  fetch,
+		review: viewport.New(80, 20),
+		save:   true,
+	}
+
+	m.targetInput = textinput.New()
+	m.targetInput.Prompt = "> "
+	m.targetInput.SetValue(targetDir)
+	m.targetInput.Focus()
+
+	m.refInput = textinput.New()
+	m.refInput.Prompt = "> "
+	m.refInput.Placeholder = config.FixedCommit + " (tested)"
+
+	m.backupInput = textinput.New()
+	m.backupInput.Prompt = "  > "
+	m.backupInput.Placeholder = "backup directory"
+
+	importInto, ref, backupDir, noBackup := base.ImportInto, base.FrameworkRef, base.BackupDir, base.NoBackup
+	if saved != nil {
+		if importInto == "" {
+			importInto = saved.ImportInto
+		}
+		if ref == "" {
+			ref = saved.FrameworkRef
+		}
+		if backupDir == "" {
+			backupDir = saved.BackupDir
+		}
+		noBackup = noBackup || saved.NoBackup
+	}
+	for i, choice := range wizardImportChoices {
+		if choice.location == importInto {
+			m.importCursor = i
+		}
+	}
+	m.refInput.SetValue(ref)
+	switch {
+	case noBackup:
+		m.backupCursor = wizardBackupNone
+	case backupDir != "":
+		m.backupCursor = wizardBackupCustom
+		m.backupInput.SetValue(backupDir)
+	}
+
+	return m
+}
+
+func (m InitWizardModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m InitWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resizeReview()
+		m.mcp = m.sizeMCP(m.mcp)
+		return m, nil
+
+	case frameworkFetchedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("failed to fetch the framework: %w", msg.err)
+			m.step = wizardRef
+			return m, m.refInput.Focus()
+		}
+		m.repoPath, m.fetchedRef = msg.path, msg.ref
+		return m.enterComponents()
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.cancelled = true
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case wizardTarget:
+			return m.updateTarget(msg)
+		case wizardRef:
+			return m.updateRef(msg)
+		case wizardComponents:
+			return m.updateComponents(msg)
+		case wizardMCP:
+			return m.updateMCP(msg)
+		case wizardBackup:
+			return m.updateBackup(msg)
+		case wizardReview:
+			return m.updateReview(msg)
+		}
+		return m, nil
+	}
+
+	// Cursor blinks and other messages go to the screen's input
+	var cmd tea.Cmd
+	switch m.step {
+	case wizardTarget:
+		m.targetInput, cmd = m.targetInput.Update(msg)
+	case wizardRef:
+		m.refInput, cmd = m.refInput.Update(msg)
+	case wizardBackup:
+		m.backupInput, cmd = m.backupInput.Update(msg)
+	case wizardMCP:
+		var model tea.Model
+		model, cmd = m.mcp.Update(msg)
+		m.mcp = model.(MCPSelectorModel)
+	}
+	return m, cmd
+}
+
+func (m InitWizardModel) updateTarget(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeyUp:
+		if m.importCursor > 0 {
+			m.importCursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.importCursor < len(wizardImportChoices)-1 {
+			m.importCursor++
+		}
+		return m, nil
+	case tea.KeyEnter:
+		if strings.TrimSpace(m.targetInput.Value()) == "" {
+			m.err = fmt.Errorf("enter the project directory")
+			return m, nil
+		}
+		m.err = nil
+		m.step = wizardRef
+		m.targetInput.Blur()
+		return m, m.refInput.Focus()
+	}
+
+	var cmd tea.Cmd
+	m.targetInput, cmd = m.targetInput.Update(msg)
+	return m, cmd
+}
+
+func (m InitWizardModel) updateRef(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.err = nil
+		m.step = wizardTarget
+		m.refInput.Blur()
+		return m, m.targetInput.Focus()
+	case tea.KeyEnter:
+		m.err = nil
+		m.refInput.Blur()
+		ref := strings.TrimSpace(m.refInput.Value())
+		if m.repoPath != "" && ref == m.fetchedRef {
+			return m.enterComponents()
+		}
+		m.step = wizardFetching
+		fetch := m.fetch
+		return m, func() tea.Msg {
+			path, err := fetch(ref)
+			return frameworkFetchedMsg{ref: ref, path: path, err: err}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.refInput, cmd = m.refInput.Update(msg)
+	return m, cmd
+}
+
+// enterComponents shows the component selector, building both selectors from the
+// fetched framework unless they already match the checkout and target
+func (m InitWizardModel) enterComponents() (tea.Model, tea.Cmd) {
+	targetDir := m.targetDir()
+	if m.loadedFor != m.repoPath+"\x00"+targetDir {
+		if err := m.loadFramework(targetDir); err != nil {
+			m.err = err
+			m.step = wizardRef
+			return m, m.refInput.Focus()
+		}
+		m.loadedFor = m.repoPath + "\x00" + targetDir
+	}
+	m.step = wizardComponents
+	return m, nil
+}
+
+// loadFramework builds the selectors from the checkout, checking the command line
+// selection, else the saved answers, else the previous installation, else everything
+func (m *InitWizardModel) loadFramework(targetDir string) error {
+	availableCore, err := DiscoverCoreFiles(m.repoPath)
+	if err != nil {
+		return err
+	}
+	availableModes, err := DiscoverModes(m.repoPath)
+	if err != nil {
+		return err
+	}
+
+	var previousCore, previousModes []string
+	if manifest, err := LoadInstallManifest(targetDir); err == nil && manifest != nil {
+		previousCore, previousModes = manifest.Core, manifest.Modes
+	}
+	selection := m.base.Components
+	if m.saved != nil {
+		if len(selection.Core) == 0 {
+			selection.Core = m.saved.Core
+		}
+		if len(selection.Modes) == 0 {
+			selection.Modes = m.saved.Modes
+		}
+	}
+	// Names missing at this ref fall back to the previous selection
+	core, err := resolveComponents(availableCore, selection.Core, selection.ExcludeCore, previousCore, ComponentCore)
+	if err != nil {
+		core, _ = resolveComponents(availableCore, nil, nil, previousCore, ComponentCore)
+	}
+	modes, err := resolveComponents(availableModes, selection.Modes, selection.ExcludeMode, previousModes, ComponentMode)
+	if err != nil {
+		modes, _ = resolveComponents(availableModes, nil, nil, previousModes, ComponentMode)
+	}
+	m.components = NewComponentSelector(append(availableCore, availableModes...), append(core, modes...))
+
+	servers, err := DiscoverAllMCPServers(m.repoPath, targetDir)
+	if err != nil {
+		return err
+	}
+	preselectConfiguredMCPServers(servers, m.repoPath, filepath.Join(targetDir, config.MCPConfigFile))
+	names := m.base.MCPServers
+	if names == nil && m.saved != nil {
+		names = m.saved.MCPServers
+	}
+	if names != nil {
+		selected, _ := ResolveMCPServers(servers, names)
+		for i := range servers {
+			servers[i].Selected = false
+			for _, server := range selected {
+				if server.Name == servers[i].Name {
+					servers[i].Selected = true
+				}
+			}
+		}
+	}
+	m.mcp = m.sizeMCP(NewMCPSelector(servers, m.repoPath))
+
+	return nil
+}
+
+// sizeMCP passes the terminal size to the MCP selector, less the wizard's progress line
+func (m InitWizardModel) sizeMCP(selector MCPSelectorModel) MCPSelectorModel {
+	if m.width == 0 {
+		return selector
+	}
+	model, _ := selector.Update(tea.WindowSizeMsg{Width: m.width, Height: max(m.height-2, 1)})
+	return model.(MCPSelectorModel)
+}
+
+func (m InitWizardModel) updateComponents(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	model, cmd := m.components.Update(msg)
+	m.components = model.(ComponentSelectorModel)
+	switch {
+	case m.components.confirmed:
+		m.components.confirmed = false
+		m.step = wizardMCP
+		return m, nil
+	case m.components.quitting:
+		m.components.quitting = false
+		m.step = wizardRef
+		return m, m.refInput.Focus()
+	}
+	return m, cmd
+}
+
+func (m InitWizardModel) updateMCP(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	model, cmd := m.mcp.Update(msg)
+	m.mcp = model.(MCPSelectorModel)
+	switch {
+	case m.mcp.confirmed:
+		m.mcp.confirmed = false
+		m.step = wizardBackup
+		if m.backupCursor == wizardBackupCustom {
+			return m, m.backupInput.Focus()
+		}
+		return m, nil
+	case m.mcp.quitting:
+		m.mcp.quitting = false
+		m.step = wizardComponents
+		return m, nil
+	}
+	return m, cmd
+}
+
+func (m InitWizardModel) updateBackup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.err = nil
+		m.backupInput.Blur()
+		m.step = wizardMCP
+		return m, nil
+	case tea.KeyUp, tea.KeyDown:
+		if msg.Type == tea.KeyUp && m.backupCursor > 0 {
+			m.backupCursor--
+		}
+		if msg.Type == tea.KeyDown && m.backupCursor < len(wizardBackupChoices)-1 {
+			m.backupCursor++
+		}
+		if m.backupCursor == wizardBackupCustom {
+			return m, m.backupInput.Focus()
+		}
+		m.backupInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		if m.backupCursor == wizardBackupCustom && strings.TrimSpace(m.backupInput.Value()) == "" {
+			m.err = fmt.Errorf("enter the backup directory")
+			return m, nil
+		}
+		m.err = nil
+		m.backupInput.Blur()
+		return m.enterReview(), nil
+	}
+
+	if m.backupCursor != wizardBackupCustom {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.backupInput, cmd = m.backupInput.Update(msg)
+	return m, cmd
+}
+
+// enterReview plans the installation the answers describe and shows it
+func (m InitWizardModel) enterReview() InitWizardModel {
+	m.step = wizardReview
+	cfg := m.installConfig()
+	servers, _ := ResolveMCPServers(m.mcp.servers, cfg.MCPServers)
+	m.plan, m.err = PlanInstallation(m.targetDir(), m.repoPath, &cfg, servers)
+	m.resizeReview()
+	m.review.SetContent(m.renderPlan())
+	m.review.GotoTop()
+	return m
+}
+
+func (m InitWizardModel) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.err = nil
+		m.step = wizardBackup
+		if m.backupCursor == wizardBackupCustom {
+			return m, m.backupInput.Focus()
+		}
+		return m, nil
+	case msg.Type == tea.KeyEnter && m.err == nil:
+		m.done = true
+		return m, tea.Quit
+	case msg.String() == "s":
+		m.save = !m.save
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.review, cmd = m.review.Update(msg)
+	return m, cmd
+}
+
+// resizeReview fits the review viewport between the header and the save toggle
+func (m *InitWizardModel) resizeReview() {
+	if m.width == 0 {
+		return
+	}
+	m.review.Width = m.width
+	m.review.Height = max(m.height-9, 3)
+}
+
+// targetDir returns the absolute project directory entered on the first screen
+func (m InitWizardModel) targetDir() string {
+	dir := strings.TrimSpace(m.targetInput.Value())
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// Profile returns the wizard's answers; empty selections are recorded as "none" so a
+// later --config run doesn't fall back to everything
+func (m InitWizardModel) Profile() InitProfile {
+	profile := InitProfile{
+		ImportInto:   wizardImportChoices[m.importCursor].location,
+		FrameworkRef: strings.TrimSpace(m.refInput.Value()),
+		Core:         []string{"none"},
+		Modes:        []string{"none"},
+		MCPServers:   []string{"none"},
+	}
+
+	var core, modes []string
+	for _, component := range m.components.GetSelectedComponents() {
+		if component.Kind == ComponentMode {
+			modes = append(modes, component.Name)
+		} else {
+			core = append(core, component.Name)
+		}
+	}
+	if len(core) > 0 {
+		profile.Core = core
+	}
+	if len(modes) > 0 {
+		profile.Modes = modes
+	}
+
+	var servers []string
+	for _, server := range m.mcp.GetSelectedServers() {
+		servers = append(servers, strings.ToLower(server.Name))
+	}
+	if len(servers) > 0 {
+		profile.MCPServers = servers
+	}
+
+	switch m.backupCursor {
+	case wizardBackupCustom:
+		profile.BackupDir = strings.TrimSpace(m.backupInput.Value())
+	case wizardBackupNone:
+		profile.NoBackup = true
+	}
+
+	return profile
+}
+
+// installConfig returns the command line options with the answers applied, as init runs them
+func (m InitWizardModel) installConfig() InstallConfig {
+	cfg := m.base
+	profile := m.Profile()
+	profile.Override(&cfg)
+	cfg.FrameworkDir = m.repoPath
+	return cfg
+}
+
+// renderPlan lists the planned files for the review viewport
+func (m InitWizardModel) renderPlan() string {
+	if m.err != nil {
+		return fmt.Sprintf("❌ %v", m.err)
+	}
+
+	var b strings.Builder
+	created, changed := 0, 0
+	for _, file := range m.plan.Files {
+		if file.Exists {
+			changed++
+		} else {
+			created++
+		}
+	}
+	b.WriteString(fmt.Sprintf("%d files to create, %d to modify\n\n", created, changed))
+	for _, file := range m.plan.Files {
+		mark := "+"
+		if file.Exists {
+			mark = "~"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s\n", mark, file.Path))
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.plan.BackupDir == "":
+		b.WriteString("No backups\n")
+	case len(m.plan.Backups) == 0:
+		b.WriteString("Nothing to back up\n")
+	default:
+		b.WriteString(fmt.Sprintf("Backed up to %s first:\n", m.plan.BackupDir))
+		for _, path := range m.plan.Backups {
+			b.WriteString(fmt.Sprintf("  %s\n", path))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m InitWizardModel) View() string {
+	if m.cancelled {
+		return "\nCancelled the init wizard.\n"
+	}
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+	number := int(m.step) + 1
+	if m.step >= wizardFetching {
+		number = int(m.step)
+	}
+	b.WriteString(titleStyle.Render("SuperClaude Setup"))
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  Step %d of %d · %s", number, wizardStepCount, wizardStepTitles[m.step])))
+	b.WriteString("\n\n")
+
+	var footer string
+	switch m.step {
+	case wizardTarget:
+		b.WriteString("Project directory\n")
+		b.WriteString(m.targetInput.View())
+		b.WriteString("\n\nWrite the SuperClaude import to\n")
+		b.WriteString(m.renderChoices(m.importCursor, importChoiceLabels()))
+		footer = "↑/↓ choose the import file · enter next · esc cancel"
+
+	case wizardRef:
+		b.WriteString("Framework branch, tag or commit (empty for the tested commit)\n")
+		b.WriteString(m.refInput.View())
+		footer = "enter fetch the framework · esc back"
+
+	case wizardFetching:
+		ref := m.refInput.Value()
+		if ref == "" {
+			ref = config.FixedCommit
+		}
+		b.WriteString(fmt.Sprintf("Fetching SuperClaude Framework at %s…", ref))
+
+	case wizardComponents:
+		b.WriteString(m.components.View())
+
+	case wizardMCP:
+		b.WriteString(m.mcp.View())
+
+	case wizardBackup:
+		b.WriteString("Before changing existing files\n")
+		b.WriteString(m.renderChoices(m.backupCursor, wizardBackupChoices))
+		if m.backupCursor == wizardBackupCustom {
+			b.WriteString("\n")
+			b.WriteString(m.backupInput.View())
+		}
+		footer = "↑/↓ choose · enter review · esc back"
+
+	case wizardReview:
+		b.WriteString(fmt.Sprintf("Install into %s (+ create, ~ modify)\n\n", m.targetDir()))
+		b.WriteString(m.review.View())
+		checkbox := checkboxUnchecked
+		if m.save {
+			checkbox = checkboxChecked
+		}
+		b.WriteString(fmt.Sprintf("\n\n%s Save these answers to %s for init --config\n",
+			checkbox, filepath.Join(config.ToolConfigDir, config.InitProfileFile)))
+		footer = "↑/↓ scroll · s save answers · enter install · esc back"
+	}
+
+	if m.err != nil && m.step != wizardReview {
+		b.WriteString(fmt.Sprintf("\n\n❌ %v", m.err))
+	}
+	if footer != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(footer))
+	}
+
+	return b.String()
+}
+
+// renderChoices renders a single-choice list with the cursor's choice marked
+func (m InitWizardModel) renderChoices(cursor int, labels []string) string {
+	var b strings.Builder
+	for i, label := range labels {
+		if i == cursor {
+			b.WriteString(selectedStyle.Render("(•) " + label))
+		} else {
+			b.WriteString(unselectedStyle.Render("( ) " + label))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func importChoiceLabels() []string {
+	labels := make([]string, len(wizardImportChoices))
+	for i, choice := range wizardImportChoices {
+		labels[i] = choice.label
+	}
+	return labels
+}
+
+// Result returns the answers of a completed wizard
+func (m InitWizardModel) Result() *InitWizardResult {
+	return &InitWizardResult{
+		TargetDir:    m.targetDir(),
+		Profile:      m.Profile(),
+		FrameworkDir: m.repoPath,
+		Save:         m.save,
+	}
+}
+
+// ShowInitWizard runs the wizard full screen and returns the answers
+func ShowInitWizard(targetDir string, base InstallConfig, saved *InitProfile, fetch FrameworkFetcher) (*InitWizardResult, error) {
+	if !useTUI() {
+		reason := "needs an interactive terminal"
+		switch {
+		case promptOptions.AssumeYes:
+			reason = "asks every question and can't run with --yes"
+		case promptOptions.NoInput:
+			reason = "can't run with --no-input"
+		}
+		return nil, fmt.Errorf("the init wizard %s; run init with flags, or with --config %s to reuse saved answers",
+			reason, filepath.Join(config.ToolConfigDir, config.InitProfileFile))
+	}
+
+	program := tea.NewProgram(NewInitWizard(targetDir, base, saved, fetch), tea.WithAltScreen())
+	finalModel, err := program.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run init wizard: %w", err)
+	}
+
+	final := finalModel.(InitWizardModel)
+	if !final.done {
+		return nil, fmt.Errorf("user cancelled the init wizard")
+	}
+
+	return final.Result(), nil
+}
@@ -0,0 +1,190 @@
+package installer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestInitWizard validates the wizard's steps, the review of planned files and the
+// answers it returns
+func TestInitWizard(t *testing.T) {
+	// press sends keys, running fetches synchronously so each step is reached in order
+	press := func(t *testing.T, m InitWizardModel, keys ...string) InitWizardModel {
+		t.Helper()
+		for _, k := range keys {
+			var msg tea.KeyMsg
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			case "up":
+				msg = tea.KeyMsg{Type: tea.KeyUp}
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			case "backspace":
+				msg = tea.KeyMsg{Type: tea.KeyBackspace}
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			model, cmd := m.Update(msg)
+			m = model.(InitWizardModel)
+			if m.step == wizardFetching && cmd != nil {
+				model, _ = m.Update(cmd())
+				m = model.(InitWizardModel)
+			}
+		}
+		return m
+	}
+
+	repoPath := createTestFrameworkRepo(t)
+	var fetched []string
+	fetch := func(ref string) (string, error) {
+		fetched = append(fetched, ref)
+		if ref == "missing" {
+			return "", fmt.Errorf("unknown revision")
+		}
+		return repoPath, nil
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{"CLAUDE.md": "# My project\n"})
+		fetched = nil
+
+		// Claude dir import, fixed commit, FLAGS.md unchecked, Context7, no backups
+		m := press(t, NewInitWizard(targetDir, InstallConfig{}, nil, fetch),
+			"down", "down", "enter", "enter", " ", "enter", " ", "enter", "down", "down", "enter")
+		if m.step != wizardReview {
+			t.Fatalf("Expected the review step, got %d:\n%s", m.step, m.View())
+		}
+		if !reflect.DeepEqual(fetched, []string{""}) {
+			t.Errorf("Expected one fetch at the fixed commit, got %q", fetched)
+		}
+
+		view := ansi.Strip(m.View())
+		for _, expected := range []string{"Step 6 of 6", "+ .claude/CLAUDE.md", "+ .superclaude/MCP/MCP_Context7.md", "+ .mcp.json", "No backups"} {
+			if !strings.Contains(view, expected) {
+				t.Errorf("Expected %q in the review, got:\n%s", expected, view)
+			}
+		}
+		if strings.Contains(view, "MCP_Serena.md") {
+			t.Errorf("Expected unselected servers to be left out of the review")
+		}
+
+		m = press(t, m, "s", "enter")
+		if !m.done {
+			t.Fatalf("Expected enter to confirm the review")
+		}
+		result := m.Result()
+		expected := InitProfile{
+			ImportInto: ImportIntoClaudeDir,
+			Core:       []string{"rules", "principles"},
+			Modes:      []string{"brainstorming", "introspection", "task_management", "orchestration", "token_efficiency"},
+			MCPServers: []string{"context7"},
+			NoBackup:   true,
+		}
+		if !reflect.DeepEqual(result.Profile, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, result.Profile)
+		}
+		if result.Save || result.TargetDir != targetDir || result.FrameworkDir != repoPath {
+			t.Errorf("Expected an unsaved result for %s from %s, got %+v", targetDir, repoPath, result)
+		}
+	})
+
+	t.Run("SavedAnswers", func(t *testing.T) {
+		saved := &InitProfile{
+			FrameworkRef: "v4.1.0",
+			Core:         []string{"flags"},
+			Modes:        []string{"none"},
+			MCPServers:   []string{"serena"},
+			BackupDir:    "backups",
+		}
+		fetched = nil
+
+		m := press(t, NewInitWizard(t.TempDir(), InstallConfig{}, saved, fetch), "enter", "enter", "enter", "enter", "enter")
+		if m.step != wizardReview || !reflect.DeepEqual(fetched, []string{"v4.1.0"}) {
+			t.Fatalf("Expected the saved ref to be fetched and the review reached, got %q:\n%s", fetched, m.View())
+		}
+		if profile := m.Profile(); !reflect.DeepEqual(profile, InitProfile{
+			FrameworkRef: "v4.1.0",
+			Core:         []string{"flags"},
+			Modes:        []string{"none"},
+			MCPServers:   []string{"serena"},
+			BackupDir:    "backups",
+		}) {
+			t.Errorf("Expected the saved answers to be the defaults, got %+v", profile)
+		}
+		if !m.Result().Save {
+			t.Errorf("Expected saving to be on by default")
+		}
+	})
+
+	t.Run("FlagsPreselect", func(t *testing.T) {
+		base := InstallConfig{
+			ImportInto: ImportIntoLocal,
+			Components: ComponentSelection{Core: []string{"flags"}, Modes: []string{"none"}},
+			MCPServers: []string{"serena"},
+			NoBackup:   true,
+		}
+
+		m := press(t, NewInitWizard(t.TempDir(), base, nil, fetch), "enter", "enter", "enter", "enter", "enter")
+		if m.step != wizardReview {
+			t.Fatalf("Expected the review step, got %d:\n%s", m.step, m.View())
+		}
+		if profile := m.Profile(); !reflect.DeepEqual(profile, InitProfile{
+			ImportInto: ImportIntoLocal,
+			Core:       []string{"flags"},
+			Modes:      []string{"none"},
+			MCPServers: []string{"serena"},
+			NoBackup:   true,
+		}) {
+			t.Errorf("Expected the flags to be the defaults on every screen, got %+v", profile)
+		}
+
+		// An answer changed on a screen wins over the flag, in the review and the install
+		m = press(t, NewInitWizard(t.TempDir(), base, nil, fetch), "up", "up", "enter", "enter", "enter", "enter", "enter")
+		cfg := m.installConfig()
+		if cfg.ImportInto != ImportIntoProject || !reflect.DeepEqual(cfg.Components.Core, []string{"flags"}) {
+			t.Errorf("Expected the chosen CLAUDE.md over --import-into, got %+v", cfg)
+		}
+		if view := ansi.Strip(m.View()); !strings.Contains(view, "+ CLAUDE.md") || strings.Contains(view, "CLAUDE.local.md") {
+			t.Errorf("Expected the review to show the chosen import file, got:\n%s", view)
+		}
+	})
+
+	t.Run("BackAndRefetch", func(t *testing.T) {
+		fetched = nil
+		m := press(t, NewInitWizard(t.TempDir(), InstallConfig{}, nil, fetch), "enter", "enter", "enter", "esc", "esc")
+		if m.step != wizardRef {
+			t.Fatalf("Expected esc to step back to the ref, got %d", m.step)
+		}
+
+		m = press(t, m, "m", "i", "s", "s", "i", "n", "g", "enter")
+		if m.step != wizardRef || !strings.Contains(m.View(), "unknown revision") {
+			t.Errorf("Expected a failed fetch to stay on the ref step, got:\n%s", m.View())
+		}
+
+		for range len("missing") {
+			m = press(t, m, "backspace")
+		}
+		m = press(t, m, "enter")
+		if m.step != wizardComponents || !reflect.DeepEqual(fetched, []string{"", "missing"}) {
+			t.Errorf("Expected the fetched checkout to be reused, got step %d fetches %q", m.step, fetched)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		model, _ := NewInitWizard(t.TempDir(), InstallConfig{}, nil, fetch).Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		if m := model.(InitWizardModel); !m.cancelled || m.done {
+			t.Errorf("Expected ctrl+c to cancel the wizard")
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// PlannedFile is a file or symlink an installation creates or modifies
+type PlannedFile struct {
+	Path   string // Relative to the project, with forward slashes
+	Exists bool   // Already present, so it is merged or replaced
+}
+
+// InstallPlan lists what an installation would change, for review before it runs
+type InstallPlan struct {
+	Files     []PlannedFile
+	BackupDir string   // Empty without backups
+	Backups   []string // Existing paths copied to BackupDir first
+}
+
+// PlanInstallation lists the files init writes into targetDir from the framework
+// checkout at repoPath, with the selected MCP servers, in the order the steps write them
+func PlanInstallation(targetDir, repoPath string, cfg *InstallConfig, servers []MCPServer) (*InstallPlan, error) {
+	ctx, err := NewInstallContext(targetDir, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.ScanExistingFiles(); err != nil {
+		return nil, err
+	}
+
+	plan := &InstallPlan{}
+	add := func(path string) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(targetDir, path)
+		}
+		_, err := os.Lstat(path)
+		plan.Files = append(plan.Files, PlannedFile{Path: relativeToTarget(targetDir, path), Exists: err == nil})
+	}
+
+	// Framework files copied into .superclaude
+	sources := []struct{ src, dst string }{
+		{config.CoreSourcePath, ""},
+		{config.CommandsSourcePath, "Commands"},
+		{config.AgentsSourcePath, "Agents"},
+		{config.ModesSourcePath, "Modes"},
+	}
+	for _, source := range sources {
+		files, err := markdownFiles(filepath.Join(repoPath, source.src))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			add(filepath.Join(config.SuperClaudeDir, source.dst, file))
+		}
+	}
+	add(filepath.Join(config.SuperClaudeDir, config.CLAUDEFile))
+
+	mcp := cfg.AddRecommendedMCP && len(servers) > 0
+	if mcp {
+		for _, server := range servers {
+			if server.MDFile != "" && server.docSource(repoPath) != "" {
+				add(filepath.Join(config.SuperClaudeDir, "MCP", server.MDFile))
+			}
+		}
+	}
+	add(filepath.Join(config.SuperClaudeDir, config.ManifestFile))
+
+	add(ctx.ImportLocation.RelPath())
+	// Other memory files lose their import when it moves
+	for _, location := range FindSuperClaudeImports(targetDir) {
+		if location != ctx.ImportLocation {
+			add(location.RelPath())
+		}
+	}
+
+	if cfg.AddRecommendedMCP {
+		add(config.MCPConfigFile)
+
+		// Secrets for the variables the servers reference, as configureMCPSecrets stores them
+		var requirements []MCPEnvRequirement
+		if mcp {
+			for _, change := range selectedMCPEnv(servers, repoPath) {
+				requirements = append(requirements, change.Env...)
+			}
+		}
+		secrets, err := storesMCPSecrets(targetDir, requirements, cfg.MCPEnv)
+		if err != nil {
+			return nil, err
+		}
+		if secrets {
+			add(SecretsPath(targetDir))
+			if !isGitignored(ProjectConfigDir(targetDir), config.SecretsFile) {
+				add(filepath.Join(ProjectConfigDir(targetDir), ".gitignore"))
+			}
+		}
+
+		for _, path := range mcpClientConfigPaths(targetDir, cfg.MCPTargets) {
+			add(path)
+		}
+		if cfg.ApproveMCP != MCPApprovalNone {
+			add(cfg.MCPSettings.File())
+		}
+	}
+
+	if !ctx.SkipClaudeDir {
+		add(filepath.Join(config.ClaudeDir, "commands", "sc"))
+		add(filepath.Join(config.ClaudeDir, "agents", "sc"))
+	}
+
+	// Existing files copied aside first, as createBackups does
+	if ctx.BackupManager != nil {
+		plan.BackupDir = relativeToTarget(targetDir, ctx.BackupDir)
+		candidates := []string{config.CLAUDEFile, config.ClaudeLocalFile, config.MCPConfigFile, config.SuperClaudeDir, config.ClaudeDir}
+		for _, path := range candidates {
+			if _, err := os.Lstat(filepath.Join(targetDir, path)); err == nil {
+				plan.Backups = append(plan.Backups, path)
+			}
+		}
+		for _, path := range mcpClientConfigPaths(targetDir, cfg.MCPTargets) {
+			if _, err := os.Lstat(path); err == nil {
+				plan.Backups = append(plan.Backups, relativeToTarget(targetDir, path))
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// markdownFiles returns the .md files under dir relative to it, as copyMarkdownFiles
+// copies them; a missing dir has none
+func markdownFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
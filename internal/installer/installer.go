@@ -3,13 +3,18 @@ package installer
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
 )
 
 // Installer manages the SuperClaude installation process
 type Installer struct {
-	steps   map[string]*InstallStep
-	context *InstallContext
-	graph   *DependencyGraph
+	steps    map[string]*InstallStep
+	context  *InstallContext
+	graph    *DependencyGraph
+	observer StepObserver
 }
 
 // NewInstaller creates a new installer instance
@@ -34,9 +39,18 @@ func NewInstaller(targetDir string, config *InstallConfig) (*Installer, error) {
 	return installer, nil
 }
 
+// SetObserver sends the step events of Install to observer instead of the log
+func (i *Installer) SetObserver(observer StepObserver) {
+	i.observer = observer
+}
+
 // Install executes the installation process using DAG-based topological sorting
 func (i *Installer) Install() error {
-	log.Printf("Starting SuperClaude installation")
+	observer := i.observer
+	if observer == nil {
+		observer = logStepEvent
+		log.Printf("Starting SuperClaude installation")
+	}
 
 	// Get topological ordering from the pre-built dependency graph
 	executionOrder, err := i.graph.GetTopologicalOrder()
@@ -44,30 +58,60 @@ func (i *Installer) Install() error {
 		return fmt.Errorf("failed to determine execution order: %w", err)
 	}
 
+	defer func() { i.context.pauseProgress = nil }()
+
 	// Execute steps in topological order
-	for _, stepName := range executionOrder {
+	for index, stepName := range executionOrder {
 		step, exists := i.steps[stepName]
 		if !exists {
 			return fmt.Errorf("step '%s' not found in available steps", stepName)
 		}
 
-		log.Printf("Executing step: %s", step.Name)
-
-		// Execute the step
-		if err := step.Execute(i.context); err != nil {
-			return fmt.Errorf("execution failed for step %s: %w", step.Name, err)
+		event := StepEvent{Step: step.Name, Title: step.Title, Index: index + 1, Total: len(executionOrder)}
+		started := time.Now()
+		var paused time.Duration
+
+		// Prompts inside the step pause its progress, and the time they wait isn't counted
+		i.context.pauseProgress = func() func() {
+			pausedAt := time.Now()
+			observer(event.with(StepPaused))
+			return func() {
+				paused += time.Since(pausedAt)
+				observer(event.with(StepResumed))
+			}
 		}
 
-		// Run validation if defined (after execution)
-		if step.Validate != nil {
-			if err := step.Validate(i.context); err != nil {
-				return fmt.Errorf("validation failed for step %s: %w", step.Name, err)
-			}
+		i.context.copiedFiles = 0
+		observer(event.with(StepStarted))
+
+		err := i.executeStep(step)
+		event.Elapsed = time.Since(started) - paused
+		if err != nil {
+			event.Err = err
+			observer(event.with(StepFailed))
+			return err
 		}
 
 		// Mark step as completed
 		i.context.Completed = append(i.context.Completed, step.Name)
-		log.Printf("Completed step: %s", step.Name)
+		event.Files = i.context.copiedFiles
+		observer(event.with(StepCompleted))
+	}
+
+	return nil
+}
+
+// executeStep runs a step and its validation
+func (i *Installer) executeStep(step *InstallStep) error {
+	if err := step.Execute(i.context); err != nil {
+		return fmt.Errorf("execution failed for step %s: %w", step.Name, err)
+	}
+
+	// Run validation if defined (after execution)
+	if step.Validate != nil {
+		if err := step.Validate(i.context); err != nil {
+			return fmt.Errorf("validation failed for step %s: %w", step.Name, err)
+		}
 	}
 
 	return nil
@@ -81,6 +125,12 @@ func (i *Installer) GetInstallationSummary() InstallationSummary {
 		CompletedSteps:   i.context.Completed,
 		ExistingFiles:    *i.context.ExistingFiles,
 		MCPConfigCreated: i.context.Config.AddRecommendedMCP,
+		ImportFile:       i.context.ImportLocation.RelPath(),
+		MCPChanges:       i.context.MCPChanges,
+		MCPRuntimeIssues: i.context.MCPRuntimeIssues,
+		MCPClientFiles:   i.context.MCPClientFiles,
+		MCPApproval:      i.context.MCPApproval,
+		Conflicts:        i.context.ConflictDecisions,
 	}
 
 	if i.context.BackupManager != nil {
@@ -101,6 +151,23 @@ type InstallationSummary struct {
 	BackedUpFiles    []string
 	ExistingFiles    ExistingFiles
 	MCPConfigCreated bool
+	ImportFile       string // Memory file holding the SuperClaude import, relative to TargetDir
+	MCPChanges       []MCPServerChange
+	MCPRuntimeIssues []MCPRuntimeIssue
+	MCPClientFiles   []string // Config files of other MCP clients that received the servers
+	MCPApproval      *MCPApproval
+	Conflicts        []ConflictDecision // Choices made for existing files under --interactive
+}
+
+// conflictAction returns what was chosen for an existing file under --interactive, or
+// an empty action if it wasn't asked about
+func (s *InstallationSummary) conflictAction(path string) ConflictAction {
+	for _, decision := range s.Conflicts {
+		if decision.Path == path {
+			return decision.Action
+		}
+	}
+	return ""
 }
 
 // PrintSummary displays a human-readable installation summary
@@ -118,28 +185,77 @@ func (s *InstallationSummary) PrintSummary() {
 
 	fmt.Printf("\nFiles created/modified:\n")
 
-	if s.ExistingFiles.CLAUDEmd {
-		fmt.Printf("  - CLAUDE.md (merged with SuperClaude import)\n")
-	} else {
-		fmt.Printf("  - CLAUDE.md (created)\n")
+	importFile, importFileExisted := s.ImportFile, s.ExistingFiles.ImportFile
+	if importFile == "" {
+		importFile, importFileExisted = config.CLAUDEFile, s.ExistingFiles.CLAUDEmd
+	}
+
+	switch {
+	case s.conflictAction(importFile) == ConflictSkip:
+		fmt.Printf("  - %s (left unchanged)\n", importFile)
+	case s.conflictAction(importFile) == ConflictOverwrite:
+		fmt.Printf("  - %s (overwritten with the SuperClaude template)\n", importFile)
+	case importFileExisted:
+		fmt.Printf("  - %s (merged with SuperClaude import)\n", importFile)
+	default:
+		fmt.Printf("  - %s (created)\n", importFile)
 	}
 
 	if s.MCPConfigCreated {
-		if s.ExistingFiles.MCPConfig {
+		switch action := s.conflictAction(config.MCPConfigFile); {
+		case action == ConflictSkip:
+			fmt.Printf("  - .mcp.json (left unchanged)\n")
+		case action == ConflictOverwrite:
+			fmt.Printf("  - .mcp.json (overwritten with the selected servers)\n")
+		case s.ExistingFiles.MCPConfig:
 			fmt.Printf("  - .mcp.json (merged with recommended servers)\n")
-		} else {
+			for _, change := range s.MCPChanges {
+				if len(change.Diff) > 0 {
+					fmt.Printf("      %s\n", describeMCPChange(change))
+				}
+			}
+		default:
 			fmt.Printf("  - .mcp.json (created with recommended servers)\n")
 		}
 	}
+	for _, file := range s.MCPClientFiles {
+		fmt.Printf("  - %s (merged with the selected servers)\n", relativeToTarget(s.TargetDir, file))
+	}
+	if approval := s.MCPApproval; approval != nil {
+		switch {
+		case approval.EnableAll:
+			fmt.Printf("  - %s (approved all project MCP servers)\n", approval.File)
+		case len(approval.Servers) > 0:
+			fmt.Printf("  - %s (approved %s)\n", approval.File, strings.Join(approval.Servers, ", "))
+		}
+	}
 
-	fmt.Printf("  - .superclaude/ (framework files)\n")
+	if s.conflictAction(config.SuperClaudeDir+"/") == ConflictSkip {
+		fmt.Printf("  - .superclaude/ (left unchanged)\n")
+	} else {
+		fmt.Printf("  - .superclaude/ (framework files)\n")
+	}
 
 	if !s.ExistingFiles.ClaudeDir {
 		fmt.Printf("  - .claude/ (created)\n")
 	}
 
+	if len(s.Conflicts) > 0 {
+		fmt.Printf("\nConflicts resolved (--interactive):\n")
+		for _, decision := range s.Conflicts {
+			fmt.Printf("  - %s\n", decision.describe())
+		}
+	}
+
+	if len(s.MCPRuntimeIssues) > 0 {
+		fmt.Printf("\n⚠️  MCP servers that will not start until their runtime is installed:\n")
+		for _, issue := range s.MCPRuntimeIssues {
+			fmt.Printf("  - %s\n", issue)
+		}
+	}
+
 	fmt.Printf("\nNext steps:\n")
-	fmt.Printf("1. Review CLAUDE.md to ensure imports are correct\n")
+	fmt.Printf("1. Review %s to ensure imports are correct\n", importFile)
 	fmt.Printf("2. Restart Claude Code to load new configuration\n")
 	fmt.Printf("3. Use SuperClaude commands and features in Claude Code\n")
 }
@@ -18,7 +18,7 @@ func setupTestMCPSelector() func() {
 	originalSelector := selectMCPServers
 
 	// Mock implementation that selects all available servers
-	selectMCPServers = func(servers []MCPServer) ([]MCPServer, error) {
+	selectMCPServers = func(servers []MCPServer, repoPath string) ([]MCPServer, error) {
 		// For tests, select all available servers
 		for i := range servers {
 			servers[i].Selected = true
@@ -0,0 +1,180 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// manifestVersion is bumped when the manifest format changes incompatibly
+const manifestVersion = 1
+
+// InstallManifest records the choices made during installation so that re-running
+// init (the update path) keeps them unless new flags override them
+type InstallManifest struct {
+	Version         int            `json:"version"`
+	FrameworkCommit string         `json:"frameworkCommit"`        // Commit installed, resolved from FrameworkRef
+	FrameworkRef    string         `json:"frameworkRef,omitempty"` // Branch, tag or commit requested with --ref
+	ImportInto      ImportLocation `json:"importInto,omitempty"`
+	ImportBlock     string         `json:"importBlock,omitempty"`    // Import section as written into ImportInto
+	ImportSkeleton  string         `json:"importSkeleton,omitempty"` // Content around it, if the installer created the file
+	Core            []string       `json:"core"`
+	Modes           []string       `json:"modes"`
+	MCPApprovals    []MCPApproval  `json:"mcpApprovals,omitempty"` // Removed again by clean
+	MCPServers      []InstalledMCP `json:"mcpServers,omitempty"`   // Removed again by mcp remove
+}
+
+// InstalledMCP is an MCP server installed by init or mcp add, with the config entries
+// merged for it, so mcp list and remove work without the framework checkout
+type InstalledMCP struct {
+	Name    string     `json:"name"`             // e.g. Context7
+	Source  string     `json:"source,omitempty"` // MCPSourceFramework, MCPSourceBuiltin, MCPSourceUser or MCPSourceProject
+	Doc     string     `json:"doc,omitempty"`    // MCP_*.md file in .superclaude/MCP
+	Entries []MCPEntry `json:"entries,omitempty"`
+}
+
+// MCPEntry is one server entry merged into an MCP config file
+type MCPEntry struct {
+	File   string `json:"file"`             // Config file, e.g. .mcp.json or .cursor/mcp.json
+	Key    string `json:"key"`              // Key the entry is under, the renamed key for renamed entries
+	Kept   bool   `json:"kept,omitempty"`   // The user's differing entry was kept, so the entry is theirs
+	Digest string `json:"digest,omitempty"` // Hash of the entry as written, to notice later edits
+}
+
+// mcpServer returns the recorded server with the case-insensitive name, or nil
+func (m *InstallManifest) mcpServer(name string) *InstalledMCP {
+	if m == nil {
+		return nil
+	}
+	for i := range m.MCPServers {
+		if strings.EqualFold(m.MCPServers[i].Name, strings.TrimSpace(name)) {
+			return &m.MCPServers[i]
+		}
+	}
+	return nil
+}
+
+// recordMCPChanges records the servers and the outcome of merging them into config
+// files. An entry replaces what was recorded for the same file and key, so a renamed
+// or kept entry takes over from an earlier record of the original key.
+func (m *InstallManifest) recordMCPChanges(servers []MCPServer, changes []MCPServerChange) {
+	for _, server := range servers {
+		record := m.mcpServer(server.Name)
+		if record == nil {
+			m.MCPServers = append(m.MCPServers, InstalledMCP{Name: server.Name})
+			record = &m.MCPServers[len(m.MCPServers)-1]
+		}
+		record.Source, record.Doc = server.Source, server.MDFile
+
+		for _, change := range changes {
+			if !strings.EqualFold(change.Server, server.Name) {
+				continue
+			}
+			entry := MCPEntry{File: change.File, Key: change.Key, Kept: change.Action == MCPServerKept}
+			if change.Action == MCPServerRenamed {
+				entry.Key = change.RenamedTo
+			}
+			if !entry.Kept {
+				entry.Digest = mcpEntryDigest(change.written)
+			}
+			record.Entries = slices.DeleteFunc(record.Entries, func(recorded MCPEntry) bool {
+				return recorded.File == change.File && (recorded.Key == change.Key || recorded.Key == entry.Key)
+			})
+			record.Entries = append(record.Entries, entry)
+		}
+	}
+}
+
+// forgetMCPServers drops the entries of the named servers in the clients' config
+// files, and the servers once no entries in other files remain
+func (m *InstallManifest) forgetMCPServers(names []string, clients []mcpClient) {
+	var kept []InstalledMCP
+	for _, server := range m.MCPServers {
+		if !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, server.Name) }) {
+			kept = append(kept, server)
+			continue
+		}
+
+		var entries []MCPEntry
+		for _, entry := range server.Entries {
+			if !slices.ContainsFunc(clients, func(client mcpClient) bool { return client.File == entry.File }) {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) > 0 {
+			kept = append(kept, InstalledMCP{Name: server.Name, Source: server.Source, Entries: entries})
+		}
+	}
+	m.MCPServers = kept
+}
+
+// mcpEntryDigest hashes a server definition independent of formatting and key order.
+// Only the hash is recorded, since entries written for Claude Desktop hold secret values.
+func mcpEntryDigest(raw json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// ManifestPath returns the location of the install manifest for a project
+func ManifestPath(targetDir string) string {
+	return filepath.Join(targetDir, config.SuperClaudeDir, config.ManifestFile)
+}
+
+// LoadInstallManifest reads the install manifest. It returns nil without error when the
+// project has no manifest, e.g. installations made by older versions.
+func LoadInstallManifest(targetDir string) (*InstallManifest, error) {
+	data, err := os.ReadFile(ManifestPath(targetDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read install manifest: %w", err)
+	}
+
+	var manifest InstallManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse install manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// importRemoval returns the import section and the generated content around it that
+// were recorded for location, or empty strings when the manifest has none
+func (m *InstallManifest) importRemoval(location ImportLocation) (block, skeleton string) {
+	if m == nil || m.ImportInto != location {
+		return "", ""
+	}
+	return m.ImportBlock, m.ImportSkeleton
+}
+
+// Save writes the install manifest into the project's .superclaude directory
+func (m *InstallManifest) Save(targetDir string) error {
+	m.Version = manifestVersion
+
+	output, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal install manifest: %w", err)
+	}
+
+	if err := os.WriteFile(ManifestPath(targetDir), append(output, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write install manifest: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
 )
 
 // MCPServer represents an MCP server with its metadata
@@ -16,15 +18,62 @@ type MCPServer struct {
 	MDFile      string `json:"mdFile"`      // e.g., "MCP_Context7.md"
 	ConfigFile  string `json:"configFile"`  // e.g., "context7.json"
 	Selected    bool   `json:"selected"`    // Selection state for TUI
+
+	// Servers from a user or project registry carry their config and instructions
+	Source  string                     `json:"source"`            // MCPSourceFramework, MCPSourceUser or MCPSourceProject
+	Config  map[string]json.RawMessage `json:"config,omitempty"`  // .mcp.json entries by key
+	DocPath string                     `json:"docPath,omitempty"` // Instructions markdown; MDFile is empty without one
+
+	// Metadata from the MCP_*.md front matter or summary, see parseMCPDoc
+	Description string   `json:"description,omitempty"` // e.g., "Official library documentation lookup"
+	Purpose     string   `json:"purpose,omitempty"`
+	Runtime     string   `json:"runtime,omitempty"` // e.g., "node 18+"
+	Env         []string `json:"env,omitempty"`     // Variables the server needs
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// LoadConfigRaw returns the server's .mcp.json entries, from the registry or from
+// the framework's configs directory
+func (s MCPServer) LoadConfigRaw(repoPath string) (map[string]json.RawMessage, error) {
+	if s.Config != nil {
+		return s.Config, nil
+	}
+	return LoadMCPConfigRaw(repoPath, s.ConfigFile)
 }
 
-// DiscoverMCPServers scans the SuperClaude/MCP directory and returns available MCP servers
+// docSource returns the path of the server's instructions markdown, or "" if it has none
+func (s MCPServer) docSource(repoPath string) string {
+	switch {
+	case s.DocPath != "":
+		return s.DocPath
+	case s.MDFile != "" && s.Config == nil:
+		return filepath.Join(repoPath, "SuperClaude", "MCP", s.MDFile)
+	}
+	return ""
+}
+
+// copyMCPDocs copies the instructions of the servers that have them into mcpDir
+func copyMCPDocs(repoPath, mcpDir string, servers []MCPServer) error {
+	for _, server := range servers {
+		src := server.docSource(repoPath)
+		if src == "" {
+			continue
+		}
+		if err := copyFile(src, filepath.Join(mcpDir, server.MDFile)); err != nil {
+			return fmt.Errorf("failed to copy MCP file %s: %w", server.MDFile, err)
+		}
+	}
+	return nil
+}
+
+// DiscoverMCPServers scans the SuperClaude/MCP directory and returns available MCP servers.
+// Checkouts without one, such as older refs and forks, get the built-in catalog.
 func DiscoverMCPServers(repoPath string) ([]MCPServer, error) {
 	mcpDir := filepath.Join(repoPath, "SuperClaude", "MCP")
 
 	// Check if MCP directory exists
 	if _, err := os.Stat(mcpDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("MCP directory not found: %s", mcpDir)
+		return builtinMCPServers(), nil
 	}
 
 	// Read MCP directory
@@ -61,9 +110,10 @@ func DiscoverMCPServers(repoPath string) ([]MCPServer, error) {
 			MDFile:      entry.Name(),
 			ConfigFile:  configFile,
 			Selected:    false,
+			Source:      MCPSourceFramework,
 		}
 
-		servers = append(servers, server)
+		servers = append(servers, server.describe(repoPath))
 	}
 
 	// Sort servers by name for consistent display
@@ -74,13 +124,32 @@ func DiscoverMCPServers(repoPath string) ([]MCPServer, error) {
 	return servers, nil
 }
 
+// builtinMCPServers returns config.RecommendedMCPServers as servers sorted by name.
+// They carry their config and have no instructions file.
+func builtinMCPServers() []MCPServer {
+	servers := make([]MCPServer, 0, len(config.RecommendedMCPServers))
+	for key, entry := range config.RecommendedMCPServers {
+		server := MCPServer{
+			Name:        entry.Name,
+			DisplayName: entry.Description,
+			Description: strings.TrimPrefix(entry.Description, entry.Name+" - "),
+			Source:      MCPSourceBuiltin,
+			Config:      map[string]json.RawMessage{key: entry.Config},
+		}
+		servers = append(servers, server.describe(""))
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+	return servers
+}
+
 // LoadMCPConfig loads an MCP server configuration from its JSON file
 func LoadMCPConfig(repoPath, configFile string) (map[string]interface{}, error) {
-	configPath := filepath.Join(repoPath, "SuperClaude", "MCP", "configs", configFile)
-
-	data, err := os.ReadFile(configPath)
+	data, err := readMCPConfigFile(repoPath, configFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read MCP config %s: %w", configFile, err)
+		return nil, err
 	}
 
 	var config map[string]interface{}
@@ -91,6 +160,38 @@ func LoadMCPConfig(repoPath, configFile string) (map[string]interface{}, error)
 	return config, nil
 }
 
+// LoadMCPConfigRaw loads an MCP server configuration keeping each server's JSON as
+// written upstream, so it can be inserted into .mcp.json with its key order intact
+func LoadMCPConfigRaw(repoPath, configFile string) (map[string]json.RawMessage, error) {
+	data, err := readMCPConfigFile(repoPath, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP config %s: %w", configFile, err)
+	}
+
+	return config, nil
+}
+
+// readMCPConfigFile reads a framework config file and validates it against the server
+// definition schema, so a broken upstream file is reported before it reaches .mcp.json
+func readMCPConfigFile(repoPath, configFile string) ([]byte, error) {
+	configPath := filepath.Join(repoPath, "SuperClaude", "MCP", "configs", configFile)
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP config %s: %w", configFile, err)
+	}
+	if problems := validateMCPServerFile(data, "configs/"+configFile); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid MCP config %s: %w", configFile, problems)
+	}
+
+	return data, nil
+}
+
 // GetSelectedServers returns only the servers that are marked as selected
 func GetSelectedServers(servers []MCPServer) []MCPServer {
 	var selected []MCPServer
@@ -101,3 +202,52 @@ func GetSelectedServers(servers []MCPServer) []MCPServer {
 	}
 	return selected
 }
+
+// ResolveMCPServers selects servers by name for non-interactive installs. Names match
+// case-insensitively against the server name ("Context7" or "context7"); "all" selects
+// every server and "none" selects nothing.
+func ResolveMCPServers(servers []MCPServer, names []string) ([]MCPServer, error) {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", "none":
+			continue
+		case "all":
+			for _, server := range servers {
+				selected[server.Name] = true
+			}
+			continue
+		}
+
+		found := false
+		for _, server := range servers {
+			if strings.ToLower(server.Name) == name {
+				selected[server.Name] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown MCP server %q (available: %s)", name, strings.Join(mcpServerNames(servers), ", "))
+		}
+	}
+
+	var result []MCPServer
+	for _, server := range servers {
+		if selected[server.Name] {
+			server.Selected = true
+			result = append(result, server)
+		}
+	}
+	return result, nil
+}
+
+// mcpServerNames returns the lowercase names accepted by --mcp
+func mcpServerNames(servers []MCPServer) []string {
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, strings.ToLower(server.Name))
+	}
+	return names
+}
@@ -0,0 +1,285 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
+)
+
+// Claude Code settings keys that approve project MCP servers from .mcp.json
+const (
+	enabledMCPServersKey  = "enabledMcpjsonServers"
+	disabledMCPServersKey = "disabledMcpjsonServers"
+	enableAllMCPServerKey = "enableAllProjectMcpServers"
+)
+
+// MCPApprovalMode selects how init pre-approves the project's MCP servers, so Claude
+// Code does not ask each developer to approve them
+type MCPApprovalMode string
+
+// Approval modes
+const (
+	MCPApprovalNone     MCPApprovalMode = ""
+	MCPApprovalSelected MCPApprovalMode = "selected" // List the selected servers in enabledMcpjsonServers
+	MCPApprovalAll      MCPApprovalMode = "all"      // Set enableAllProjectMcpServers
+)
+
+// ParseMCPApprovalMode validates an --approve-mcp value
+func ParseMCPApprovalMode(value string) (MCPApprovalMode, error) {
+	switch mode := MCPApprovalMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case MCPApprovalNone, MCPApprovalSelected, MCPApprovalAll:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid --approve-mcp %q (valid: selected, all)", value)
+}
+
+// MCPSettingsScope selects the Claude Code settings file that receives the approvals
+type MCPSettingsScope string
+
+// Settings scopes
+const (
+	MCPSettingsLocal   MCPSettingsScope = "local"   // .claude/settings.local.json, personal
+	MCPSettingsProject MCPSettingsScope = "project" // .claude/settings.json, shared with the team
+)
+
+// ParseMCPSettingsScope validates an --mcp-settings value; empty means local
+func ParseMCPSettingsScope(value string) (MCPSettingsScope, error) {
+	switch scope := MCPSettingsScope(strings.ToLower(strings.TrimSpace(value))); scope {
+	case "":
+		return MCPSettingsLocal, nil
+	case MCPSettingsLocal, MCPSettingsProject:
+		return scope, nil
+	}
+	return "", fmt.Errorf("invalid --mcp-settings %q (valid: local, project)", value)
+}
+
+// File returns the settings file of the scope, relative to the project
+func (s MCPSettingsScope) File() string {
+	if s == MCPSettingsProject {
+		return filepath.Join(config.ClaudeDir, "settings.json")
+	}
+	return filepath.Join(config.ClaudeDir, "settings.local.json")
+}
+
+// MCPApproval records what init added to a settings file, so clean removes only that
+type MCPApproval struct {
+	File      string   `json:"file"`                // Settings file relative to the project
+	Servers   []string `json:"servers,omitempty"`   // Keys added to enabledMcpjsonServers
+	EnableAll bool     `json:"enableAll,omitempty"` // enableAllProjectMcpServers was set
+}
+
+// approvedMCPKeys returns the .mcp.json keys the merge left the selected servers under
+func approvedMCPKeys(changes []MCPServerChange) []string {
+	keys := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.Action == MCPServerRenamed {
+			keys = append(keys, change.RenamedTo)
+		} else {
+			keys = append(keys, change.Key)
+		}
+	}
+	return keys
+}
+
+// approveMCPServers merges the approval into the settings file, creating it when
+// missing, and returns what was added
+func approveMCPServers(targetDir string, scope MCPSettingsScope, mode MCPApprovalMode, keys []string) (MCPApproval, error) {
+	approval := MCPApproval{File: filepath.ToSlash(scope.File())}
+	path := filepath.Join(targetDir, scope.File())
+
+	doc, err := readSettingsDocument(path, approval.File)
+	if err != nil {
+		return approval, err
+	}
+	if doc == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return approval, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if doc, err = jsonedit.Parse([]byte(newJSONFile)); err != nil {
+			return approval, err
+		}
+	}
+
+	switch mode {
+	case MCPApprovalAll:
+		if enabled, _ := doc.Raw(enableAllMCPServerKey); string(enabled) != "true" {
+			if err := doc.Set([]string{enableAllMCPServerKey}, json.RawMessage("true")); err != nil {
+				return approval, fmt.Errorf("failed to update %s: %w", approval.File, err)
+			}
+			approval.EnableAll = true
+		}
+	case MCPApprovalSelected:
+		enabled, err := settingsStringList(doc, approval.File, enabledMCPServersKey)
+		if err != nil {
+			return approval, err
+		}
+		disabled, err := settingsStringList(doc, approval.File, disabledMCPServersKey)
+		if err != nil {
+			return approval, err
+		}
+
+		for _, key := range keys {
+			if slices.Contains(disabled, key) {
+				fmt.Printf("⚠️  %s is listed in %s in %s and stays disabled\n", key, disabledMCPServersKey, approval.File)
+			}
+			if !slices.Contains(enabled, key) {
+				enabled = append(enabled, key)
+				approval.Servers = append(approval.Servers, key)
+			}
+		}
+		if len(approval.Servers) > 0 {
+			if err := setSettingsStringList(doc, enabledMCPServersKey, enabled); err != nil {
+				return approval, fmt.Errorf("failed to update %s: %w", approval.File, err)
+			}
+		}
+	}
+
+	if !approval.EnableAll && len(approval.Servers) == 0 {
+		return approval, nil
+	}
+	if err := os.WriteFile(path, doc.Bytes(), 0o600); err != nil {
+		return approval, fmt.Errorf("failed to write %s: %w", approval.File, err)
+	}
+	return approval, nil
+}
+
+// revokeMCPApproval removes what an earlier approval added to its settings file,
+// leaving entries added by hand. It reports whether the file changed.
+func revokeMCPApproval(targetDir string, approval MCPApproval) (bool, error) {
+	path := filepath.Join(targetDir, filepath.FromSlash(approval.File))
+	doc, err := readSettingsDocument(path, approval.File)
+	if err != nil || doc == nil {
+		return false, err
+	}
+
+	changed := false
+	if approval.EnableAll {
+		deleted, err := doc.Delete(enableAllMCPServerKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to update %s: %w", approval.File, err)
+		}
+		changed = changed || deleted
+	}
+
+	if len(approval.Servers) > 0 {
+		enabled, err := settingsStringList(doc, approval.File, enabledMCPServersKey)
+		if err != nil {
+			return false, err
+		}
+		remaining := slices.DeleteFunc(slices.Clone(enabled), func(key string) bool {
+			return slices.Contains(approval.Servers, key)
+		})
+		switch {
+		case len(remaining) == len(enabled):
+		case len(remaining) == 0:
+			if _, err := doc.Delete(enabledMCPServersKey); err != nil {
+				return false, fmt.Errorf("failed to update %s: %w", approval.File, err)
+			}
+			changed = true
+		default:
+			if err := setSettingsStringList(doc, enabledMCPServersKey, remaining); err != nil {
+				return false, fmt.Errorf("failed to update %s: %w", approval.File, err)
+			}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+	if err := os.WriteFile(path, doc.Bytes(), 0o600); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", approval.File, err)
+	}
+	return true, nil
+}
+
+// RevokeMCPApprovals removes the MCP approvals recorded in the install manifest and
+// returns the settings files that changed
+func RevokeMCPApprovals(targetDir string) ([]string, error) {
+	manifest, err := LoadInstallManifest(targetDir)
+	if err != nil || manifest == nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, approval := range manifest.MCPApprovals {
+		revoked, err := revokeMCPApproval(targetDir, approval)
+		if err != nil {
+			return changed, err
+		}
+		if revoked {
+			changed = append(changed, approval.File)
+		}
+	}
+	return changed, nil
+}
+
+// mergeMCPApprovals combines a new approval with those recorded earlier, so entries
+// added by previous runs are still removed by clean
+func mergeMCPApprovals(previous []MCPApproval, approval MCPApproval) []MCPApproval {
+	merged := slices.Clone(previous)
+	for i := range merged {
+		if merged[i].File != approval.File {
+			continue
+		}
+		merged[i].EnableAll = merged[i].EnableAll || approval.EnableAll
+		merged[i].Servers = slices.Clone(merged[i].Servers)
+		for _, key := range approval.Servers {
+			if !slices.Contains(merged[i].Servers, key) {
+				merged[i].Servers = append(merged[i].Servers, key)
+			}
+		}
+		return merged
+	}
+	return append(merged, approval)
+}
+
+// readSettingsDocument parses a settings file, returning nil when it does not exist
+func readSettingsDocument(path, file string) (*jsonedit.Document, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	doc, err := jsonedit.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if kind := doc.Root().Kind; kind != jsonedit.Object {
+		return nil, fmt.Errorf("%s must be a JSON object, got %s", file, kind)
+	}
+	return doc, nil
+}
+
+// settingsStringList reads a string array setting; a missing key is an empty list
+func settingsStringList(doc *jsonedit.Document, file, key string) ([]string, error) {
+	raw, ok := doc.Raw(key)
+	if !ok {
+		return nil, nil
+	}
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		value, _ := doc.Lookup(key)
+		line, column := doc.Position(value.Start)
+		return nil, fmt.Errorf("%s:%d:%d: %s must be an array of server names", file, line, column, key)
+	}
+	return values, nil
+}
+
+// setSettingsStringList writes a string array setting
+func setSettingsStringList(doc *jsonedit.Document, key string, values []string) error {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return doc.Set([]string{key}, raw)
+}
@@ -0,0 +1,122 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestApproveMCPServers validates that approvals are merged into Claude Code's
+// settings without disturbing other settings and removed again by clean
+func TestApproveMCPServers(t *testing.T) {
+	readSettings := func(t *testing.T, path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read settings: %v", err)
+		}
+		return string(data)
+	}
+
+	t.Run("Selected", func(t *testing.T) {
+		targetDir := t.TempDir()
+		const original = `{
+  "permissions": {"allow": ["Bash(go test:*)"]},
+  "enabledMcpjsonServers": ["mine"],
+  "disabledMcpjsonServers": ["serena"]
+}
+`
+		writeTestFiles(t, targetDir, map[string]string{".claude/settings.local.json": original})
+		path := filepath.Join(targetDir, ".claude", "settings.local.json")
+
+		changes := []MCPServerChange{
+			{Key: "context7", Action: MCPServerAdded},
+			{Key: "mine", Action: MCPServerKept},
+			{Key: "serena", Action: MCPServerRenamed, RenamedTo: "serena-superclaude"},
+		}
+		approval, err := approveMCPServers(targetDir, MCPSettingsLocal, MCPApprovalSelected, approvedMCPKeys(changes))
+		if err != nil {
+			t.Fatalf("approveMCPServers failed: %v", err)
+		}
+		expected := MCPApproval{File: ".claude/settings.local.json", Servers: []string{"context7", "serena-superclaude"}}
+		if !reflect.DeepEqual(approval, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, approval)
+		}
+
+		settings := readSettings(t, path)
+		if !strings.Contains(settings, `"enabledMcpjsonServers": ["mine","context7","serena-superclaude"]`) ||
+			!strings.Contains(settings, `"permissions": {"allow": ["Bash(go test:*)"]}`) {
+			t.Errorf("Expected the servers to be appended in place, got:\n%s", settings)
+		}
+
+		// A second run adds nothing and leaves the file alone
+		again, err := approveMCPServers(targetDir, MCPSettingsLocal, MCPApprovalSelected, []string{"context7"})
+		if err != nil || len(again.Servers) != 0 {
+			t.Errorf("Expected nothing new to approve, got %+v (%v)", again, err)
+		}
+
+		writeTestFiles(t, targetDir, map[string]string{".superclaude/CLAUDE.md": "# SuperClaude\n"})
+		manifest := &InstallManifest{MCPApprovals: mergeMCPApprovals(nil, approval)}
+		if err := manifest.Save(targetDir); err != nil {
+			t.Fatalf("Failed to save manifest: %v", err)
+		}
+		files, err := RevokeMCPApprovals(targetDir)
+		if err != nil || !reflect.DeepEqual(files, []string{".claude/settings.local.json"}) {
+			t.Errorf("Expected the settings file to change, got %v (%v)", files, err)
+		}
+		if settings := readSettings(t, path); settings != original {
+			t.Errorf("Expected the original settings back, got:\n%s", settings)
+		}
+	})
+
+	t.Run("AllInNewProjectSettings", func(t *testing.T) {
+		targetDir := t.TempDir()
+		approval, err := approveMCPServers(targetDir, MCPSettingsProject, MCPApprovalAll, []string{"context7"})
+		if err != nil {
+			t.Fatalf("approveMCPServers failed: %v", err)
+		}
+		if !approval.EnableAll || approval.File != ".claude/settings.json" {
+			t.Errorf("Expected enableAllProjectMcpServers in settings.json, got %+v", approval)
+		}
+		path := filepath.Join(targetDir, ".claude", "settings.json")
+		if settings := readSettings(t, path); !strings.Contains(settings, `"enableAllProjectMcpServers": true`) {
+			t.Errorf("Expected the setting, got:\n%s", settings)
+		}
+
+		if _, err := revokeMCPApproval(targetDir, approval); err != nil {
+			t.Fatalf("revokeMCPApproval failed: %v", err)
+		}
+		if settings := readSettings(t, path); strings.Contains(settings, "enableAllProjectMcpServers") {
+			t.Errorf("Expected the setting to be removed, got:\n%s", settings)
+		}
+	})
+
+	t.Run("InvalidList", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{".claude/settings.local.json": "{\n  \"enabledMcpjsonServers\": \"all\"\n}\n"})
+		_, err := approveMCPServers(targetDir, MCPSettingsLocal, MCPApprovalSelected, []string{"context7"})
+		if err == nil || !strings.Contains(err.Error(), ".claude/settings.local.json:2:28: enabledMcpjsonServers must be an array") {
+			t.Errorf("Expected a positioned error, got %v", err)
+		}
+	})
+
+	t.Run("MergeRecords", func(t *testing.T) {
+		previous := []MCPApproval{{File: ".claude/settings.local.json", Servers: []string{"context7"}}}
+		merged := mergeMCPApprovals(previous, MCPApproval{File: ".claude/settings.local.json", Servers: []string{"serena", "context7"}})
+		expected := []MCPApproval{{File: ".claude/settings.local.json", Servers: []string{"context7", "serena"}}}
+		if !reflect.DeepEqual(merged, expected) || len(previous[0].Servers) != 1 {
+			t.Errorf("Expected %+v without changing the previous record, got %+v", expected, merged)
+		}
+	})
+
+	t.Run("ParseFlags", func(t *testing.T) {
+		if _, err := ParseMCPApprovalMode("some"); err == nil {
+			t.Errorf("Expected an invalid --approve-mcp to fail")
+		}
+		if scope, err := ParseMCPSettingsScope(""); err != nil || scope != MCPSettingsLocal {
+			t.Errorf("Expected local by default, got %q (%v)", scope, err)
+		}
+	})
+}
@@ -0,0 +1,405 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// MCPProtocolVersion is the protocol revision offered in the initialize request
+const MCPProtocolVersion = "2025-06-18"
+
+// DefaultMCPCheckTimeout bounds the handshake; first runs of npx and uvx download packages
+const DefaultMCPCheckTimeout = 30 * time.Second
+
+// maxToolPages stops tools/list pagination from looping on a misbehaving server
+const maxToolPages = 100
+
+// stderrTailSize is how much of a server's stderr is kept for error reports
+const stderrTailSize = 4096
+
+// MCPCheckResult is the outcome of starting one server and listing its tools
+type MCPCheckResult struct {
+	Server          string // .mcp.json server key
+	Transport       MCPTransport
+	ServerName      string // serverInfo.name from initialize
+	ServerVersion   string // serverInfo.version from initialize
+	ProtocolVersion string // Protocol revision the server agreed to
+	Tools           int
+	Duration        time.Duration
+	Err             error
+	Stderr          string // Tail of the server's stderr when the check failed
+}
+
+// OK reports whether the handshake and tools/list succeeded
+func (r MCPCheckResult) OK() bool {
+	return r.Err == nil
+}
+
+// CheckMCPServers connects to the named servers from .mcp.json (all when names is
+// empty), launching stdio servers or calling the url of http and sse servers, performs
+// the initialize handshake and tools/list, and reports the outcome of each. ${VAR}
+// references are expanded from the environment and the secrets file.
+func CheckMCPServers(targetDir string, names []string, timeout time.Duration) ([]MCPCheckResult, error) {
+	mcpPath := filepath.Join(targetDir, config.MCPConfigFile)
+	if !fileExists(mcpPath) {
+		return nil, fmt.Errorf("failed to read .mcp.json: %s does not exist", mcpPath)
+	}
+	mcpConfig, problems, err := readMCPConfig(mcpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := names
+	if len(keys) == 0 {
+		keys = mcpConfig.Keys()
+	}
+	for _, key := range keys {
+		if _, ok := mcpConfig.MCPServers[key]; !ok {
+			return nil, fmt.Errorf("MCP server %q is not configured in .mcp.json", key)
+		}
+	}
+
+	lookup, err := mcpEnvLookup(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]MCPCheckResult, 0, len(keys))
+	for _, key := range keys {
+		if invalid := problems.forServer(key); len(invalid) > 0 {
+			results = append(results, MCPCheckResult{Server: key, Transport: mcpConfig.MCPServers[key].Transport(), Err: fmt.Errorf("invalid configuration: %w", invalid)})
+			continue
+		}
+		results = append(results, checkMCPServer(targetDir, key, mcpConfig.MCPServers[key], lookup, timeout))
+	}
+	return results, nil
+}
+
+// checkMCPServer expands a definition and runs the handshake under the timeout
+func checkMCPServer(targetDir, key string, definition MCPServerConfig, lookup func(string) (string, bool), timeout time.Duration) MCPCheckResult {
+	result := MCPCheckResult{Server: key, Transport: definition.Transport()}
+	if problems := definition.Validate(); len(problems) > 0 {
+		result.Err = fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+		return result
+	}
+
+	var missing []string
+	expand := func(value string) string {
+		expanded, unset := expandEnvReferences(value, lookup)
+		missing = append(missing, unset...)
+		return expanded
+	}
+	expanded := MCPServerConfig{Type: definition.Type, Command: expand(definition.Command), URL: expand(definition.URL)}
+	for _, arg := range definition.Args {
+		expanded.Args = append(expanded.Args, expand(arg))
+	}
+	expanded.Env = make(map[string]string, len(definition.Env))
+	for name, value := range definition.Env {
+		expanded.Env[name] = expand(value)
+	}
+	expanded.Headers = make(map[string]string, len(definition.Headers))
+	for name, value := range definition.Headers {
+		expanded.Headers[name] = expand(value)
+	}
+	if len(missing) > 0 {
+		result.Err = fmt.Errorf("environment variables not set: %s (see %s)", strings.Join(missing, ", "),
+			filepath.ToSlash(filepath.Join(config.ToolConfigDir, config.SecretsFile)))
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	var conn mcpConn
+	var err error
+	stderr := &tailBuffer{limit: stderrTailSize}
+	switch expanded.Transport() {
+	case MCPTransportHTTP:
+		conn = newMCPHTTPConn(ctx, expanded.URL, expanded.Headers)
+	case MCPTransportSSE:
+		conn, err = dialMCPSSE(ctx, expanded.URL, expanded.Headers)
+	default:
+		conn, err = startMCPStdio(ctx, targetDir, expanded, stderr)
+	}
+	if err != nil {
+		result.Err = fmt.Errorf("failed to connect: %w", err)
+		if expanded.Transport() == MCPTransportStdio {
+			result.Err = fmt.Errorf("failed to start: %w", err)
+		}
+		return result
+	}
+
+	// The handshake fills its own copy so a timed-out goroutine cannot race the result
+	done := make(chan MCPCheckResult, 1)
+	go func() {
+		handshaken := MCPCheckResult{Server: key, Transport: result.Transport}
+		handshaken.Err = runMCPHandshake(conn, &handshaken)
+		done <- handshaken
+	}()
+
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.Err = fmt.Errorf("no response within %s", timeout)
+		// Remote requests end with the context, so wait for the handshake to stop using
+		// the connection before closing it. A stdio server's reader only returns once
+		// close stops the server.
+		if expanded.Transport() != MCPTransportStdio {
+			<-done
+		}
+	}
+	conn.close()
+
+	result.Duration = time.Since(start)
+	if result.Err != nil {
+		result.Stderr = strings.TrimSpace(stderr.String())
+	}
+	return result
+}
+
+// expandEnvReferences replaces ${VAR} and ${VAR:-default} like Claude Code does and
+// returns the names of variables that are unset and have no default
+func expandEnvReferences(value string, lookup func(string) (string, bool)) (string, []string) {
+	var missing []string
+	expanded := envReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		match := envReferencePattern.FindStringSubmatch(reference)
+		if resolved, ok := lookup(match[1]); ok {
+			return resolved
+		}
+		if strings.Contains(reference, ":-") {
+			return match[2]
+		}
+		missing = append(missing, match[1])
+		return ""
+	})
+	return expanded, missing
+}
+
+// mcpSession speaks newline-delimited JSON-RPC with a server process over stdio
+type mcpSession struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	scanner *bufio.Scanner
+	nextID  int
+}
+
+// mcpMessage is a JSON-RPC request, notification or response
+type mcpMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  interface{}     `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+// mcpError is a JSON-RPC error object
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// startMCPStdio launches a stdio server in targetDir with its env added to ours
+func startMCPStdio(ctx context.Context, targetDir string, definition MCPServerConfig, stderr io.Writer) (*mcpSession, error) {
+	cmd := exec.CommandContext(ctx, definition.Command, definition.Args...) // #nosec G204 -- runs the command the user configured in .mcp.json
+	cmd.Dir = targetDir
+	cmd.Env = os.Environ()
+	for name, value := range definition.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	cmd.Stderr = stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	// Servers that leave children holding the pipes must not block Wait
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return &mcpSession{cmd: cmd, stdin: stdin, scanner: scanner}, nil
+}
+
+// mcpConn is a client connection over one of the transports
+type mcpConn interface {
+	// call sends a request and decodes the result of its response
+	call(method string, params, result interface{}) error
+	// notify sends a notification
+	notify(method string) error
+	close()
+}
+
+// runMCPHandshake runs initialize, notifications/initialized and tools/list
+func runMCPHandshake(conn mcpConn, result *MCPCheckResult) error {
+	var initialized struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	err := conn.call("initialize", map[string]interface{}{
+		"protocolVersion": MCPProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "super-claude-lite", "version": "check"},
+	}, &initialized)
+	if err != nil {
+		return err
+	}
+	result.ServerName = initialized.ServerInfo.Name
+	result.ServerVersion = initialized.ServerInfo.Version
+	result.ProtocolVersion = initialized.ProtocolVersion
+
+	if err := conn.notify("notifications/initialized"); err != nil {
+		return err
+	}
+
+	cursor := ""
+	for page := 0; page < maxToolPages; page++ {
+		var params interface{}
+		if cursor != "" {
+			params = map[string]string{"cursor": cursor}
+		}
+		var listed struct {
+			Tools      []json.RawMessage `json:"tools"`
+			NextCursor string            `json:"nextCursor"`
+		}
+		if err := conn.call("tools/list", params, &listed); err != nil {
+			return err
+		}
+		result.Tools += len(listed.Tools)
+		if cursor = listed.NextCursor; cursor == "" {
+			return nil
+		}
+	}
+	return nil
+}
+
+// call sends a request and waits for its response, answering pings from the server
+// and skipping notifications and log lines on the way
+func (s *mcpSession) call(method string, params, result interface{}) error {
+	s.nextID++
+	id := json.RawMessage(fmt.Sprint(s.nextID))
+	if err := s.send(mcpMessage{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	for s.scanner.Scan() {
+		var message mcpMessage
+		if err := json.Unmarshal(s.scanner.Bytes(), &message); err != nil {
+			continue
+		}
+
+		if message.Method != "" && message.ID != nil {
+			if err := s.send(replyTo(message)); err != nil {
+				return err
+			}
+			continue
+		}
+		if done, err := message.responseTo(id, method, result); done {
+			return err
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	return fmt.Errorf("server exited before answering %s", method)
+}
+
+// notify sends a notification
+func (s *mcpSession) notify(method string) error {
+	return s.send(mcpMessage{JSONRPC: "2.0", Method: method})
+}
+
+// responseTo reports whether the message answers request id and, if so, decodes its
+// result or returns its error
+func (m mcpMessage) responseTo(id json.RawMessage, method string, result interface{}) (bool, error) {
+	if m.Method != "" || !bytes.Equal(m.ID, id) {
+		return false, nil
+	}
+	if m.Error != nil {
+		return true, fmt.Errorf("%s failed: %s (code %d)", method, m.Error.Message, m.Error.Code)
+	}
+	if err := json.Unmarshal(m.Result, result); err != nil {
+		return true, fmt.Errorf("invalid %s result: %w", method, err)
+	}
+	return true, nil
+}
+
+// replyTo answers a request sent by the server: ping succeeds, anything else is unsupported
+func replyTo(request mcpMessage) mcpMessage {
+	response := mcpMessage{JSONRPC: "2.0", ID: request.ID}
+	if request.Method == "ping" {
+		response.Result = json.RawMessage("{}")
+	} else {
+		response.Error = &mcpError{Code: -32601, Message: "method not supported by super-claude-lite"}
+	}
+	return response
+}
+
+func (s *mcpSession) send(message mcpMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := s.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to server: %w", err)
+	}
+	return nil
+}
+
+// close ends the session and stops the server
+func (s *mcpSession) close() {
+	_ = s.stdin.Close()
+	if s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+	_ = s.cmd.Wait()
+}
+
+// tailBuffer keeps the last limit bytes written to it
+type tailBuffer struct {
+	mu    sync.Mutex
+	data  []byte
+	limit int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
@@ -0,0 +1,313 @@
+package installer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMCPServerEnv selects the fake server's behaviour when the test binary is
+// started as an MCP server by TestCheckMCPServers
+const fakeMCPServerEnv = "SUPERCLAUDE_FAKE_MCP_SERVER"
+
+// TestFakeMCPServer is not a real test: with fakeMCPServerEnv set it turns the test
+// binary into a small stdio MCP server
+func TestFakeMCPServer(t *testing.T) {
+	mode := os.Getenv(fakeMCPServerEnv)
+	if mode == "" {
+		return
+	}
+
+	switch mode {
+	case "crash":
+		fmt.Fprintln(os.Stderr, "fatal: missing configuration")
+		os.Exit(1)
+	case "hang":
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	write := func(message map[string]interface{}) {
+		message["jsonrpc"] = "2.0"
+		data, _ := json.Marshal(message)
+		fmt.Printf("%s\n", data)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Cursor string `json:"cursor"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil || request.Method == "" {
+			continue
+		}
+
+		switch request.Method {
+		case "initialize":
+			if mode == "error" {
+				write(map[string]interface{}{"id": request.ID, "error": map[string]interface{}{"code": -32602, "message": "unsupported protocol"}})
+				continue
+			}
+			fmt.Printf("starting fake server\n")
+			write(map[string]interface{}{"id": request.ID, "result": map[string]interface{}{
+				"protocolVersion": MCPProtocolVersion,
+				"serverInfo":      map[string]string{"name": "fake", "version": os.Getenv("FAKE_VERSION")},
+			}})
+		case "tools/list":
+			if request.Params.Cursor == "" {
+				write(map[string]interface{}{"method": "notifications/message", "params": map[string]string{"level": "info"}})
+				write(map[string]interface{}{"id": "server-1", "method": "ping"})
+				write(map[string]interface{}{"id": request.ID, "result": map[string]interface{}{
+					"tools":      []map[string]string{{"name": "search"}, {"name": "fetch"}},
+					"nextCursor": "page-2",
+				}})
+			} else {
+				write(map[string]interface{}{"id": request.ID, "result": map[string]interface{}{
+					"tools": []map[string]string{{"name": "summarize"}},
+				}})
+			}
+		}
+	}
+	os.Exit(0)
+}
+
+// TestCheckMCPServers validates the handshake against the fake server and the
+// reporting of startup errors
+func TestCheckMCPServers(t *testing.T) {
+	targetDir := t.TempDir()
+	fakeServer := func(mode string, env map[string]string) map[string]interface{} {
+		env[fakeMCPServerEnv] = mode
+		return map[string]interface{}{
+			"command": os.Args[0],
+			"args":    []string{"-test.run=^TestFakeMCPServer$"},
+			"env":     env,
+		}
+	}
+	mcpConfig := map[string]interface{}{"mcpServers": map[string]interface{}{
+		"ok":      fakeServer("ok", map[string]string{"FAKE_VERSION": "${FAKE_VERSION:-1.2.3}"}),
+		"secret":  fakeServer("ok", map[string]string{"FAKE_VERSION": "${FAKE_SECRET_VERSION}"}),
+		"error":   fakeServer("error", map[string]string{}),
+		"crash":   fakeServer("crash", map[string]string{}),
+		"hang":    fakeServer("hang", map[string]string{}),
+		"missing": map[string]interface{}{"command": filepath.Join(targetDir, "no-such-server")},
+		"invalid": map[string]interface{}{"type": "http", "command": "server"},
+	}}
+	data, err := json.Marshal(mcpConfig)
+	if err != nil {
+		t.Fatalf("Failed to marshal .mcp.json: %v", err)
+	}
+	writeTestFiles(t, targetDir, map[string]string{".mcp.json": string(data)})
+
+	results, err := CheckMCPServers(targetDir, nil, 2*time.Second)
+	if err != nil {
+		t.Fatalf("CheckMCPServers failed: %v", err)
+	}
+
+	byServer := make(map[string]MCPCheckResult)
+	for _, result := range results {
+		byServer[result.Server] = result
+	}
+	if len(byServer) != 7 {
+		t.Fatalf("Expected 7 results, got %+v", results)
+	}
+
+	ok := byServer["ok"]
+	if !ok.OK() || ok.ServerName != "fake" || ok.ServerVersion != "1.2.3" || ok.Tools != 3 || ok.ProtocolVersion != MCPProtocolVersion {
+		t.Errorf("Expected a healthy fake server with 3 tools, got %+v", ok)
+	}
+
+	expectedErrors := map[string]string{
+		"secret":  "environment variables not set: FAKE_SECRET_VERSION",
+		"error":   "initialize failed: unsupported protocol (code -32602)",
+		"crash":   "server exited before answering initialize",
+		"hang":    "no response within 2s",
+		"missing": "failed to start",
+		"invalid": "url is required for http servers",
+	}
+	for server, expected := range expectedErrors {
+		result := byServer[server]
+		if result.OK() || !strings.Contains(result.Err.Error(), expected) {
+			t.Errorf("Expected %s to fail with %q, got %v", server, expected, result.Err)
+		}
+	}
+	if !strings.Contains(byServer["crash"].Stderr, "fatal: missing configuration") {
+		t.Errorf("Expected the crash output in Stderr, got %q", byServer["crash"].Stderr)
+	}
+
+	t.Run("SecretsFile", func(t *testing.T) {
+		if err := saveSecrets(targetDir, map[string]string{"FAKE_SECRET_VERSION": "9.9.9"}); err != nil {
+			t.Fatalf("saveSecrets failed: %v", err)
+		}
+		results, err := CheckMCPServers(targetDir, []string{"secret"}, 2*time.Second)
+		if err != nil {
+			t.Fatalf("CheckMCPServers failed: %v", err)
+		}
+		if len(results) != 1 || !results[0].OK() || results[0].ServerVersion != "9.9.9" {
+			t.Errorf("Expected the secret to be expanded from the secrets file, got %+v", results)
+		}
+	})
+
+	if _, err := CheckMCPServers(targetDir, []string{"unknown"}, time.Second); err == nil {
+		t.Errorf("Expected an error for a server that is not configured")
+	}
+}
+
+// fakeRemoteResult answers the requests the check sends to a remote server
+func fakeRemoteResult(method string) interface{} {
+	if method == "initialize" {
+		return map[string]interface{}{
+			"protocolVersion": MCPProtocolVersion,
+			"serverInfo":      map[string]string{"name": "remote", "version": "2.0.0"},
+		}
+	}
+	return map[string]interface{}{"tools": []map[string]string{{"name": "search"}}}
+}
+
+// TestCheckRemoteMCPServers validates the handshake over the streamable HTTP and
+// legacy SSE transports, including headers with ${VAR} references
+func TestCheckRemoteMCPServers(t *testing.T) {
+	type rpcRequest struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	reply := func(request rpcRequest) []byte {
+		data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": fakeRemoteResult(request.Method)})
+		return data
+	}
+
+	sessionDeleted := false
+	sseMessages := make(chan []byte, 4)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "missing token", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			sessionDeleted = r.Header.Get("Mcp-Session-Id") == "session-1"
+			return
+		}
+
+		var request rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case request.Method == "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(reply(request))
+		case r.Header.Get("Mcp-Session-Id") != "session-1" || r.Header.Get("MCP-Protocol-Version") != MCPProtocolVersion:
+			http.Error(w, "unknown session", http.StatusNotFound)
+		case request.ID == nil:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", reply(request))
+		}
+	})
+	// Starts a session, then never answers tools/list
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return
+		}
+		switch {
+		case request.Method == "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-2")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(reply(request))
+		case request.ID == nil:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			<-r.Context().Done()
+		}
+	})
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, ": connected\n\nevent: endpoint\ndata: /messages?session=1\n\n")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case message := <-sseMessages:
+				_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", message)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || r.URL.Query().Get("session") != "1" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if request.ID != nil {
+			sseMessages <- reply(request)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("REMOTE_TOKEN", "s3cret")
+	targetDir := t.TempDir()
+	data, err := json.Marshal(map[string]interface{}{"mcpServers": map[string]interface{}{
+		"http":         map[string]interface{}{"type": "http", "url": server.URL + "/mcp", "headers": map[string]string{"Authorization": "Bearer ${REMOTE_TOKEN}"}},
+		"sse":          map[string]interface{}{"type": "sse", "url": server.URL + "/sse"},
+		"unauthorized": map[string]interface{}{"type": "http", "url": server.URL + "/mcp"},
+		"slow":         map[string]interface{}{"type": "http", "url": server.URL + "/slow"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to marshal .mcp.json: %v", err)
+	}
+	writeTestFiles(t, targetDir, map[string]string{".mcp.json": string(data)})
+
+	results, err := CheckMCPServers(targetDir, []string{"http", "sse", "unauthorized"}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("CheckMCPServers failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %+v", results)
+	}
+
+	byServer := make(map[string]MCPCheckResult)
+	for _, result := range results {
+		byServer[result.Server] = result
+	}
+	for _, name := range []string{"http", "sse"} {
+		result := byServer[name]
+		if !result.OK() || result.ServerName != "remote" || result.Tools != 1 || string(result.Transport) != name {
+			t.Errorf("Expected a healthy %s server with 1 tool, got %+v", name, result)
+		}
+	}
+	if !sessionDeleted {
+		t.Errorf("Expected the HTTP session to be deleted after the check")
+	}
+	if result := byServer["unauthorized"]; result.OK() || !strings.Contains(result.Err.Error(), "401") {
+		t.Errorf("Expected the server without a token to fail with 401, got %v", result.Err)
+	}
+
+	// A timeout closes the session only after the handshake has stopped (run with -race)
+	results, err = CheckMCPServers(targetDir, []string{"slow"}, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("CheckMCPServers failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "no response within 200ms") {
+		t.Errorf("Expected the slow server to time out, got %+v", results)
+	}
+}
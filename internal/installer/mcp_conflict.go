@@ -0,0 +1,276 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MCPConflictStrategy decides what happens when .mcp.json already defines a server
+// key with a different configuration than the framework's
+type MCPConflictStrategy string
+
+const (
+	// MCPConflictKeep leaves the existing entry untouched (default)
+	MCPConflictKeep MCPConflictStrategy = "keep"
+	// MCPConflictOverwrite replaces the existing entry with the framework's
+	MCPConflictOverwrite MCPConflictStrategy = "overwrite"
+	// MCPConflictRename keeps the existing entry and adds the framework's under a new key
+	MCPConflictRename MCPConflictStrategy = "rename"
+	// MCPConflictPrompt asks for each conflicting entry
+	MCPConflictPrompt MCPConflictStrategy = "prompt"
+)
+
+// renamedMCPKeySuffix is appended to the framework's key when a conflict is resolved by renaming
+const renamedMCPKeySuffix = "-superclaude"
+
+// MCPConflictStrategies returns the supported strategies
+func MCPConflictStrategies() []MCPConflictStrategy {
+	return []MCPConflictStrategy{MCPConflictKeep, MCPConflictOverwrite, MCPConflictRename, MCPConflictPrompt}
+}
+
+// ParseMCPConflictStrategy validates a --mcp-conflict value
+func ParseMCPConflictStrategy(value string) (MCPConflictStrategy, error) {
+	for _, strategy := range MCPConflictStrategies() {
+		if string(strategy) == value {
+			return strategy, nil
+		}
+	}
+
+	names := make([]string, 0, len(MCPConflictStrategies()))
+	for _, strategy := range MCPConflictStrategies() {
+		names = append(names, string(strategy))
+	}
+	return "", fmt.Errorf("invalid MCP conflict strategy %q (valid: %s)", value, strings.Join(names, ", "))
+}
+
+// MCPServerAction records what the merge did with one .mcp.json server key
+type MCPServerAction string
+
+// Merge outcomes for a server key
+const (
+	MCPServerAdded     MCPServerAction = "added"
+	MCPServerUnchanged MCPServerAction = "unchanged"
+	MCPServerKept      MCPServerAction = "kept"
+	MCPServerReplaced  MCPServerAction = "replaced"
+	MCPServerRenamed   MCPServerAction = "renamed"
+)
+
+// MCPFieldChange is one differing field between two server definitions. Map fields
+// such as env are compared per entry, e.g. "env.API_KEY".
+type MCPFieldChange struct {
+	Field    string
+	Existing string // JSON value in .mcp.json, empty if missing
+	Incoming string // JSON value from the framework, empty if missing
+}
+
+// MCPServerChange is the outcome of merging one framework server into .mcp.json
+type MCPServerChange struct {
+	Key       string
+	Action    MCPServerAction
+	RenamedTo string              // Key the framework's entry was added under (renamed only)
+	Diff      []MCPFieldChange    // Differences from the existing entry (kept, replaced, renamed)
+	Env       []MCPEnvRequirement // Variables the framework's entry references (all but kept)
+	Server    string              // Name of the server the entry belongs to
+	File      string              // Config file merged into, e.g. .mcp.json
+	written   json.RawMessage     // Entry as written (all but kept), recorded by its digest
+}
+
+// promptMCPConflict asks how to resolve a conflict; overridden in tests
+var promptMCPConflict = func(key string, diff []MCPFieldChange) (MCPConflictStrategy, error) {
+	strategies := []MCPConflictStrategy{MCPConflictKeep, MCPConflictOverwrite, MCPConflictRename}
+	choice, err := promptChoice(fmt.Sprintf("Resolve %q:", key),
+		[]string{"keep existing", "overwrite with the framework entry", "rename the framework entry"},
+		"use --mcp-conflict keep, overwrite or rename")
+	if err != nil {
+		return "", err
+	}
+	return strategies[choice], nil
+}
+
+// mergeMCPServer adds the framework's definition for key to servers, resolving a
+// conflicting existing entry with the given strategy
+func mergeMCPServer(servers map[string]interface{}, file, key string, incoming interface{}, strategy MCPConflictStrategy, pause progressPause) (MCPServerChange, error) {
+	existing, exists := servers[key]
+	if !exists {
+		servers[key] = incoming
+		return MCPServerChange{Key: key, Action: MCPServerAdded}, nil
+	}
+
+	diff := diffMCPServer(existing, incoming)
+	if len(diff) == 0 {
+		return MCPServerChange{Key: key, Action: MCPServerUnchanged}, nil
+	}
+
+	fmt.Printf("⚠️  %s server %q differs from the SuperClaude configuration:\n%s", file, key, formatMCPDiff(diff))
+
+	if strategy == MCPConflictPrompt {
+		var err error
+		resume := pause.pause()
+		strategy, err = promptMCPConflict(key, diff)
+		resume()
+		if err != nil {
+			return MCPServerChange{}, err
+		}
+	}
+
+	change := MCPServerChange{Key: key, Diff: diff}
+	switch strategy {
+	case MCPConflictOverwrite:
+		servers[key] = incoming
+		change.Action = MCPServerReplaced
+	case MCPConflictRename:
+		// A copy renamed by an earlier run is reused rather than added again
+		change.RenamedTo = renamedMCPKey(servers, key+renamedMCPKeySuffix, incoming)
+		servers[change.RenamedTo] = incoming
+		change.Action = MCPServerRenamed
+	default:
+		change.Action = MCPServerKept
+	}
+
+	return change, nil
+}
+
+// renamedMCPKey returns the key or numbered key holding a definition equal to incoming,
+// or else the first free one
+func renamedMCPKey(servers map[string]interface{}, key string, incoming interface{}) string {
+	candidate := key
+	for i := 2; ; i++ {
+		existing, taken := servers[candidate]
+		if !taken || len(diffMCPServer(existing, incoming)) == 0 {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d", key, i)
+	}
+}
+
+// diffMCPServer compares two server definitions field by field: command and args
+// first, then map fields (env, headers) per entry, then any other fields by name
+func diffMCPServer(existing, incoming interface{}) []MCPFieldChange {
+	existingFields := flattenMCPServer(existing)
+	incomingFields := flattenMCPServer(incoming)
+
+	fields := make(map[string]bool)
+	for field := range existingFields {
+		fields[field] = true
+	}
+	for field := range incomingFields {
+		fields[field] = true
+	}
+
+	var diff []MCPFieldChange
+	for field := range fields {
+		oldValue, hasOld := existingFields[field]
+		newValue, hasNew := incomingFields[field]
+		if hasOld && hasNew && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		change := MCPFieldChange{Field: field}
+		if hasOld {
+			change.Existing = formatMCPValue(oldValue)
+		}
+		if hasNew {
+			change.Incoming = formatMCPValue(newValue)
+		}
+		diff = append(diff, change)
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		ri, rj := mcpFieldRank(diff[i].Field), mcpFieldRank(diff[j].Field)
+		if ri != rj {
+			return ri < rj
+		}
+		return diff[i].Field < diff[j].Field
+	})
+
+	return diff
+}
+
+// flattenMCPServer maps a server definition to comparable fields, expanding nested
+// objects one level deep ("env.API_KEY")
+func flattenMCPServer(definition interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+
+	object, ok := definition.(map[string]interface{})
+	if !ok {
+		fields["definition"] = definition
+		return fields
+	}
+
+	for name, value := range object {
+		if nested, isMap := value.(map[string]interface{}); isMap {
+			for key, nestedValue := range nested {
+				fields[name+"."+key] = nestedValue
+			}
+			continue
+		}
+		fields[name] = value
+	}
+
+	return fields
+}
+
+// mcpFieldRank orders diff fields the way server definitions are usually read
+func mcpFieldRank(field string) int {
+	name, _, _ := strings.Cut(field, ".")
+	switch name {
+	case "type":
+		return 0
+	case "command", "url":
+		return 1
+	case "args", "headers":
+		return 2
+	case "env":
+		return 3
+	default:
+		return 4
+	}
+}
+
+func formatMCPValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// formatMCPDiff renders a field diff with - for the existing and + for the framework value
+func formatMCPDiff(diff []MCPFieldChange) string {
+	var b strings.Builder
+	for _, change := range diff {
+		fmt.Fprintf(&b, "    %s:\n", change.Field)
+		if change.Existing != "" {
+			fmt.Fprintf(&b, "      - %s\n", change.Existing)
+		}
+		if change.Incoming != "" {
+			fmt.Fprintf(&b, "      + %s\n", change.Incoming)
+		}
+	}
+	return b.String()
+}
+
+// describeMCPChange formats a merge outcome for the installation summary
+func describeMCPChange(change MCPServerChange) string {
+	switch change.Action {
+	case MCPServerKept:
+		return fmt.Sprintf("%s: kept existing entry (differs in %s)", change.Key, mcpDiffFields(change.Diff))
+	case MCPServerReplaced:
+		return fmt.Sprintf("%s: replaced existing entry (%s)", change.Key, mcpDiffFields(change.Diff))
+	case MCPServerRenamed:
+		return fmt.Sprintf("%s: kept existing entry, SuperClaude entry added as %s", change.Key, change.RenamedTo)
+	default:
+		return fmt.Sprintf("%s: %s", change.Key, change.Action)
+	}
+}
+
+func mcpDiffFields(diff []MCPFieldChange) string {
+	fields := make([]string, 0, len(diff))
+	for _, change := range diff {
+		fields = append(fields, change.Field)
+	}
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,219 @@
+package installer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestDiffMCPServer validates the structured diff of two server definitions
+func TestDiffMCPServer(t *testing.T) {
+	existing := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "@upstash/context7-mcp@1.0.0"},
+		"env":     map[string]interface{}{"DEBUG": "1", "API_KEY": "abc"},
+	}
+	incoming := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "@upstash/context7-mcp@latest"},
+		"env":     map[string]interface{}{"API_KEY": "abc", "TIMEOUT": "30"},
+	}
+
+	expected := []MCPFieldChange{
+		{Field: "args", Existing: `["-y","@upstash/context7-mcp@1.0.0"]`, Incoming: `["-y","@upstash/context7-mcp@latest"]`},
+		{Field: "env.DEBUG", Existing: `"1"`},
+		{Field: "env.TIMEOUT", Incoming: `"30"`},
+	}
+	if got := diffMCPServer(existing, incoming); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	if got := diffMCPServer(incoming, incoming); len(got) != 0 {
+		t.Errorf("Expected no diff for identical definitions, got %+v", got)
+	}
+}
+
+// TestMergeMCPConfigConflicts validates each --mcp-conflict strategy
+func TestMergeMCPConfigConflicts(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+
+	// context7 differs from the framework config, serena is missing
+	const existingConfig = `{"mcpServers": {"context7": {"command": "npx", "args": ["-y", "@upstash/context7-mcp@1.0.0"]}}}`
+
+	originalPrompt := promptMCPConflict
+	defer func() { promptMCPConflict = originalPrompt }()
+	promptMCPConflict = func(key string, diff []MCPFieldChange) (MCPConflictStrategy, error) {
+		return MCPConflictOverwrite, nil
+	}
+
+	testCases := []struct {
+		strategy     MCPConflictStrategy
+		action       MCPServerAction
+		expectedKeys []string
+		context7Args string
+	}{
+		{MCPConflictKeep, MCPServerKept, []string{"context7", "serena"}, "@upstash/context7-mcp@1.0.0"},
+		{MCPConflictOverwrite, MCPServerReplaced, []string{"context7", "serena"}, "@upstash/context7-mcp@latest"},
+		{MCPConflictRename, MCPServerRenamed, []string{"context7", "context7-superclaude", "serena"}, "@upstash/context7-mcp@1.0.0"},
+		{MCPConflictPrompt, MCPServerReplaced, []string{"context7", "serena"}, "@upstash/context7-mcp@latest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+			if err := os.WriteFile(mcpPath, []byte(existingConfig), 0o600); err != nil {
+				t.Fatalf("Failed to write .mcp.json: %v", err)
+			}
+
+			changes, err := mergeMCPConfig(mcpPath, true, servers, repoPath, tc.strategy, nil)
+			if err != nil {
+				t.Fatalf("mergeMCPConfig failed: %v", err)
+			}
+
+			expectedChanges := map[string]MCPServerAction{"context7": tc.action, "serena": MCPServerAdded}
+			for _, change := range changes {
+				if expectedChanges[change.Key] != change.Action {
+					t.Errorf("Expected %s to be %s, got %s", change.Key, expectedChanges[change.Key], change.Action)
+				}
+				if change.Key == "context7" && len(change.Diff) != 1 {
+					t.Errorf("Expected one differing field for context7, got %+v", change.Diff)
+				}
+			}
+
+			keys, err := readConfiguredMCPServers(mcpPath)
+			if err != nil {
+				t.Fatalf("Failed to read .mcp.json: %v", err)
+			}
+			if !reflect.DeepEqual(keys, tc.expectedKeys) {
+				t.Errorf("Expected servers %v, got %v", tc.expectedKeys, keys)
+			}
+
+			data, err := os.ReadFile(mcpPath)
+			if err != nil {
+				t.Fatalf("Failed to read .mcp.json: %v", err)
+			}
+			var parsed struct {
+				MCPServers map[string]struct {
+					Args []string `json:"args"`
+				} `json:"mcpServers"`
+			}
+			if err := json.Unmarshal(data, &parsed); err != nil {
+				t.Fatalf("Failed to parse .mcp.json: %v", err)
+			}
+			if args := parsed.MCPServers["context7"].Args; len(args) != 2 || args[1] != tc.context7Args {
+				t.Errorf("Expected context7 args to end with %s, got %v", tc.context7Args, args)
+			}
+		})
+	}
+
+	t.Run("RenameTwice", func(t *testing.T) {
+		mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+		if err := os.WriteFile(mcpPath, []byte(existingConfig), 0o600); err != nil {
+			t.Fatalf("Failed to write .mcp.json: %v", err)
+		}
+
+		for run := 1; run <= 2; run++ {
+			changes, err := mergeMCPConfig(mcpPath, true, servers, repoPath, MCPConflictRename, nil)
+			if err != nil {
+				t.Fatalf("mergeMCPConfig run %d failed: %v", run, err)
+			}
+			for _, change := range changes {
+				if change.Key == "context7" && change.RenamedTo != "context7-superclaude" {
+					t.Errorf("Expected run %d to leave context7 under context7-superclaude, got %+v", run, change)
+				}
+			}
+		}
+
+		keys, err := readConfiguredMCPServers(mcpPath)
+		if err != nil {
+			t.Fatalf("Failed to read .mcp.json: %v", err)
+		}
+		if expected := []string{"context7", "context7-superclaude", "serena"}; !reflect.DeepEqual(keys, expected) {
+			t.Errorf("Expected servers %v after renaming twice, got %v", expected, keys)
+		}
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+		if _, err := createMCPConfigWithSelected(mcpPath, servers, repoPath); err != nil {
+			t.Fatalf("createMCPConfigWithSelected failed: %v", err)
+		}
+
+		changes, err := mergeMCPConfig(mcpPath, true, servers, repoPath, MCPConflictKeep, nil)
+		if err != nil {
+			t.Fatalf("mergeMCPConfig failed: %v", err)
+		}
+		for _, change := range changes {
+			if change.Action != MCPServerUnchanged {
+				t.Errorf("Expected %s to be unchanged, got %s", change.Key, change.Action)
+			}
+		}
+	})
+}
+
+// TestMergeMCPConfigPreservesFormatting validates that merging and removing servers
+// only touches the affected entries
+func TestMergeMCPConfigPreservesFormatting(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+
+	const original = `{
+  "mcpServers": {
+    "github": {"command": "github-mcp"}
+  },
+  "zeta": 1,
+  "alpha": 2
+}
+`
+	mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+	if err := os.WriteFile(mcpPath, []byte(original), 0o600); err != nil {
+		t.Fatalf("Failed to write .mcp.json: %v", err)
+	}
+
+	if _, err := mergeMCPConfig(mcpPath, true, servers[:1], repoPath, MCPConflictKeep, nil); err != nil {
+		t.Fatalf("mergeMCPConfig failed: %v", err)
+	}
+
+	const merged = `{
+  "mcpServers": {
+    "github": {"command": "github-mcp"},
+    "context7": {
+      "command": "npx",
+      "args": [
+        "-y",
+        "@upstash/context7-mcp@latest"
+      ]
+    }
+  },
+  "zeta": 1,
+  "alpha": 2
+}
+`
+	data, err := os.ReadFile(mcpPath)
+	if err != nil {
+		t.Fatalf("Failed to read .mcp.json: %v", err)
+	}
+	if string(data) != merged {
+		t.Errorf("Unexpected merged .mcp.json:\n%s", data)
+	}
+
+	if _, err := removeMCPConfigServers(mcpPath, []string{"context7"}); err != nil {
+		t.Fatalf("removeMCPConfigServers failed: %v", err)
+	}
+	data, err = os.ReadFile(mcpPath)
+	if err != nil {
+		t.Fatalf("Failed to read .mcp.json: %v", err)
+	}
+	if string(data) != original {
+		t.Errorf("Expected removal to restore the original file, got:\n%s", data)
+	}
+}
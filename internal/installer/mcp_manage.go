@@ -0,0 +1,573 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// MCPServerStatus describes one MCP server for `mcp list`
+type MCPServerStatus struct {
+	Name       string   `json:"name"`       // Name accepted by --mcp and `mcp add`, or the .mcp.json key for custom servers
+	Keys       []string `json:"keys"`       // .mcp.json server keys provided by the server
+	Available  bool     `json:"available"`  // Offered by the SuperClaude Framework
+	Configured bool     `json:"configured"` // Present in .mcp.json
+	Imported   bool     `json:"imported"`   // MCP_*.md imported from .superclaude/CLAUDE.md
+	Source     string   `json:"source"`     // Where an available server is defined: framework, built-in, user or project
+	Transport  string   `json:"transport"`  // stdio, http or sse, as configured in .mcp.json or offered
+
+	// Metadata of an available server, see MCPServer
+	Description string   `json:"description,omitempty"`
+	Purpose     string   `json:"purpose,omitempty"`
+	Runtime     string   `json:"runtime,omitempty"`
+	Env         []string `json:"env,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// MCPManager adds and removes MCP servers in an existing installation, keeping
+// .mcp.json, .superclaude/MCP and the *MCP_INTEGRATIONS* block consistent
+type MCPManager struct {
+	TargetDir        string
+	RepoPath         string
+	ConflictStrategy MCPConflictStrategy // Applied when `add` finds a differing .mcp.json entry
+	Env              MCPEnvOptions       // Values for secrets the added servers need
+	Targets          []MCPTarget         // Other MCP clients whose configs are updated too
+	Versions         *MCPVersions        // Pins the package specs of added servers when set
+	servers          []MCPServer
+}
+
+// NewMCPManager creates a manager for the installation in targetDir using the
+// MCP servers offered by the framework checkout at repoPath
+func NewMCPManager(targetDir, repoPath string) (*MCPManager, error) {
+	servers, err := DiscoverAllMCPServers(repoPath, targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover MCP servers: %w", err)
+	}
+
+	return &MCPManager{TargetDir: targetDir, RepoPath: repoPath, servers: servers}, nil
+}
+
+// NewInstalledMCPManager creates a manager that works from the project alone, for
+// listing and removing servers. Its servers are those recorded in the install manifest
+// or documented in .superclaude/MCP, and those of the user and project registries.
+func NewInstalledMCPManager(targetDir string) (*MCPManager, error) {
+	manager := &MCPManager{TargetDir: targetDir}
+
+	registered, err := registryMCPServers(targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover MCP servers: %w", err)
+	}
+	installed, err := manager.installedServers()
+	if err != nil {
+		return nil, err
+	}
+
+	manager.servers = mergeMCPServers(registered, installed)
+	return manager, nil
+}
+
+// installedServers returns the servers recorded in the manifest with their current
+// .mcp.json entries, and those with only an MCP_*.md file in .superclaude/MCP, as
+// installed by versions that did not record them
+func (m *MCPManager) installedServers() ([]MCPServer, error) {
+	manifest, err := LoadInstallManifest(m.TargetDir)
+	if err != nil {
+		return nil, err
+	}
+	mcpConfig, _, err := readMCPConfig(m.mcpConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []MCPServer
+	recordedDocs := make(map[string]bool)
+	if manifest != nil {
+		for _, record := range manifest.MCPServers {
+			server := MCPServer{Name: record.Name, MDFile: record.Doc, Source: record.Source, Config: make(map[string]json.RawMessage)}
+			for _, entry := range record.Entries {
+				if raw, ok := mcpConfig.raw[entry.Key]; ok && entry.File == config.MCPConfigFile && !entry.Kept {
+					server.Config[entry.Key] = raw
+				}
+			}
+			servers = append(servers, server)
+			recordedDocs[record.Doc] = true
+		}
+	}
+
+	entries, err := os.ReadDir(m.mcpDocsDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read MCP directory: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || recordedDocs[name] || !strings.HasPrefix(name, "MCP_") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		servers = append(servers, MCPServer{
+			Name:   strings.TrimSuffix(strings.TrimPrefix(name, "MCP_"), ".md"),
+			MDFile: name,
+			Source: MCPSourceFramework,
+			Config: make(map[string]json.RawMessage),
+		})
+	}
+
+	for i, server := range servers {
+		if server.MDFile != "" && fileExists(filepath.Join(m.mcpDocsDir(), server.MDFile)) {
+			server.DocPath = filepath.Join(m.mcpDocsDir(), server.MDFile)
+		}
+		servers[i] = server.describe("")
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+	return servers, nil
+}
+
+func (m *MCPManager) mcpConfigPath() string {
+	return filepath.Join(m.TargetDir, config.MCPConfigFile)
+}
+
+func (m *MCPManager) superClaudePath() string {
+	return filepath.Join(m.TargetDir, config.SuperClaudeDir, config.CLAUDEFile)
+}
+
+func (m *MCPManager) mcpDocsDir() string {
+	return filepath.Join(m.TargetDir, config.SuperClaudeDir, "MCP")
+}
+
+// checkInstalled ensures the project has a SuperClaude installation to modify
+func (m *MCPManager) checkInstalled() error {
+	if !fileExists(m.superClaudePath()) {
+		return fmt.Errorf("SuperClaude is not installed in %s (run init first)", m.TargetDir)
+	}
+	return nil
+}
+
+// serverKeys returns the .mcp.json keys defined by a server's config file
+func (m *MCPManager) serverKeys(server MCPServer) ([]string, error) {
+	serverConfig, err := server.LoadConfigRaw(m.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(serverConfig))
+	for key := range serverConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// List reports every available, configured or imported server
+func (m *MCPManager) List() ([]MCPServerStatus, error) {
+	configured, err := readConfiguredMCPServers(m.mcpConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	imported, err := readMCPImports(m.superClaudePath())
+	if err != nil {
+		return nil, err
+	}
+	transports, err := readConfiguredMCPTransports(m.mcpConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MCPServerStatus
+	claimed := make(map[string]bool)
+	for _, server := range m.servers {
+		serverConfig, err := server.LoadConfigRaw(m.RepoPath)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := m.serverKeys(server)
+		if err != nil {
+			return nil, err
+		}
+
+		status := MCPServerStatus{
+			Name:      strings.ToLower(server.Name),
+			Keys:      keys,
+			Available: true,
+			Imported:  server.MDFile != "" && slices.Contains(imported, server.MDFile),
+			Source:    server.Source,
+
+			Description: server.Description,
+			Purpose:     server.Purpose,
+			Runtime:     server.Runtime,
+			Env:         server.Env,
+			Tags:        server.Tags,
+		}
+		for _, key := range keys {
+			claimed[key] = true
+			transport, ok := transports[key]
+			if ok {
+				status.Configured = true
+			} else {
+				transport = mcpTransportOf(serverConfig[key])
+			}
+			if status.Transport == "" {
+				status.Transport = string(transport)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Servers added to .mcp.json by hand
+	for _, key := range configured {
+		if !claimed[key] {
+			statuses = append(statuses, MCPServerStatus{Name: key, Keys: []string{key}, Configured: true, Transport: string(transports[key])})
+		}
+	}
+
+	return statuses, nil
+}
+
+// Add installs the named servers: their config goes into .mcp.json (differing entries
+// are resolved with ConflictStrategy), their MCP_*.md file into .superclaude/MCP and an
+// import into the *MCP_INTEGRATIONS* block
+func (m *MCPManager) Add(names []string) ([]MCPServerChange, error) {
+	if err := m.checkInstalled(); err != nil {
+		return nil, err
+	}
+	if m.RepoPath == "" {
+		return nil, fmt.Errorf("adding MCP servers needs the framework checkout")
+	}
+
+	selected, err := ResolveMCPServers(m.servers, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+	if m.Versions != nil {
+		if selected, err = pinMCPServers(selected, m.RepoPath, *m.Versions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(m.mcpDocsDir(), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create MCP directory: %w", err)
+	}
+	if err := copyMCPDocs(m.RepoPath, m.mcpDocsDir(), selected); err != nil {
+		return nil, err
+	}
+
+	// A new .mcp.json has no conflicts, so merge into an empty one
+	if !fileExists(m.mcpConfigPath()) {
+		if _, err := createMCPConfigWithSelected(m.mcpConfigPath(), nil, m.RepoPath); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := mergeMCPConfig(m.mcpConfigPath(), true, selected, m.RepoPath, m.ConflictStrategy, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureMCPSecrets(m.TargetDir, changes, m.Env, nil); err != nil {
+		return nil, err
+	}
+	warnLiteralSecrets(m.TargetDir)
+
+	issues, err := checkSelectedMCPRuntimes(m.RepoPath, selected)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		fmt.Printf("⚠️  %s\n", issue)
+	}
+
+	files, clientChanges, err := writeMCPClientConfigs(m.TargetDir, m.Targets, selected, m.RepoPath, m.ConflictStrategy, m.Env, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		fmt.Printf("✅ Updated %s\n", relativeToTarget(m.TargetDir, file))
+	}
+
+	// Record the entries so remove deletes only these; older installs have no manifest
+	manifest, err := LoadInstallManifest(m.TargetDir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		manifest.recordMCPChanges(selected, append(slices.Clone(changes), clientChanges...))
+		if err := manifest.Save(m.TargetDir); err != nil {
+			return nil, err
+		}
+	}
+
+	imported, err := readMCPImports(m.superClaudePath())
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range selected {
+		if server.MDFile != "" && !slices.Contains(imported, server.MDFile) {
+			imported = append(imported, server.MDFile)
+		}
+	}
+
+	return changes, updateSuperClaudeMCPImports(m.superClaudePath(), m.importedServers(imported))
+}
+
+// Remove uninstalls the named servers from .mcp.json, the Targets' configs,
+// .superclaude/MCP and the *MCP_INTEGRATIONS* block. Only entries recorded in the install
+// manifest are deleted, and only while they are as written; an entry the user kept, or
+// edited since, is left in place with a warning. Names that are not installed servers
+// are removed from .mcp.json by key. Returns the .mcp.json keys that were removed.
+func (m *MCPManager) Remove(names []string) ([]string, error) {
+	if err := m.checkInstalled(); err != nil {
+		return nil, err
+	}
+
+	manifest, err := LoadInstallManifest(m.TargetDir)
+	if err != nil {
+		return nil, err
+	}
+	configured, err := readConfiguredMCPServers(m.mcpConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []mcpClient{claudeCodeClient}
+	for _, target := range m.Targets {
+		clients = append(clients, mcpClients[target])
+	}
+
+	keys := make(map[string][]string) // By config file
+	var docs, recorded []string
+	for _, name := range names {
+		server, known := m.findServer(name)
+		switch record := manifest.mcpServer(name); {
+		case record != nil:
+			owned, err := m.ownedMCPKeys(*record, clients)
+			if err != nil {
+				return nil, err
+			}
+			for file, fileKeys := range owned {
+				keys[file] = append(keys[file], fileKeys...)
+			}
+			if record.Doc != "" {
+				docs = append(docs, record.Doc)
+			}
+			recorded = append(recorded, record.Name)
+		case slices.Contains(configured, name):
+			for _, client := range clients {
+				keys[client.File] = append(keys[client.File], name)
+			}
+		case known:
+			// Installed before entries were recorded, so only its doc and import go
+			fmt.Printf("⚠️  No recorded .mcp.json entries for %s; remove them by key if they are SuperClaude's\n", name)
+		default:
+			return nil, fmt.Errorf("unknown MCP server %q (available: %s; configured: %s)", name,
+				strings.Join(mcpServerNames(m.servers), ", "), strings.Join(configured, ", "))
+		}
+		if known && server.MDFile != "" && !slices.Contains(docs, server.MDFile) {
+			docs = append(docs, server.MDFile)
+		}
+	}
+
+	removed, err := removeMCPConfigServers(m.mcpConfigPath(), keys[claudeCodeClient.File])
+	if err != nil {
+		return nil, err
+	}
+	for _, client := range clients[1:] {
+		path, err := client.Path(m.TargetDir)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := removeMCPClientServers(client, path, keys[client.File]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, doc := range docs {
+		if err := os.Remove(filepath.Join(m.mcpDocsDir(), doc)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove MCP file %s: %w", doc, err)
+		}
+	}
+
+	imported, err := readMCPImports(m.superClaudePath())
+	if err != nil {
+		return nil, err
+	}
+	var remaining []string
+	for _, doc := range imported {
+		if !slices.Contains(docs, doc) {
+			remaining = append(remaining, doc)
+		}
+	}
+	if err := updateSuperClaudeMCPImports(m.superClaudePath(), m.importedServers(remaining)); err != nil {
+		return nil, err
+	}
+
+	if len(recorded) > 0 {
+		manifest.forgetMCPServers(recorded, clients)
+		if err := manifest.Save(m.TargetDir); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// ownedMCPKeys returns the recorded keys of a server in the clients' config files that
+// still hold the entry as written, warning about those it leaves in place
+func (m *MCPManager) ownedMCPKeys(record InstalledMCP, clients []mcpClient) (map[string][]string, error) {
+	owned := make(map[string][]string)
+	for _, client := range clients {
+		path, err := client.Path(m.TargetDir)
+		if err != nil {
+			return nil, err
+		}
+		current, err := readMCPClientEntries(client, path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range record.Entries {
+			raw, ok := current[entry.Key]
+			switch {
+			case entry.File != client.File || !ok:
+				continue
+			case entry.Kept:
+				fmt.Printf("⚠️  Left %s in %s: your entry was kept when %s was installed\n", entry.Key, client.File, record.Name)
+			case mcpEntryDigest(raw) != entry.Digest:
+				fmt.Printf("⚠️  Left %s in %s: it changed since SuperClaude wrote it\n", entry.Key, client.File)
+			default:
+				owned[client.File] = append(owned[client.File], entry.Key)
+			}
+		}
+	}
+	return owned, nil
+}
+
+// readMCPClientEntries returns the server entries in a client's config file by key; a
+// missing file has none
+func readMCPClientEntries(client mcpClient, path string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", client.File, err)
+	}
+
+	doc, problems := parseMCPDocument(data, client.File)
+	if problems != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", client.File, problems)
+	}
+	entries := make(map[string]json.RawMessage)
+	for _, key := range doc.Keys(client.ServersKey) {
+		entries[key], _ = doc.Raw(client.ServersKey, key)
+	}
+	return entries, nil
+}
+
+// findServer looks up a framework server by its case-insensitive name
+func (m *MCPManager) findServer(name string) (MCPServer, bool) {
+	for _, server := range m.servers {
+		if strings.EqualFold(server.Name, strings.TrimSpace(name)) {
+			return server, true
+		}
+	}
+	return MCPServer{}, false
+}
+
+// importedServers maps MCP_*.md file names back to servers, keeping files that no
+// longer match a framework server so their imports are preserved
+func (m *MCPManager) importedServers(docs []string) []MCPServer {
+	servers := make([]MCPServer, 0, len(docs))
+	for _, doc := range docs {
+		server := MCPServer{MDFile: doc}
+		for _, known := range m.servers {
+			if known.MDFile == doc {
+				server = known
+				break
+			}
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// readConfiguredMCPServers returns the server keys in .mcp.json, sorted; a missing file has none
+func readConfiguredMCPServers(mcpPath string) ([]string, error) {
+	mcpConfig, _, err := readMCPConfig(mcpPath)
+	if err != nil {
+		return nil, err
+	}
+	return mcpConfig.Keys(), nil
+}
+
+// removeMCPConfigServers deletes server keys from .mcp.json in place and returns the
+// keys that were present
+func removeMCPConfigServers(mcpPath string, keys []string) ([]string, error) {
+	return removeMCPClientServers(claudeCodeClient, mcpPath, keys)
+}
+
+// removeMCPClientServers deletes server keys from a client's config file in place and
+// returns the keys that were present
+func removeMCPClientServers(client mcpClient, path string, keys []string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", client.File, err)
+	}
+
+	doc, problems := parseMCPDocument(data, client.File)
+	if problems != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", client.File, problems)
+	}
+	if _, problem := serversValue(doc, client.File, client.ServersKey); problem != nil {
+		return nil, fmt.Errorf("cannot remove servers from %s: %w", client.File, problem)
+	}
+
+	var removed []string
+	for _, key := range keys {
+		deleted, err := doc.Delete(client.ServersKey, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove %s from %s: %w", key, client.File, err)
+		}
+		if deleted {
+			removed = append(removed, key)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	return removed, os.WriteFile(path, doc.Bytes(), 0o600)
+}
+
+// readMCPImports returns the MCP_*.md files imported by the *MCP_INTEGRATIONS* block
+func readMCPImports(superClaudePath string) ([]string, error) {
+	content, err := os.ReadFile(superClaudePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .superclaude/CLAUDE.md: %w", err)
+	}
+
+	var docs []string
+	inMCPSection := false
+	for _, line := range strings.Split(string(content), "\n") {
+		switch {
+		case strings.HasPrefix(line, "*MCP_INTEGRATIONS*"):
+			inMCPSection = true
+		case inMCPSection && strings.HasPrefix(line, "@MCP/"):
+			docs = append(docs, strings.TrimSpace(strings.TrimPrefix(line, "@MCP/")))
+		case inMCPSection:
+			inMCPSection = false
+		}
+	}
+	return docs, nil
+}
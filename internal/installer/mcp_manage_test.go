@@ -0,0 +1,269 @@
+package installer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestMCPManager validates that add, list and remove keep .mcp.json, the MCP docs and
+// the *MCP_INTEGRATIONS* block in sync
+func TestMCPManager(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	targetDir := t.TempDir()
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude/CLAUDE.md": "# SuperClaude Entry Point\n\n*MANDATORY*\n@FLAGS.md\n",
+		".mcp.json":              `{"mcpServers": {"github": {"command": "github-mcp"}}}`,
+	})
+	if err := (&InstallManifest{}).Save(targetDir); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	manager, err := NewMCPManager(targetDir, repoPath)
+	if err != nil {
+		t.Fatalf("NewMCPManager failed: %v", err)
+	}
+
+	superClaudePath := filepath.Join(targetDir, ".superclaude", "CLAUDE.md")
+	mcpPath := filepath.Join(targetDir, ".mcp.json")
+
+	readFile := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	configuredKeys := func() []string {
+		t.Helper()
+		keys, err := readConfiguredMCPServers(mcpPath)
+		if err != nil {
+			t.Fatalf("Failed to read .mcp.json: %v", err)
+		}
+		return keys
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		if _, err := manager.Add([]string{"serena"}); err != nil {
+			t.Fatalf("Add serena failed: %v", err)
+		}
+		if _, err := manager.Add([]string{"context7"}); err != nil {
+			t.Fatalf("Add context7 failed: %v", err)
+		}
+
+		if got := configuredKeys(); !reflect.DeepEqual(got, []string{"context7", "github", "serena"}) {
+			t.Errorf("Unexpected .mcp.json servers: %v", got)
+		}
+		expected := "# SuperClaude Entry Point\n\n*MANDATORY*\n@FLAGS.md\n\n*MCP_INTEGRATIONS*\n@MCP/MCP_Serena.md\n@MCP/MCP_Context7.md\n"
+		if got := readFile(superClaudePath); got != expected {
+			t.Errorf("Unexpected .superclaude/CLAUDE.md:\n%q", got)
+		}
+		if !fileExists(filepath.Join(targetDir, ".superclaude", "MCP", "MCP_Context7.md")) {
+			t.Errorf("Expected MCP_Context7.md to be copied")
+		}
+
+		if _, err := manager.Add([]string{"playwright"}); err == nil {
+			t.Errorf("Expected error for unknown server")
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(targetDir, ".superclaude", "MCP", "MCP_Serena.md")); err != nil {
+			t.Fatalf("Failed to remove doc: %v", err)
+		}
+
+		statuses, err := manager.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+
+		expected := []MCPServerStatus{
+			{Name: "context7", Keys: []string{"context7"}, Available: true, Configured: true, Imported: true, Source: MCPSourceFramework, Transport: "stdio", Runtime: "node 18+"},
+			{Name: "serena", Keys: []string{"serena"}, Available: true, Configured: true, Imported: true, Source: MCPSourceFramework, Transport: "stdio", Runtime: "uv 0.4+, git"},
+			{Name: "github", Keys: []string{"github"}, Configured: true, Transport: "stdio"},
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, statuses)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		removed, err := manager.Remove([]string{"Serena", "github"})
+		if err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if !reflect.DeepEqual(removed, []string{"serena", "github"}) {
+			t.Errorf("Unexpected removed keys: %v", removed)
+		}
+
+		if got := configuredKeys(); !reflect.DeepEqual(got, []string{"context7"}) {
+			t.Errorf("Unexpected .mcp.json servers: %v", got)
+		}
+		expected := "# SuperClaude Entry Point\n\n*MANDATORY*\n@FLAGS.md\n\n*MCP_INTEGRATIONS*\n@MCP/MCP_Context7.md\n"
+		if got := readFile(superClaudePath); got != expected {
+			t.Errorf("Unexpected .superclaude/CLAUDE.md:\n%q", got)
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(readFile(mcpPath)), &parsed); err != nil {
+			t.Errorf("Expected valid .mcp.json: %v", err)
+		}
+
+		if _, err := manager.Remove([]string{"unknown"}); err == nil {
+			t.Errorf("Expected error for unknown server")
+		}
+	})
+
+	t.Run("Installed", func(t *testing.T) {
+		installed, err := NewInstalledMCPManager(targetDir)
+		if err != nil {
+			t.Fatalf("NewInstalledMCPManager failed: %v", err)
+		}
+		statuses, err := installed.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		expected := []MCPServerStatus{
+			{Name: "context7", Keys: []string{"context7"}, Available: true, Configured: true, Imported: true, Source: MCPSourceFramework, Transport: "stdio", Runtime: "node 18+"},
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, statuses)
+		}
+
+		if _, err := installed.Add([]string{"serena"}); err == nil {
+			t.Errorf("Expected add to need the framework checkout")
+		}
+		if _, err := installed.Remove([]string{"context7"}); err != nil {
+			t.Fatalf("Remove without the framework checkout failed: %v", err)
+		}
+		if got := configuredKeys(); len(got) != 0 {
+			t.Errorf("Expected context7 to be removed, got %v", got)
+		}
+	})
+
+	t.Run("Not_installed", func(t *testing.T) {
+		other, err := NewMCPManager(t.TempDir(), repoPath)
+		if err != nil {
+			t.Fatalf("NewMCPManager failed: %v", err)
+		}
+		if _, err := other.Add([]string{"context7"}); err == nil {
+			t.Errorf("Expected error when SuperClaude is not installed")
+		}
+	})
+}
+
+// TestMCPManagerRemoveOwnership validates that remove deletes only the entries the
+// installer wrote, leaving kept, renamed-around and edited entries of the user's
+func TestMCPManagerRemoveOwnership(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{"SuperClaude/Agents/system-architect.md": "# System Architect\n"})
+	const userConfig = `{"mcpServers": {"context7": {"command": "custom"}}}`
+
+	// install adds context7 with the strategy and returns the project and its .mcp.json
+	install := func(t *testing.T, strategy MCPConflictStrategy) (*MCPManager, string) {
+		t.Helper()
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{
+			".superclaude/CLAUDE.md": "# SuperClaude Entry Point\n",
+			".mcp.json":              userConfig,
+		})
+		if err := (&InstallManifest{}).Save(targetDir); err != nil {
+			t.Fatalf("Failed to save manifest: %v", err)
+		}
+		manager, err := NewMCPManager(targetDir, repoPath)
+		if err != nil {
+			t.Fatalf("NewMCPManager failed: %v", err)
+		}
+		manager.ConflictStrategy = strategy
+		if _, err := manager.Add([]string{"context7"}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		return manager, filepath.Join(targetDir, ".mcp.json")
+	}
+
+	t.Run("Kept", func(t *testing.T) {
+		manager, mcpPath := install(t, MCPConflictKeep)
+		removed, err := manager.Remove([]string{"context7"})
+		if err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("Expected the kept entry to stay, removed %v", removed)
+		}
+		if data, _ := os.ReadFile(mcpPath); string(data) != userConfig {
+			t.Errorf("Expected .mcp.json unchanged, got:\n%s", data)
+		}
+		if fileExists(filepath.Join(manager.TargetDir, ".superclaude", "MCP", "MCP_Context7.md")) {
+			t.Errorf("Expected MCP_Context7.md to be removed")
+		}
+	})
+
+	t.Run("Renamed", func(t *testing.T) {
+		manager, mcpPath := install(t, MCPConflictRename)
+		removed, err := manager.Remove([]string{"context7"})
+		if err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if !reflect.DeepEqual(removed, []string{"context7-superclaude"}) {
+			t.Errorf("Expected only the renamed entry to be removed, got %v", removed)
+		}
+		if keys, _ := readConfiguredMCPServers(mcpPath); !reflect.DeepEqual(keys, []string{"context7"}) {
+			t.Errorf("Expected the user's context7 to stay, got %v", keys)
+		}
+		manifest, err := LoadInstallManifest(manager.TargetDir)
+		if err != nil || manifest == nil || len(manifest.MCPServers) != 0 {
+			t.Errorf("Expected the manifest to forget context7, got %+v (%v)", manifest, err)
+		}
+	})
+
+	t.Run("Init", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{".mcp.json": userConfig})
+		installer, err := NewInstaller(targetDir, &InstallConfig{
+			FrameworkDir:      repoPath,
+			NoBackup:          true,
+			AddRecommendedMCP: true,
+			MCPServers:        []string{"context7"},
+			MCPConflict:       MCPConflictRename,
+		})
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		if err := installer.Install(); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		manager, err := NewInstalledMCPManager(targetDir)
+		if err != nil {
+			t.Fatalf("NewInstalledMCPManager failed: %v", err)
+		}
+		removed, err := manager.Remove([]string{"context7"})
+		if err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if !reflect.DeepEqual(removed, []string{"context7-superclaude"}) {
+			t.Errorf("Expected the entry init renamed to be removed, got %v", removed)
+		}
+		if keys, _ := readConfiguredMCPServers(filepath.Join(targetDir, ".mcp.json")); !reflect.DeepEqual(keys, []string{"context7"}) {
+			t.Errorf("Expected the user's context7 to stay, got %v", keys)
+		}
+	})
+
+	t.Run("Edited", func(t *testing.T) {
+		manager, mcpPath := install(t, MCPConflictOverwrite)
+		edited := `{"mcpServers": {"context7": {"command": "npx", "args": ["-y", "my-fork"]}}}`
+		if err := os.WriteFile(mcpPath, []byte(edited), 0o600); err != nil {
+			t.Fatalf("Failed to edit .mcp.json: %v", err)
+		}
+		if removed, err := manager.Remove([]string{"context7"}); err != nil || len(removed) != 0 {
+			t.Errorf("Expected the edited entry to stay, removed %v (%v)", removed, err)
+		}
+		if data, _ := os.ReadFile(mcpPath); string(data) != edited {
+			t.Errorf("Expected .mcp.json unchanged, got:\n%s", data)
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package installer
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// mcpDocMetadata is what an MCP_*.md file says about its server
+type mcpDocMetadata struct {
+	Title       string
+	Description string
+	Purpose     string
+	Runtime     string
+	Env         []string
+	Tags        []string
+}
+
+// purposePattern matches the "**Purpose**: ..." line of the framework's MCP docs
+var purposePattern = regexp.MustCompile(`(?i)^\*\*purpose:?\*\*:?\s*(.+)$`)
+
+// parseMCPDoc reads the metadata of an MCP_*.md file. Front matter keys (description,
+// purpose, runtime, env and tags) win; otherwise the first heading is the title, the
+// "**Purpose**:" line the purpose and the first paragraph before the next section the
+// description, falling back to the purpose.
+func parseMCPDoc(content string) mcpDocMetadata {
+	var meta mcpDocMetadata
+	body := parseMCPFrontMatter(content, &meta)
+
+	var paragraph []string
+	inCode, inSection, described := false, false, meta.Description != ""
+	flush := func() {
+		if len(paragraph) > 0 && !described {
+			meta.Description = strings.Join(paragraph, " ")
+			described = true
+		}
+		paragraph = nil
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			flush()
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# ") && meta.Title == "":
+			meta.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		case strings.HasPrefix(line, "#"):
+			flush()
+			inSection = meta.Title != ""
+		case purposePattern.MatchString(line):
+			// The summary comes before the purpose line, not after it
+			flush()
+			described = true
+			if meta.Purpose == "" {
+				meta.Purpose = purposePattern.FindStringSubmatch(line)[1]
+			}
+		case line == "" || strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "|") || strings.HasPrefix(line, ">"):
+			flush()
+		case !inSection && meta.Title != "":
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	if meta.Description == "" {
+		meta.Description = meta.Purpose
+	}
+	return meta
+}
+
+// parseMCPFrontMatter fills meta from a leading "---" block of "key: value" lines,
+// where lists are written as [a, b] or as "- item" lines, and returns the rest
+func parseMCPFrontMatter(content string, meta *mcpDocMetadata) string {
+	content = strings.TrimPrefix(content, "\ufeff")
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		if rest, ok = strings.CutPrefix(content, "---\r\n"); !ok {
+			return content
+		}
+	}
+	block, body, ok := strings.Cut(rest, "\n---")
+	if !ok {
+		return content
+	}
+	if _, after, found := strings.Cut(body, "\n"); found {
+		body = after
+	} else {
+		body = ""
+	}
+
+	var list *[]string
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		if item, isItem := strings.CutPrefix(line, "- "); isItem {
+			if list != nil {
+				*list = append(*list, unquoteFrontMatter(item))
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		list = nil
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title", "name":
+			meta.Title = unquoteFrontMatter(value)
+		case "description", "summary":
+			meta.Description = unquoteFrontMatter(value)
+		case "purpose":
+			meta.Purpose = unquoteFrontMatter(value)
+		case "runtime":
+			meta.Runtime = unquoteFrontMatter(value)
+		case "env":
+			list = &meta.Env
+		case "tags":
+			list = &meta.Tags
+		}
+		if list != nil && value != "" {
+			*list = append(*list, frontMatterList(value)...)
+			list = nil
+		}
+	}
+	return body
+}
+
+// frontMatterList splits "[a, b]" or "a, b" into its items
+func frontMatterList(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = unquoteFrontMatter(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// unquoteFrontMatter trims a value and its surrounding quotes
+func unquoteFrontMatter(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return value
+}
+
+// describe fills the server's description, purpose, runtime, env and tags from its
+// MCP_*.md file, keeping a description it already has. The runtime and env vars are
+// derived from its config when the file does not state them.
+func (s MCPServer) describe(repoPath string) MCPServer {
+	doc := readMCPDoc(repoPath, s)
+	meta := parseMCPDoc(doc)
+
+	if s.Description == "" {
+		s.Description = meta.Description
+	}
+	if meta.Purpose != "" {
+		s.Purpose = meta.Purpose
+	}
+	s.Tags = meta.Tags
+
+	entries, err := s.LoadConfigRaw(repoPath)
+	if err != nil {
+		entries = nil
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	s.Runtime = meta.Runtime
+	if s.Runtime == "" {
+		var runtimes []string
+		for _, key := range keys {
+			for _, runtime := range describeMCPRuntime(entries[key]) {
+				if !slices.Contains(runtimes, runtime) {
+					runtimes = append(runtimes, runtime)
+				}
+			}
+		}
+		s.Runtime = strings.Join(runtimes, ", ")
+	}
+
+	s.Env = meta.Env
+	if len(s.Env) == 0 {
+		for _, key := range keys {
+			for _, requirement := range detectMCPEnvRequirements(key, entries[key], doc) {
+				if !slices.Contains(s.Env, requirement.Variable) {
+					s.Env = append(s.Env, requirement.Variable)
+				}
+			}
+		}
+	}
+
+	return s
+}
+
+// describeMCPRuntime names what a server definition runs on, e.g. "node 18+" for npx,
+// "uv 0.4+" and "git" for a uvx git install, or "http (remote)"
+func describeMCPRuntime(raw json.RawMessage) []string {
+	var definition MCPServerConfig
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		return nil
+	}
+	if transport := definition.Transport(); transport != MCPTransportStdio {
+		return []string{string(transport) + " (remote)"}
+	}
+	if definition.Command == "" {
+		return nil
+	}
+
+	var runtimes []string
+	for _, runtime := range requiredRuntimes(definition.Command, definition.Args) {
+		// npx, npm and uvx come with the runtime listed before them
+		if len(runtimes) > 0 && runtime.Tool != "git" {
+			continue
+		}
+		name := runtime.Tool
+		if version := strings.TrimSuffix(strings.TrimSuffix(runtime.MinVersion, ".0"), ".0"); version != "" {
+			name += " " + version + "+"
+		}
+		runtimes = append(runtimes, name)
+	}
+	return runtimes
+}
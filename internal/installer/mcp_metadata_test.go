@@ -0,0 +1,116 @@
+package installer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseMCPDoc validates that metadata is read from front matter or from the first
+// heading, summary paragraph and purpose line
+func TestParseMCPDoc(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected mcpDocMetadata
+	}{
+		{
+			name: "FrontMatter",
+			content: `---
+description: "UI components from 21st.dev"
+purpose: Generate modern UI components
+runtime: node 18+
+env: [TWENTYFIRST_API_KEY]
+tags:
+  - ui
+  - frontend
+---
+# Magic MCP Server
+
+Ignored summary.
+`,
+			expected: mcpDocMetadata{
+				Title:       "Magic MCP Server",
+				Description: "UI components from 21st.dev",
+				Purpose:     "Generate modern UI components",
+				Runtime:     "node 18+",
+				Env:         []string{"TWENTYFIRST_API_KEY"},
+				Tags:        []string{"ui", "frontend"},
+			},
+		},
+		{
+			name: "HeadingAndSummary",
+			content: `# Playwright MCP Server
+
+Browser automation and E2E testing
+with real browsers.
+
+**Purpose**: Cross-browser testing and visual validation
+
+## Triggers
+- Browser testing
+`,
+			expected: mcpDocMetadata{
+				Title:       "Playwright MCP Server",
+				Description: "Browser automation and E2E testing with real browsers.",
+				Purpose:     "Cross-browser testing and visual validation",
+			},
+		},
+		{
+			name:    "PurposeOnly",
+			content: "# Context7 MCP Server\n\n**Purpose**: Official library documentation lookup\n\n## Triggers\n\nImport statements\n",
+			expected: mcpDocMetadata{
+				Title:       "Context7 MCP Server",
+				Description: "Official library documentation lookup",
+				Purpose:     "Official library documentation lookup",
+			},
+		},
+		{
+			name:     "Empty",
+			content:  "",
+			expected: mcpDocMetadata{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMCPDoc(tt.content); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestDiscoverMCPServerMetadata validates that discovery fills the metadata from the
+// docs and derives the runtime and env vars from the configs
+func TestDiscoverMCPServerMetadata(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{
+		"SuperClaude/MCP/MCP_Context7.md": "# Context7 MCP Server\n\n**Purpose**: Official library documentation lookup\n\nSet CONTEXT7_API_KEY for higher rate limits.\n",
+	})
+
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+	byName := make(map[string]MCPServer)
+	for _, server := range servers {
+		byName[server.Name] = server
+	}
+
+	context7 := byName["Context7"]
+	if context7.DisplayName != "Context7 MCP integration" || context7.Description != "Official library documentation lookup" {
+		t.Errorf("Expected the purpose as description, got %+v", context7)
+	}
+	if context7.Runtime != "node 18+" || !reflect.DeepEqual(context7.Env, []string{"CONTEXT7_API_KEY"}) {
+		t.Errorf("Expected node and the documented key, got runtime %q env %v", context7.Runtime, context7.Env)
+	}
+	if serena := byName["Serena"]; serena.Runtime != "uv 0.4+, git" {
+		t.Errorf("Expected uv and git for Serena, got %q", serena.Runtime)
+	}
+
+	details := mcpServerDetails(context7)
+	expected := []string{"Official library documentation lookup", "runtime: node 18+ · env: CONTEXT7_API_KEY"}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("Expected %v in the selector, got %v", expected, details)
+	}
+}
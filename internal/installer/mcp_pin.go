@@ -0,0 +1,435 @@
+package installer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
+)
+
+// Versions --pin-mcp writes, shipped with the tool
+//
+//go:embed mcp_versions.json
+var builtinMCPVersions []byte
+
+// MCP package managers whose specs can be pinned
+const (
+	MCPPackageNPM = "npm" // Packages run with npx
+	MCPPackageUVX = "uvx" // PyPI packages and git+https sources run with uvx
+)
+
+// npmVersionPattern matches an exact npm version such as 1.0.14 or 2025.7.1
+var npmVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)
+
+// releasePattern matches an exact PyPI version or a release tag such as v0.1.4
+var releasePattern = regexp.MustCompile(`^v?\d+(?:\.\d+)*(?:[-.+]?[0-9A-Za-z]+)*$`)
+
+// commitPattern matches an abbreviated or full git commit
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// uvxValueFlags are uvx options followed by a value that is not the package
+var uvxValueFlags = map[string]bool{"--with": true, "--python": true, "-p": true, "--index": true, "--index-url": true, "--extra-index-url": true}
+
+// MCPVersions maps package names to the exact versions --pin-mcp writes
+type MCPVersions struct {
+	NPM map[string]string `json:"npm"` // npm package -> version
+	UVX map[string]string `json:"uvx"` // PyPI package -> version, git+https URL -> tag or commit
+}
+
+// LoadMCPVersions returns the built-in versions, overridden by mcp-versions.json in
+// the user config dir and in the project, and then by file when it is set
+func LoadMCPVersions(targetDir, file string) (MCPVersions, error) {
+	versions := MCPVersions{NPM: make(map[string]string), UVX: make(map[string]string)}
+	if err := versions.merge(builtinMCPVersions, "built-in MCP versions"); err != nil {
+		return versions, err
+	}
+
+	var paths []string
+	if userDir, err := UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(userDir, config.MCPVersionsFile))
+	}
+	paths = append(paths, filepath.Join(ProjectConfigDir(targetDir), config.MCPVersionsFile))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return versions, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := versions.merge(data, path); err != nil {
+			return versions, err
+		}
+	}
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return versions, fmt.Errorf("failed to read MCP versions file: %w", err)
+		}
+		if err := versions.merge(data, file); err != nil {
+			return versions, err
+		}
+	}
+	return versions, nil
+}
+
+// merge adds the versions in a versions file, rejecting anything but exact versions
+func (v *MCPVersions) merge(data []byte, source string) error {
+	var overrides MCPVersions
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+
+	for name, version := range overrides.NPM {
+		if !npmVersionPattern.MatchString(version) {
+			return fmt.Errorf("%s: npm package %s: %q is not an exact version", source, name, version)
+		}
+		v.NPM[name] = version
+	}
+	for name, version := range overrides.UVX {
+		pkg := MCPPackage{Manager: MCPPackageUVX, Name: name, Version: version}
+		if !pkg.Pinned() {
+			return fmt.Errorf("%s: uvx package %s: %q is not an exact version, tag or commit", source, name, version)
+		}
+		v.UVX[name] = version
+	}
+	return nil
+}
+
+// lookup returns the version to pin a package to
+func (v MCPVersions) lookup(pkg MCPPackage) (string, bool) {
+	versions := v.NPM
+	if pkg.Manager == MCPPackageUVX {
+		versions = v.UVX
+	}
+	version, ok := versions[pkg.Name]
+	return version, ok
+}
+
+// MCPPackage is the package an npx or uvx server runs, as found in its args
+type MCPPackage struct {
+	Manager string // MCPPackageNPM or MCPPackageUVX
+	Name    string // npm or PyPI package name, or git+https URL
+	Version string // Requested version, tag or commit; empty when none
+	arg     int    // Index of the spec in args
+	from    bool   // The spec is uvx's --from value rather than the command
+}
+
+// Pinned reports whether the spec requests an exact version rather than @latest, a
+// range or a branch
+func (p MCPPackage) Pinned() bool {
+	switch {
+	case p.Version == "":
+		return false
+	case p.Manager == MCPPackageNPM:
+		return npmVersionPattern.MatchString(p.Version)
+	case isGitSource(p.Name):
+		return releasePattern.MatchString(p.Version) || commitPattern.MatchString(p.Version)
+	}
+	return releasePattern.MatchString(p.Version)
+}
+
+// String formats the spec as written in args
+func (p MCPPackage) String() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.spec(p.Version)
+}
+
+// spec formats the package at version for the command line
+func (p MCPPackage) spec(version string) string {
+	if p.Manager == MCPPackageUVX && p.from && !isGitSource(p.Name) {
+		return p.Name + "==" + version
+	}
+	return p.Name + "@" + version
+}
+
+// isGitSource reports whether a uvx spec installs from a git repository
+func isGitSource(spec string) bool {
+	return strings.HasPrefix(spec, "git+")
+}
+
+// findMCPPackage returns the registry package a server command runs, if any. Local
+// paths and other URLs are not packages.
+func findMCPPackage(command string, args []string) (MCPPackage, bool) {
+	switch strings.TrimSuffix(filepath.Base(command), filepath.Ext(command)) {
+	case "npx":
+		for i := 0; i < len(args); i++ {
+			switch arg := args[i]; {
+			case arg == "-p" || arg == "--package":
+				if i+1 < len(args) {
+					return parseNPMPackage(args[i+1], i+1)
+				}
+				return MCPPackage{}, false
+			case strings.HasPrefix(arg, "-"):
+				continue
+			default:
+				return parseNPMPackage(arg, i)
+			}
+		}
+	case "uvx":
+		for i := 0; i < len(args); i++ {
+			switch arg := args[i]; {
+			case arg == "--from":
+				if i+1 < len(args) {
+					return parseUVXPackage(args[i+1], i+1, true)
+				}
+				return MCPPackage{}, false
+			case uvxValueFlags[arg]:
+				i++
+			case strings.HasPrefix(arg, "-"):
+				continue
+			default:
+				return parseUVXPackage(arg, i, false)
+			}
+		}
+	}
+	return MCPPackage{}, false
+}
+
+// parseNPMPackage splits name@version, keeping the @ of a scoped name
+func parseNPMPackage(spec string, arg int) (MCPPackage, bool) {
+	if strings.ContainsAny(spec, ":\\") || strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") {
+		return MCPPackage{}, false
+	}
+	pkg := MCPPackage{Manager: MCPPackageNPM, Name: spec, arg: arg}
+	if at := strings.LastIndex(spec, "@"); at > 0 {
+		pkg.Name, pkg.Version = spec[:at], spec[at+1:]
+	}
+	return pkg, true
+}
+
+// parseUVXPackage splits a git+https URL from its @ref, or a PyPI name from its
+// ==version or @version. Other version specifiers are not exact and are dropped.
+func parseUVXPackage(spec string, arg int, from bool) (MCPPackage, bool) {
+	pkg := MCPPackage{Manager: MCPPackageUVX, Name: spec, arg: arg, from: from}
+	if isGitSource(spec) {
+		path := strings.LastIndex(spec, "/")
+		if at := strings.Index(spec[path+1:], "@"); path >= 0 && at >= 0 {
+			pkg.Name, pkg.Version = spec[:path+1+at], spec[path+2+at:]
+		}
+		return pkg, true
+	}
+	if strings.ContainsAny(spec, ":/\\") || strings.HasPrefix(spec, ".") {
+		return MCPPackage{}, false
+	}
+
+	if name, version, found := strings.Cut(spec, "=="); found {
+		pkg.Name, pkg.Version = name, version
+	} else if name, version, found := strings.Cut(spec, "@"); found {
+		pkg.Name, pkg.Version = name, version
+	} else if end := strings.IndexAny(spec, "<>=!~;"); end > 0 {
+		pkg.Name = spec[:end]
+	}
+	pkg.Name = strings.TrimSpace(pkg.Name)
+	return pkg, true
+}
+
+// pinnedArgs returns args with the package spec at version
+func pinnedArgs(args []string, pkg MCPPackage, version string) (json.RawMessage, error) {
+	pinned := slices.Clone(args)
+	pinned[pkg.arg] = pkg.spec(version)
+	return json.Marshal(pinned)
+}
+
+// pinMCPServer rewrites an unpinned package spec in a server definition to the
+// version from versions. Pinned specs and packages without a known version are
+// left as they are.
+func pinMCPServer(key string, raw json.RawMessage, versions MCPVersions) (json.RawMessage, error) {
+	var definition MCPServerConfig
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+	}
+	pkg, ok := findMCPPackage(definition.Command, definition.Args)
+	if !ok || pkg.Pinned() {
+		return raw, nil
+	}
+	version, ok := versions.lookup(pkg)
+	if !ok {
+		fmt.Printf("⚠️  No pinned version for %s (%s); leaving it unpinned\n", key, pkg)
+		return raw, nil
+	}
+
+	args, err := pinnedArgs(definition.Args, pkg, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin %s: %w", key, err)
+	}
+	pinned, err := jsonedit.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+	}
+	if err := pinned.Set([]string{"args"}, args); err != nil {
+		return nil, fmt.Errorf("failed to pin %s: %w", key, err)
+	}
+	fmt.Printf("📌 Pinned %s to %s\n", key, pkg.spec(version))
+	return json.RawMessage(pinned.Bytes()), nil
+}
+
+// pinMCPServers returns copies of the selected servers with their package specs
+// pinned, carrying the pinned config and the original instructions file
+func pinMCPServers(selected []MCPServer, repoPath string, versions MCPVersions) ([]MCPServer, error) {
+	pinned := make([]MCPServer, 0, len(selected))
+	for _, server := range selected {
+		serverConfig, err := server.LoadConfigRaw(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MCP config for %s: %w", server.Name, err)
+		}
+
+		definitions := make(map[string]json.RawMessage, len(serverConfig))
+		for key, raw := range serverConfig {
+			if definitions[key], err = pinMCPServer(key, raw, versions); err != nil {
+				return nil, err
+			}
+		}
+
+		server.DocPath = server.docSource(repoPath)
+		server.Config = definitions
+		pinned = append(pinned, server)
+	}
+	return pinned, nil
+}
+
+// MCPPinStatus is the package a configured server runs and the version the versions
+// files pin it to
+type MCPPinStatus struct {
+	Server    string
+	Package   MCPPackage
+	Available string // Version from the versions files; empty when unknown
+}
+
+// CheckMCPPins returns the package of each stdio server in .mcp.json that runs one,
+// in file order
+func CheckMCPPins(targetDir string, versions MCPVersions) ([]MCPPinStatus, error) {
+	mcpConfig, _, err := readMCPConfig(filepath.Join(targetDir, config.MCPConfigFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var pins []MCPPinStatus
+	for _, key := range mcpConfig.Keys() {
+		definition := mcpConfig.MCPServers[key]
+		if definition.Transport() != MCPTransportStdio {
+			continue
+		}
+		pkg, ok := findMCPPackage(definition.Command, definition.Args)
+		if !ok {
+			continue
+		}
+		available, _ := versions.lookup(pkg)
+		pins = append(pins, MCPPinStatus{Server: key, Package: pkg, Available: available})
+	}
+	return pins, nil
+}
+
+// ErrMCPPinBumpDeclined is returned by UpdateMCPPins when the bumps are not confirmed
+var ErrMCPPinBumpDeclined = errors.New("MCP pin bump declined")
+
+// promptMCPPinBump asks whether to apply the listed pin bumps; overridden in tests
+var promptMCPPinBump = func(count int) (bool, error) {
+	return PromptConfirm(fmt.Sprintf("Bump %d pin(s) in .mcp.json?", count), "use --yes")
+}
+
+// UpdateMCPPins offers to bump pinned servers in .mcp.json to newer versions from the
+// versions files, limited to names when given, and returns the servers bumped. Pins
+// newer than the versions files, pins that can't be ordered against them such as commit
+// SHAs, and unpinned servers are left alone. The confirmation follows --yes and
+// --no-input; declining it returns ErrMCPPinBumpDeclined.
+func UpdateMCPPins(targetDir string, names []string, versions MCPVersions) ([]MCPPinStatus, error) {
+	pins, err := CheckMCPPins(targetDir, versions)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(pins, func(pin MCPPinStatus) bool { return pin.Server == name }) {
+			return nil, fmt.Errorf("%s is not an npx or uvx server in .mcp.json", name)
+		}
+	}
+
+	var bumps []MCPPinStatus
+	for _, pin := range pins {
+		if len(names) > 0 && !slices.Contains(names, pin.Server) {
+			continue
+		}
+		if !pin.Package.Pinned() || pin.Available == "" || pin.Available == pin.Package.Version {
+			continue
+		}
+		if !orderableVersions(pin.Available, pin.Package.Version) {
+			fmt.Printf("  %s: %s %s has unknown order against %s in the versions files, left alone\n", pin.Server, pin.Package.Name, pin.Package.Version, pin.Available)
+			continue
+		}
+		if !isNewerVersion(pin.Available, pin.Package.Version) {
+			fmt.Printf("  %s: keeping %s %s, newer than %s in the versions files\n", pin.Server, pin.Package.Name, pin.Package.Version, pin.Available)
+			continue
+		}
+		bumps = append(bumps, pin)
+	}
+	if len(bumps) == 0 {
+		return nil, nil
+	}
+
+	for _, bump := range bumps {
+		fmt.Printf("  %s: %s %s → %s\n", bump.Server, bump.Package.Name, bump.Package.Version, bump.Available)
+	}
+	confirmed, err := promptMCPPinBump(len(bumps))
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return nil, ErrMCPPinBumpDeclined
+	}
+
+	mcpPath := filepath.Join(targetDir, config.MCPConfigFile)
+	data, err := os.ReadFile(mcpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .mcp.json: %w", err)
+	}
+	doc, problems := parseMCPDocument(data, config.MCPConfigFile)
+	if problems != nil {
+		return nil, fmt.Errorf("failed to parse .mcp.json: %w", problems)
+	}
+	mcpConfig, _, err := readMCPConfig(mcpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bump := range bumps {
+		args, err := pinnedArgs(mcpConfig.MCPServers[bump.Server].Args, bump.Package, bump.Available)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bump %s: %w", bump.Server, err)
+		}
+		if err := doc.Set([]string{"mcpServers", bump.Server, "args"}, args); err != nil {
+			return nil, fmt.Errorf("failed to bump %s: %w", bump.Server, err)
+		}
+	}
+
+	if err := os.WriteFile(mcpPath, doc.Bytes(), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write .mcp.json: %w", err)
+	}
+	return bumps, nil
+}
+
+// pinVersionPattern matches the pinned versions that can be ordered: X.Y or X.Y.Z,
+// optionally prefixed with v
+var pinVersionPattern = regexp.MustCompile(`^v?\d+\.\d+(?:\.\d+)?$`)
+
+// orderableVersions reports whether both pinned versions can be compared. Other refs,
+// such as commit SHAs and branches, have no known order.
+func orderableVersions(available, current string) bool {
+	return pinVersionPattern.MatchString(available) && pinVersionPattern.MatchString(current)
+}
+
+// isNewerVersion reports whether available is newer than current. Versions that can't
+// be ordered never count as newer, so a bump can't turn into a downgrade.
+func isNewerVersion(available, current string) bool {
+	return orderableVersions(available, current) && compareVersions(available, current) > 0
+}
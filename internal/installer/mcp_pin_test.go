@@ -0,0 +1,190 @@
+package installer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFindMCPPackage validates that package specs are found in npx and uvx args and
+// classified as pinned or not
+func TestFindMCPPackage(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		found   bool
+		pkg     string
+		version string
+		pinned  bool
+	}{
+		{"NPMLatest", "npx", []string{"-y", "@upstash/context7-mcp@latest"}, true, "@upstash/context7-mcp", "latest", false},
+		{"NPMUnversioned", "npx", []string{"-y", "@modelcontextprotocol/server-sequential-thinking"}, true, "@modelcontextprotocol/server-sequential-thinking", "", false},
+		{"NPMExact", "npx", []string{"@playwright/mcp@0.0.32", "--headless"}, true, "@playwright/mcp", "0.0.32", true},
+		{"NPMRange", "npx", []string{"-y", "tool@^1.2.0"}, true, "tool", "^1.2.0", false},
+		{"NPMPackageFlag", "npx", []string{"-p", "tool@1.2.3", "tool-server"}, true, "tool", "1.2.3", true},
+		{"NPMLocal", "npx", []string{"./server.js"}, false, "", "", false},
+		{"GitUnpinned", "uvx", []string{"--from", "git+https://github.com/oraios/serena", "serena", "start-mcp-server"}, true, "git+https://github.com/oraios/serena", "", false},
+		{"GitTag", "uvx", []string{"--from", "git+https://github.com/oraios/serena@v0.1.4", "serena"}, true, "git+https://github.com/oraios/serena", "v0.1.4", true},
+		{"GitBranch", "uvx", []string{"--from", "git+https://github.com/oraios/serena@main", "serena"}, true, "git+https://github.com/oraios/serena", "main", false},
+		{"PyPIExact", "uvx", []string{"--python", "3.12", "--from", "mcp-server-fetch==2025.4.7", "mcp-server-fetch"}, true, "mcp-server-fetch", "2025.4.7", true},
+		{"PyPIPositional", "uvx", []string{"mcp-server-git@latest"}, true, "mcp-server-git", "latest", false},
+		{"PyPIRange", "uvx", []string{"mcp-server-time>=1.0"}, true, "mcp-server-time", "", false},
+		{"OtherCommand", "node", []string{"server.js"}, false, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, found := findMCPPackage(tt.command, tt.args)
+			if found != tt.found {
+				t.Fatalf("Expected found=%v, got %v (%+v)", tt.found, found, pkg)
+			}
+			if !found {
+				return
+			}
+			if pkg.Name != tt.pkg || pkg.Version != tt.version || pkg.Pinned() != tt.pinned {
+				t.Errorf("Expected %s %q pinned=%v, got %s %q pinned=%v", tt.pkg, tt.version, tt.pinned, pkg.Name, pkg.Version, pkg.Pinned())
+			}
+		})
+	}
+}
+
+// TestLoadMCPVersions validates that user and project versions files override the
+// built-in versions and that inexact versions are rejected
+func TestLoadMCPVersions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	targetDir := t.TempDir()
+
+	writeTestFiles(t, home, map[string]string{
+		".config/super-claude-lite/mcp-versions.json": `{"npm": {"@upstash/context7-mcp": "1.0.20", "@playwright/mcp": "0.0.40"}}`,
+	})
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude-lite/mcp-versions.json": `{"npm": {"@upstash/context7-mcp": "1.0.21"}, "uvx": {"git+https://github.com/oraios/serena": "0123abcd"}}`,
+		"pins.json":                           `{"npm": {"@playwright/mcp": "0.0.41"}}`,
+	})
+
+	versions, err := LoadMCPVersions(targetDir, filepath.Join(targetDir, "pins.json"))
+	if err != nil {
+		t.Fatalf("LoadMCPVersions failed: %v", err)
+	}
+	expected := map[string]string{
+		"@upstash/context7-mcp": "1.0.21",
+		"@playwright/mcp":       "0.0.41",
+		"@21st-dev/magic-mcp":   "0.1.0",
+	}
+	for name, version := range expected {
+		if versions.NPM[name] != version {
+			t.Errorf("Expected %s %s, got %q", name, version, versions.NPM[name])
+		}
+	}
+	if got := versions.UVX["git+https://github.com/oraios/serena"]; got != "0123abcd" {
+		t.Errorf("Expected the project's serena commit, got %q", got)
+	}
+
+	writeTestFiles(t, targetDir, map[string]string{"latest.json": `{"npm": {"@upstash/context7-mcp": "latest"}}`})
+	_, err = LoadMCPVersions(targetDir, filepath.Join(targetDir, "latest.json"))
+	if err == nil || !strings.Contains(err.Error(), `"latest" is not an exact version`) {
+		t.Errorf("Expected an inexact version to be rejected, got %v", err)
+	}
+}
+
+// TestPinMCPServers validates that --pin-mcp rewrites unpinned specs in .mcp.json and
+// that UpdateMCPPins bumps pins after confirmation
+func TestPinMCPServers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	repoPath := createTestFrameworkRepo(t)
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+	targetDir := t.TempDir()
+	mcpPath := filepath.Join(targetDir, ".mcp.json")
+
+	versions, err := LoadMCPVersions(targetDir, "")
+	if err != nil {
+		t.Fatalf("LoadMCPVersions failed: %v", err)
+	}
+	pinned, err := pinMCPServers(servers, repoPath, versions)
+	if err != nil {
+		t.Fatalf("pinMCPServers failed: %v", err)
+	}
+	if pinned[0].DocPath == "" || servers[0].Config != nil {
+		t.Errorf("Expected pinned copies to keep their docs, got %+v", pinned[0])
+	}
+	if _, err := createMCPConfigWithSelected(mcpPath, pinned, repoPath); err != nil {
+		t.Fatalf("createMCPConfigWithSelected failed: %v", err)
+	}
+
+	data, err := os.ReadFile(mcpPath)
+	if err != nil {
+		t.Fatalf("Failed to read .mcp.json: %v", err)
+	}
+	for _, spec := range []string{`"@upstash/context7-mcp@1.0.14"`, `"git+https://github.com/oraios/serena@v0.1.4"`} {
+		if !strings.Contains(string(data), spec) {
+			t.Errorf("Expected %s in .mcp.json, got:\n%s", spec, data)
+		}
+	}
+
+	pins, err := CheckMCPPins(targetDir, versions)
+	if err != nil {
+		t.Fatalf("CheckMCPPins failed: %v", err)
+	}
+	for _, pin := range pins {
+		if !pin.Package.Pinned() {
+			t.Errorf("Expected %s to be pinned, got %s", pin.Server, pin.Package)
+		}
+	}
+
+	originalPrompt := promptMCPPinBump
+	defer func() { promptMCPPinBump = originalPrompt }()
+	confirm := false
+	promptMCPPinBump = func(int) (bool, error) { return confirm, nil }
+
+	versions.NPM["@upstash/context7-mcp"] = "1.0.15"
+	if bumped, err := UpdateMCPPins(targetDir, nil, versions); !errors.Is(err, ErrMCPPinBumpDeclined) || len(bumped) != 0 {
+		t.Errorf("Expected a declined bump to change nothing, got %+v (%v)", bumped, err)
+	}
+
+	confirm = true
+	bumped, err := UpdateMCPPins(targetDir, nil, versions)
+	if err != nil {
+		t.Fatalf("UpdateMCPPins failed: %v", err)
+	}
+	if len(bumped) != 1 || bumped[0].Server != "context7" || bumped[0].Available != "1.0.15" {
+		t.Errorf("Expected context7 to be bumped, got %+v", bumped)
+	}
+	data, err = os.ReadFile(mcpPath)
+	if err != nil || !strings.Contains(string(data), `"@upstash/context7-mcp@1.0.15"`) {
+		t.Errorf("Expected the bumped pin in .mcp.json, got %s (%v)", data, err)
+	}
+
+	versions.NPM["@upstash/context7-mcp"] = "1.0.14"
+	if bumped, err := UpdateMCPPins(targetDir, nil, versions); err != nil || len(bumped) != 0 {
+		t.Errorf("Expected a pin newer than the versions files to be kept, got %+v (%v)", bumped, err)
+	}
+	if data, _ := os.ReadFile(mcpPath); !strings.Contains(string(data), `"@upstash/context7-mcp@1.0.15"`) {
+		t.Errorf("Expected the newer pin to stay in .mcp.json, got:\n%s", data)
+	}
+
+	// A commit SHA has no order against v0.1.4, so it is never bumped
+	data = []byte(strings.Replace(string(data), "serena@v0.1.4", "serena@3f2a9c1", 1))
+	if err := os.WriteFile(mcpPath, data, 0o600); err != nil {
+		t.Fatalf("Failed to write .mcp.json: %v", err)
+	}
+	if bumped, err := UpdateMCPPins(targetDir, []string{"serena"}, versions); err != nil || len(bumped) != 0 {
+		t.Errorf("Expected a SHA pin to be left alone, got %+v (%v)", bumped, err)
+	}
+	if data, _ := os.ReadFile(mcpPath); !strings.Contains(string(data), `"git+https://github.com/oraios/serena@3f2a9c1"`) {
+		t.Errorf("Expected the SHA pin to stay in .mcp.json, got:\n%s", data)
+	}
+
+	if _, err := UpdateMCPPins(targetDir, []string{"missing"}, versions); err == nil {
+		t.Errorf("Expected an error for a server that is not configured")
+	}
+}
@@ -0,0 +1,213 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// MCP server sources, from lowest to highest precedence. Built-in servers stand in
+// for the framework's when its checkout has no MCP directory.
+const (
+	MCPSourceFramework = "framework"
+	MCPSourceBuiltin   = "built-in"
+	MCPSourceUser      = "user"
+	MCPSourceProject   = "project"
+)
+
+// MCPSourceLabel returns the origin shown for a server: upstream for the framework's,
+// else its source
+func MCPSourceLabel(source string) string {
+	if source == MCPSourceFramework {
+		return "upstream"
+	}
+	return source
+}
+
+// mcpDocNamePattern matches characters that cannot appear in a generated MCP_*.md name
+var mcpDocNamePattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// mcpRegistry is the format of a mcp-servers.json registry file
+type mcpRegistry struct {
+	Servers []mcpRegistryEntry `json:"servers"`
+}
+
+// mcpRegistryEntry defines one server offered in addition to the framework's
+type mcpRegistryEntry struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Config       json.RawMessage `json:"config"`       // .mcp.json entries by key, or a single definition stored under Name
+	Instructions string          `json:"instructions"` // Optional markdown, relative to the registry file
+}
+
+// DiscoverAllMCPServers returns the framework's servers merged with those from the
+// user and project registries. A registry server replaces a framework or user server
+// with the same name.
+func DiscoverAllMCPServers(repoPath, targetDir string) ([]MCPServer, error) {
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := registryMCPServers(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMCPServers(servers, registered), nil
+}
+
+// registryMCPServers returns the servers of the user and project registries, a project
+// server replacing a user server with the same name
+func registryMCPServers(targetDir string) ([]MCPServer, error) {
+	var servers []MCPServer
+	for _, path := range mcpRegistryPaths(targetDir) {
+		registered, err := loadMCPRegistry(path.file, path.source)
+		if err != nil {
+			return nil, err
+		}
+		servers = mergeMCPServers(servers, registered)
+	}
+	return servers, nil
+}
+
+// mcpRegistryPath is a registry file and the source its servers are labelled with
+type mcpRegistryPath struct {
+	file   string
+	source string
+}
+
+// mcpRegistryPaths returns the registry files in precedence order: the user's
+// (~/.config/super-claude-lite/mcp-servers.json) then the project's
+// (.superclaude-lite/mcp-servers.json)
+func mcpRegistryPaths(targetDir string) []mcpRegistryPath {
+	var paths []mcpRegistryPath
+	if userDir, err := UserConfigDir(); err == nil {
+		paths = append(paths, mcpRegistryPath{file: filepath.Join(userDir, config.MCPRegistryFile), source: MCPSourceUser})
+	}
+	return append(paths, mcpRegistryPath{file: filepath.Join(ProjectConfigDir(targetDir), config.MCPRegistryFile), source: MCPSourceProject})
+}
+
+// loadMCPRegistry reads a registry file; a missing file defines no servers
+func loadMCPRegistry(path, source string) ([]MCPServer, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP registry %s: %w", path, err)
+	}
+
+	var registry mcpRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP registry %s: %w", path, err)
+	}
+
+	servers := make([]MCPServer, 0, len(registry.Servers))
+	for i, entry := range registry.Servers {
+		server, err := entry.server(filepath.Dir(path), source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server #%d in MCP registry %s: %w", i+1, path, err)
+		}
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+// server converts a registry entry, resolving its instructions relative to dir
+func (e mcpRegistryEntry) server(dir, source string) (MCPServer, error) {
+	name := strings.TrimSpace(e.Name)
+	if name == "" {
+		return MCPServer{}, fmt.Errorf("name is required")
+	}
+
+	serverConfig, err := registryConfig(name, e.Config)
+	if err != nil {
+		return MCPServer{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	server := MCPServer{
+		Name:        name,
+		DisplayName: e.Description,
+		Description: e.Description,
+		Source:      source,
+		Config:      serverConfig,
+	}
+	if server.DisplayName == "" {
+		server.DisplayName = fmt.Sprintf("%s MCP integration", name)
+	}
+
+	if e.Instructions != "" {
+		docPath := e.Instructions
+		if !filepath.IsAbs(docPath) {
+			docPath = filepath.Join(dir, docPath)
+		}
+		if !fileExists(docPath) {
+			return MCPServer{}, fmt.Errorf("%s: instructions file not found: %s", name, docPath)
+		}
+		server.DocPath = docPath
+		server.MDFile = registryDocName(name, docPath)
+	}
+
+	return server.describe(""), nil
+}
+
+// registryConfig accepts either .mcp.json entries by key or a single server definition,
+// recognized by its command, url or type field, which is stored under the server name
+func registryConfig(name string, raw json.RawMessage) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || len(fields) == 0 {
+		return nil, fmt.Errorf("config must be a non-empty object")
+	}
+
+	entries := fields
+	for _, field := range []string{"command", "url", "type"} {
+		if _, ok := fields[field]; ok {
+			entries = map[string]json.RawMessage{strings.ToLower(name): raw}
+			break
+		}
+	}
+	if err := validateMCPServerConfigs(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// registryDocName returns the file name the instructions are installed under in
+// .superclaude/MCP, keeping MCP_*.md names and generating one otherwise
+func registryDocName(name, docPath string) string {
+	base := filepath.Base(docPath)
+	if strings.HasPrefix(base, "MCP_") && strings.HasSuffix(base, ".md") {
+		return base
+	}
+	return "MCP_" + strings.Trim(mcpDocNamePattern.ReplaceAllString(name, "_"), "_") + ".md"
+}
+
+// mergeMCPServers adds overrides to servers, replacing entries with the same
+// case-insensitive name, and sorts the result by name
+func mergeMCPServers(servers, overrides []MCPServer) []MCPServer {
+	for _, override := range overrides {
+		replaced := false
+		for i := range servers {
+			if strings.EqualFold(servers[i].Name, override.Name) {
+				servers[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			servers = append(servers, override)
+		}
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+	return servers
+}
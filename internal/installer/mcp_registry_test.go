@@ -0,0 +1,144 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMCPRegistries validates that user and project registries are merged with the
+// framework's servers and installed like them
+func TestMCPRegistries(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	targetDir := t.TempDir()
+	userDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+
+	writeTestFiles(t, userDir, map[string]string{
+		"super-claude-lite/mcp-servers.json": `{"servers": [
+			{"name": "Tracker", "description": "Issue tracker", "config": {"command": "tracker-mcp", "args": ["--stdio"]}},
+			{"name": "Acme", "config": {"acme": {"command": "old-acme"}}}
+		]}`,
+	})
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude/CLAUDE.md":         "# SuperClaude Entry Point\n",
+		".superclaude-lite/docs/acme.md": "# Acme\nInternal search.\n",
+		".superclaude-lite/mcp-servers.json": `{"servers": [
+			{"name": "Acme", "description": "Acme internal search", "instructions": "docs/acme.md",
+			 "config": {"acme": {"command": "acme-mcp"}, "acme-admin": {"command": "acme-mcp", "args": ["--admin"]}}}
+		]}`,
+	})
+
+	servers, err := DiscoverAllMCPServers(repoPath, targetDir)
+	if err != nil {
+		t.Fatalf("DiscoverAllMCPServers failed: %v", err)
+	}
+
+	type summary struct{ Name, DisplayName, MDFile, Source string }
+	var got []summary
+	for _, server := range servers {
+		got = append(got, summary{server.Name, server.DisplayName, server.MDFile, server.Source})
+	}
+	expected := []summary{
+		{"Acme", "Acme internal search", "MCP_Acme.md", MCPSourceProject},
+		{"Context7", "Context7 MCP integration", "MCP_Context7.md", MCPSourceFramework},
+		{"Serena", "Serena MCP integration", "MCP_Serena.md", MCPSourceFramework},
+		{"Tracker", "Issue tracker", "", MCPSourceUser},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, got)
+	}
+
+	manager, err := NewMCPManager(targetDir, repoPath)
+	if err != nil {
+		t.Fatalf("NewMCPManager failed: %v", err)
+	}
+	if _, err := manager.Add([]string{"acme", "tracker", "context7"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	keys, err := readConfiguredMCPServers(filepath.Join(targetDir, ".mcp.json"))
+	if err != nil {
+		t.Fatalf("Failed to read .mcp.json: %v", err)
+	}
+	if expectedKeys := []string{"acme", "acme-admin", "context7", "tracker"}; !reflect.DeepEqual(keys, expectedKeys) {
+		t.Errorf("Expected .mcp.json servers %v, got %v", expectedKeys, keys)
+	}
+
+	doc, err := os.ReadFile(filepath.Join(targetDir, ".superclaude", "MCP", "MCP_Acme.md"))
+	if err != nil || !strings.Contains(string(doc), "Internal search") {
+		t.Errorf("Expected the registry instructions to be copied, got %q (%v)", doc, err)
+	}
+
+	imports, err := readMCPImports(filepath.Join(targetDir, ".superclaude", "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("readMCPImports failed: %v", err)
+	}
+	if expectedImports := []string{"MCP_Acme.md", "MCP_Context7.md"}; !reflect.DeepEqual(imports, expectedImports) {
+		t.Errorf("Expected imports %v, got %v", expectedImports, imports)
+	}
+
+	t.Run("InvalidRegistry", func(t *testing.T) {
+		writeTestFiles(t, targetDir, map[string]string{
+			".superclaude-lite/mcp-servers.json": `{"servers": [{"name": "Broken", "config": {}, "instructions": "missing.md"}]}`,
+		})
+		_, err := DiscoverAllMCPServers(repoPath, targetDir)
+		if err == nil || !strings.Contains(err.Error(), "invalid server #1") {
+			t.Errorf("Expected an invalid registry error, got %v", err)
+		}
+	})
+}
+
+// TestBuiltinMCPCatalog validates that a framework checkout without SuperClaude/MCP
+// offers the built-in servers, labelled by origin, and installs them from their config
+func TestBuiltinMCPCatalog(t *testing.T) {
+	repoPath := t.TempDir()
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+
+	var names []string
+	for _, server := range servers {
+		names = append(names, server.Name)
+		if server.Source != MCPSourceBuiltin || server.MDFile != "" || server.DisplayName == "" {
+			t.Errorf("Expected a described built-in server without docs, got %+v", server)
+		}
+		for key, raw := range server.Config {
+			data := []byte(`{"mcpServers": {"` + key + `": ` + string(raw) + `}}`)
+			if problems := ValidateMCPConfig(data, "catalog"); len(problems) > 0 {
+				t.Errorf("Expected a valid config for %s, got %v", key, problems)
+			}
+		}
+	}
+	expected := []string{"Context7", "Magic", "Morphllm", "Playwright", "Sequential", "Serena"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	selected, err := ResolveMCPServers(servers, []string{"sequential", "serena"})
+	if err != nil {
+		t.Fatalf("ResolveMCPServers failed: %v", err)
+	}
+	mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+	if _, err := createMCPConfigWithSelected(mcpPath, selected, repoPath); err != nil {
+		t.Fatalf("createMCPConfigWithSelected failed: %v", err)
+	}
+	configured, err := readConfiguredMCPServers(mcpPath)
+	if err != nil {
+		t.Fatalf("readConfiguredMCPServers failed: %v", err)
+	}
+	if !reflect.DeepEqual(configured, []string{"sequential-thinking", "serena"}) {
+		t.Errorf("Expected the catalog keys in .mcp.json, got %v", configured)
+	}
+
+	framework := MCPServer{Name: "Context7", DisplayName: "Context7 MCP integration", Source: MCPSourceFramework}
+	view := NewMCPSelector([]MCPServer{framework, servers[1]}, repoPath).View()
+	for _, label := range []string{"Context7 MCP integration (upstream)", "(built-in)"} {
+		if !strings.Contains(view, label) {
+			t.Errorf("Expected %q in the selector, got:\n%s", label, view)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrorBody is how much of an unexpected HTTP response body is quoted in errors
+const maxErrorBody = 512
+
+// sseEvent is one server-sent event
+type sseEvent struct {
+	Name string
+	Data string
+}
+
+// readSSE parses server-sent events from r and passes each to fn until fn returns
+// false or the stream ends
+func readSSE(r io.Reader, fn func(sseEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var event sseEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if !fn(event) {
+					return nil
+				}
+			}
+			event, data = sseEvent{}, nil
+		case strings.HasPrefix(line, ":"):
+			// Comment, used as keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event.Name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
+
+// postMCPMessage sends a JSON-RPC message to a remote server
+func postMCPMessage(ctx context.Context, endpoint string, headers map[string]string, message mcpMessage) (*http.Response, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json, text/event-stream")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer func() { _ = response.Body.Close() }()
+		return nil, httpStatusError(response)
+	}
+	return response, nil
+}
+
+// httpStatusError describes an unexpected response with the start of its body
+func httpStatusError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return fmt.Errorf("%s: %s", response.Status, text)
+	}
+	return fmt.Errorf("%s", response.Status)
+}
+
+// mcpHTTPConn speaks the streamable HTTP transport: each message is POSTed to the
+// server url, which answers with JSON or an event stream
+type mcpHTTPConn struct {
+	ctx             context.Context
+	url             string
+	headers         map[string]string
+	sessionID       string
+	protocolVersion string
+	nextID          int
+}
+
+func newMCPHTTPConn(ctx context.Context, serverURL string, headers map[string]string) *mcpHTTPConn {
+	return &mcpHTTPConn{ctx: ctx, url: serverURL, headers: headers}
+}
+
+// requestHeaders returns the configured headers plus the session headers
+func (c *mcpHTTPConn) requestHeaders() map[string]string {
+	headers := make(map[string]string, len(c.headers)+2)
+	for name, value := range c.headers {
+		headers[name] = value
+	}
+	if c.sessionID != "" {
+		headers["Mcp-Session-Id"] = c.sessionID
+	}
+	if c.protocolVersion != "" {
+		headers["MCP-Protocol-Version"] = c.protocolVersion
+	}
+	return headers
+}
+
+func (c *mcpHTTPConn) call(method string, params, result interface{}) error {
+	c.nextID++
+	id := json.RawMessage(fmt.Sprint(c.nextID))
+	response, err := postMCPMessage(c.ctx, c.url, c.requestHeaders(), mcpMessage{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if sessionID := response.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		c.sessionID = sessionID
+	}
+
+	var answer *mcpMessage
+	if strings.HasPrefix(response.Header.Get("Content-Type"), "text/event-stream") {
+		err = readSSE(response.Body, func(event sseEvent) bool {
+			var message mcpMessage
+			if json.Unmarshal([]byte(event.Data), &message) == nil && message.Method == "" && bytes.Equal(message.ID, id) {
+				answer = &message
+				return false
+			}
+			return true
+		})
+	} else {
+		answer = &mcpMessage{}
+		err = json.NewDecoder(response.Body).Decode(answer)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if answer == nil {
+		return fmt.Errorf("server closed the stream before answering %s", method)
+	}
+
+	done, err := answer.responseTo(id, method, result)
+	if !done {
+		return fmt.Errorf("unexpected response to %s", method)
+	}
+	if err == nil && method == "initialize" {
+		var initialized struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(answer.Result, &initialized)
+		c.protocolVersion = initialized.ProtocolVersion
+	}
+	return err
+}
+
+func (c *mcpHTTPConn) notify(method string) error {
+	response, err := postMCPMessage(c.ctx, c.url, c.requestHeaders(), mcpMessage{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	return response.Body.Close()
+}
+
+// close ends the session on the server, if it started one
+func (c *mcpHTTPConn) close() {
+	if c.sessionID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.url, nil)
+	if err != nil {
+		return
+	}
+	for name, value := range c.requestHeaders() {
+		request.Header.Set(name, value)
+	}
+	if response, err := http.DefaultClient.Do(request); err == nil {
+		_ = response.Body.Close()
+	}
+}
+
+// mcpSSEConn speaks the legacy SSE transport: responses arrive on an event stream
+// opened with GET, requests are POSTed to the endpoint the stream announces
+type mcpSSEConn struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	endpoint string
+	headers  map[string]string
+	events   chan sseEvent
+	nextID   int
+}
+
+// dialMCPSSE opens the event stream and waits for the endpoint event
+func dialMCPSSE(ctx context.Context, serverURL string, headers map[string]string) (*mcpSSEConn, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	request, err := http.NewRequestWithContext(streamCtx, http.MethodGet, serverURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer func() { _ = response.Body.Close() }()
+		cancel()
+		return nil, httpStatusError(response)
+	}
+
+	conn := &mcpSSEConn{ctx: streamCtx, cancel: cancel, headers: headers, events: make(chan sseEvent)}
+	go func() {
+		defer close(conn.events)
+		defer func() { _ = response.Body.Close() }()
+		_ = readSSE(response.Body, func(event sseEvent) bool {
+			select {
+			case conn.events <- event:
+				return true
+			case <-streamCtx.Done():
+				return false
+			}
+		})
+	}()
+
+	for event := range conn.events {
+		if event.Name != "endpoint" {
+			continue
+		}
+		endpoint, err := resolveEndpoint(serverURL, event.Data)
+		if err != nil {
+			conn.close()
+			return nil, err
+		}
+		conn.endpoint = endpoint
+		return conn, nil
+	}
+
+	conn.close()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("event stream closed before announcing an endpoint")
+}
+
+// resolveEndpoint resolves the announced endpoint against the stream url
+func resolveEndpoint(serverURL, endpoint string) (string, error) {
+	base, err := url.Parse(serverURL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func (c *mcpSSEConn) post(message mcpMessage) error {
+	response, err := postMCPMessage(c.ctx, c.endpoint, c.headers, message)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+func (c *mcpSSEConn) call(method string, params, result interface{}) error {
+	c.nextID++
+	id := json.RawMessage(fmt.Sprint(c.nextID))
+	if err := c.post(mcpMessage{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+
+	for event := range c.events {
+		var message mcpMessage
+		if event.Name != "" && event.Name != "message" || json.Unmarshal([]byte(event.Data), &message) != nil {
+			continue
+		}
+		if message.Method != "" && message.ID != nil {
+			if err := c.post(replyTo(message)); err != nil {
+				return err
+			}
+			continue
+		}
+		if done, err := message.responseTo(id, method, result); done {
+			return err
+		}
+	}
+	return fmt.Errorf("event stream closed before answering %s", method)
+}
+
+func (c *mcpSSEConn) notify(method string) error {
+	return c.post(mcpMessage{JSONRPC: "2.0", Method: method})
+}
+
+func (c *mcpSSEConn) close() {
+	c.cancel()
+}
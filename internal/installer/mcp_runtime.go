@@ -0,0 +1,202 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// versionPattern finds the first dotted version number in `--version` output
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// lookPath resolves a command in PATH; overridden in tests
+var lookPath = exec.LookPath
+
+// toolVersion returns the `--version` output of a resolved tool; overridden in tests
+var toolVersion = func(path string) (string, error) {
+	output, err := exec.Command(path, "--version").Output() // #nosec G204 -- path comes from LookPath on a known runtime
+	return strings.TrimSpace(string(output)), err
+}
+
+// mcpRuntime is a tool an MCP server command depends on
+type mcpRuntime struct {
+	Tool       string
+	MinVersion string // Empty skips the version check
+	Hint       string
+}
+
+const (
+	nodeHint = "install Node.js 18 or newer from https://nodejs.org (includes npm and npx)"
+	uvHint   = "install uv from https://docs.astral.sh/uv/getting-started/installation/"
+	gitHint  = "install git from https://git-scm.com/downloads"
+)
+
+// knownMCPRuntimes maps server commands to the runtimes they need
+var knownMCPRuntimes = map[string][]mcpRuntime{
+	"npx":  {{Tool: "node", MinVersion: "18.0.0", Hint: nodeHint}, {Tool: "npx", MinVersion: "8.0.0", Hint: nodeHint}},
+	"npm":  {{Tool: "node", MinVersion: "18.0.0", Hint: nodeHint}, {Tool: "npm", MinVersion: "8.0.0", Hint: nodeHint}},
+	"node": {{Tool: "node", MinVersion: "18.0.0", Hint: nodeHint}},
+	"uvx":  {{Tool: "uv", MinVersion: "0.4.0", Hint: uvHint}, {Tool: "uvx", Hint: uvHint}},
+	"uv":   {{Tool: "uv", MinVersion: "0.4.0", Hint: uvHint}},
+}
+
+// MCPRuntimeIssue is a missing or outdated runtime needed by an MCP server
+type MCPRuntimeIssue struct {
+	Server  string // .mcp.json server key
+	Tool    string
+	Problem string
+	Hint    string
+}
+
+// String formats the issue for the summary and status output
+func (i MCPRuntimeIssue) String() string {
+	return fmt.Sprintf("%s: %s %s (%s)", i.Server, i.Tool, i.Problem, i.Hint)
+}
+
+// runtimeChecker resolves each tool once per check
+type runtimeChecker struct {
+	problems map[string]string
+}
+
+// CheckMCPRuntimes reports the runtimes the given server definitions need but that are
+// missing from PATH or older than the known minimum. Servers without a command, such as
+// remote servers, are skipped.
+func CheckMCPRuntimes(definitions map[string]json.RawMessage) []MCPRuntimeIssue {
+	keys := make([]string, 0, len(definitions))
+	for key := range definitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	checker := &runtimeChecker{problems: make(map[string]string)}
+	var issues []MCPRuntimeIssue
+	for _, key := range keys {
+		var definition struct {
+			Command string   `json:"command"`
+			Args    []string `json:"args"`
+		}
+		if err := json.Unmarshal(definitions[key], &definition); err != nil || definition.Command == "" {
+			continue
+		}
+
+		for _, runtime := range requiredRuntimes(definition.Command, definition.Args) {
+			if problem := checker.check(runtime); problem != "" {
+				issues = append(issues, MCPRuntimeIssue{Server: key, Tool: runtime.Tool, Problem: problem, Hint: runtime.Hint})
+			}
+		}
+	}
+
+	return issues
+}
+
+// CheckConfiguredMCPRuntimes checks the servers configured in a project's .mcp.json
+func CheckConfiguredMCPRuntimes(targetDir string) ([]MCPRuntimeIssue, error) {
+	mcpConfig, _, err := readMCPConfig(filepath.Join(targetDir, config.MCPConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	return CheckMCPRuntimes(mcpConfig.raw), nil
+}
+
+// checkSelectedMCPRuntimes checks the framework configs of the selected servers
+func checkSelectedMCPRuntimes(repoPath string, selected []MCPServer) ([]MCPRuntimeIssue, error) {
+	definitions := make(map[string]json.RawMessage)
+	for _, server := range selected {
+		serverConfig, err := server.LoadConfigRaw(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MCP config for %s: %w", server.Name, err)
+		}
+		for key, raw := range serverConfig {
+			definitions[key] = raw
+		}
+	}
+
+	return CheckMCPRuntimes(definitions), nil
+}
+
+// requiredRuntimes returns the tools a server command depends on. Unknown commands
+// only need to be in PATH; git+ package sources also need git.
+func requiredRuntimes(command string, args []string) []mcpRuntime {
+	name := strings.TrimSuffix(filepath.Base(command), filepath.Ext(command))
+	runtimes, ok := knownMCPRuntimes[name]
+	if !ok {
+		runtimes = []mcpRuntime{{Tool: command, Hint: "install it or fix the command in .mcp.json"}}
+	}
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "git+") {
+			runtimes = append(runtimes, mcpRuntime{Tool: "git", Hint: gitHint})
+			break
+		}
+	}
+
+	return runtimes
+}
+
+// check returns why a runtime is unusable, or "" if it is fine
+func (c *runtimeChecker) check(runtime mcpRuntime) string {
+	cacheKey := runtime.Tool + "@" + runtime.MinVersion
+	if problem, ok := c.problems[cacheKey]; ok {
+		return problem
+	}
+
+	problem := ""
+	if path, err := lookPath(runtime.Tool); err != nil {
+		problem = "not found in PATH"
+	} else if runtime.MinVersion != "" {
+		output, err := toolVersion(path)
+		version := versionPattern.FindString(output)
+		switch {
+		case err != nil || version == "":
+			problem = "version could not be determined"
+		case compareVersions(version, runtime.MinVersion) < 0:
+			problem = fmt.Sprintf("%s is older than the required %s", version, runtime.MinVersion)
+		}
+	}
+
+	c.problems[cacheKey] = problem
+	return problem
+}
+
+// compareVersions compares dotted versions numerically, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+	partsA, partsB := versionParts(a), versionParts(b)
+	for i := range partsA {
+		if partsA[i] != partsB[i] {
+			if partsA[i] < partsB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts returns major, minor and patch, treating missing parts as zero
+func versionParts(version string) [3]int {
+	var parts [3]int
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return parts
+	}
+	for i := range parts {
+		parts[i], _ = strconv.Atoi(match[i+1])
+	}
+	return parts
+}
+
+// formatRuntimeIssues formats issues for an error message, one per line
+func formatRuntimeIssues(issues []MCPRuntimeIssue) string {
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, "  - "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,125 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRuntimes makes lookPath and toolVersion answer from a map of tool versions
+func fakeRuntimes(t *testing.T, versions map[string]string) {
+	t.Helper()
+
+	originalLookPath, originalVersion := lookPath, toolVersion
+	t.Cleanup(func() { lookPath, toolVersion = originalLookPath, originalVersion })
+
+	lookPath = func(tool string) (string, error) {
+		if _, ok := versions[tool]; !ok {
+			return "", errors.New("not found")
+		}
+		return "/usr/bin/" + tool, nil
+	}
+	toolVersion = func(path string) (string, error) {
+		return versions[strings.TrimPrefix(path, "/usr/bin/")], nil
+	}
+}
+
+// TestCheckMCPRuntimes validates PATH and minimum version checks per server command
+func TestCheckMCPRuntimes(t *testing.T) {
+	definitions := map[string]json.RawMessage{
+		"context7": json.RawMessage(`{"command": "npx", "args": ["-y", "@upstash/context7-mcp@latest"]}`),
+		"serena":   json.RawMessage(`{"command": "uvx", "args": ["--from", "git+https://github.com/oraios/serena", "serena"]}`),
+		"custom":   json.RawMessage(`{"command": "my-server"}`),
+		"remote":   json.RawMessage(`{"type": "http", "url": "https://example.com/mcp"}`),
+	}
+
+	testCases := []struct {
+		name     string
+		versions map[string]string
+		expected []MCPRuntimeIssue
+	}{
+		{
+			name:     "all_present",
+			versions: map[string]string{"node": "v20.11.0", "npx": "10.2.4", "uv": "uv 0.4.18 (abc 2024-10-01)", "uvx": "", "git": "", "my-server": ""},
+		},
+		{
+			name:     "missing_and_outdated",
+			versions: map[string]string{"node": "v16.20.2", "npx": "8.19.4", "uv": "uv 0.4.18", "uvx": ""},
+			expected: []MCPRuntimeIssue{
+				{Server: "context7", Tool: "node", Problem: "16.20.2 is older than the required 18.0.0", Hint: nodeHint},
+				{Server: "custom", Tool: "my-server", Problem: "not found in PATH", Hint: "install it or fix the command in .mcp.json"},
+				{Server: "serena", Tool: "git", Problem: "not found in PATH", Hint: gitHint},
+			},
+		},
+		{
+			name:     "unknown_version",
+			versions: map[string]string{"node": "v20.1.0", "npx": "10.0.0", "uv": "uv", "uvx": "", "git": "", "my-server": ""},
+			expected: []MCPRuntimeIssue{
+				{Server: "serena", Tool: "uv", Problem: "version could not be determined", Hint: uvHint},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRuntimes(t, tc.versions)
+			if got := CheckMCPRuntimes(definitions); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestCompareVersions validates numeric version ordering
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"18.0.0", "18.0.0", 0},
+		{"v9.11.2", "18.0.0", -1},
+		{"18.10", "18.9.9", 1},
+		{"uv 0.4.0", "0.4", 0},
+	}
+
+	for _, tc := range testCases {
+		if got := compareVersions(tc.a, tc.b); got != tc.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
+
+// TestCopyMCPFilesStrictRuntimes validates that --strict-mcp fails on missing runtimes
+func TestCopyMCPFilesStrictRuntimes(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	fakeRuntimes(t, map[string]string{"node": "v20.0.0", "npx": "10.0.0"})
+
+	for _, strict := range []bool{false, true} {
+		ctx := &InstallContext{
+			TargetDir: t.TempDir(),
+			RepoPath:  repoPath,
+			Config:    &InstallConfig{AddRecommendedMCP: true, MCPServers: []string{"all"}, StrictMCP: strict},
+		}
+
+		err := copyMCPFiles(ctx)
+		if strict {
+			if err == nil || !strings.Contains(err.Error(), "serena: uv not found in PATH") {
+				t.Errorf("Expected --strict-mcp to fail on the missing uv, got %v", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("copyMCPFiles failed: %v", err)
+		}
+		tools := make([]string, 0, len(ctx.MCPRuntimeIssues))
+		for _, issue := range ctx.MCPRuntimeIssues {
+			tools = append(tools, issue.Tool)
+		}
+		if expected := []string{"uv", "uvx", "git"}; !reflect.DeepEqual(tools, expected) {
+			t.Errorf("Expected issues for %v, got %+v", expected, ctx.MCPRuntimeIssues)
+		}
+	}
+}
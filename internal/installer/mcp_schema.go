@@ -0,0 +1,299 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
+)
+
+// identifierPattern matches object keys that can be written as .key in a JSON path
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// MCPConfig is the typed model of a project's .mcp.json
+type MCPConfig struct {
+	MCPServers map[string]MCPServerConfig `json:"mcpServers"`
+	raw        map[string]json.RawMessage // Definitions as written, by key
+}
+
+// MCPSchemaError is a problem in an MCP config file, located by JSON path and position
+type MCPSchemaError struct {
+	File   string // File name for display, e.g. .mcp.json
+	Server string // Server key, empty for problems with the file itself
+	Path   string // JSON path such as mcpServers.serena.args[0], empty for the whole document
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error formats the problem as file:line:col: path: message
+func (e MCPSchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", e.File, e.Line, e.Column, e.Path, e.Msg)
+}
+
+// MCPSchemaErrors is every problem found in a file
+type MCPSchemaErrors []MCPSchemaError
+
+// Error lists the problems one per line
+func (e MCPSchemaErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("%d problems:", len(e)))
+	for _, problem := range e {
+		lines = append(lines, "  - "+problem.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// forServer returns the problems of one server
+func (e MCPSchemaErrors) forServer(key string) MCPSchemaErrors {
+	var problems MCPSchemaErrors
+	for _, problem := range e {
+		if problem.Server == key {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// schemaValidator collects problems while walking a parsed config file
+type schemaValidator struct {
+	doc      *jsonedit.Document
+	file     string
+	problems MCPSchemaErrors
+}
+
+// report records a problem at the position of value
+func (v *schemaValidator) report(server, path string, value *jsonedit.Value, format string, args ...interface{}) {
+	line, column := v.doc.Position(value.Start)
+	v.problems = append(v.problems, MCPSchemaError{
+		File: v.file, Server: server, Path: path, Line: line, Column: column, Msg: fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateMCPConfig checks a .mcp.json document: it must be an object whose mcpServers
+// maps keys to server definitions that fit their transport
+func ValidateMCPConfig(data []byte, file string) MCPSchemaErrors {
+	doc, problems := parseMCPDocument(data, file)
+	if problems != nil {
+		return problems
+	}
+
+	servers, problem := mcpServersValue(doc, file)
+	if problem != nil {
+		return MCPSchemaErrors{*problem}
+	}
+	if servers == nil {
+		return nil
+	}
+
+	v := &schemaValidator{doc: doc, file: file}
+	v.servers("mcpServers", servers)
+	return v.problems
+}
+
+// ValidateMCPConfigFile validates a project's .mcp.json; a missing file has no problems
+func ValidateMCPConfigFile(targetDir string) (MCPSchemaErrors, error) {
+	data, err := os.ReadFile(filepath.Join(targetDir, config.MCPConfigFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .mcp.json: %w", err)
+	}
+	return ValidateMCPConfig(data, config.MCPConfigFile), nil
+}
+
+// validateMCPServerFile checks a framework config file, which maps keys to definitions
+func validateMCPServerFile(data []byte, file string) MCPSchemaErrors {
+	doc, problems := parseMCPDocument(data, file)
+	if problems != nil {
+		return problems
+	}
+
+	v := &schemaValidator{doc: doc, file: file}
+	v.servers("", doc.Root())
+	return v.problems
+}
+
+// parseMCPDocument parses a config file, reporting syntax errors by position
+func parseMCPDocument(data []byte, file string) (*jsonedit.Document, MCPSchemaErrors) {
+	doc, err := jsonedit.Parse(data)
+	if err == nil {
+		return doc, nil
+	}
+
+	var syntaxErr *jsonedit.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, MCPSchemaErrors{{File: file, Line: syntaxErr.Line, Column: syntaxErr.Column, Msg: syntaxErr.Msg}}
+	}
+	return nil, MCPSchemaErrors{{File: file, Line: 1, Column: 1, Msg: err.Error()}}
+}
+
+// mcpServersValue returns the mcpServers object of a .mcp.json document, or nil when
+// the document has none. A document that is not an object, or an mcpServers that is
+// not one, is reported as a problem because nothing in it can be read or edited.
+func mcpServersValue(doc *jsonedit.Document, file string) (*jsonedit.Value, *MCPSchemaError) {
+	return serversValue(doc, file, "mcpServers")
+}
+
+// serversValue is mcpServersValue for a client that keeps its servers under serversKey
+func serversValue(doc *jsonedit.Document, file, serversKey string) (*jsonedit.Value, *MCPSchemaError) {
+	root := doc.Root()
+	if root.Kind != jsonedit.Object {
+		line, column := doc.Position(root.Start)
+		return nil, &MCPSchemaError{File: file, Line: line, Column: column, Msg: fmt.Sprintf("must be an object, got %s", root.Kind)}
+	}
+
+	servers, ok := doc.Lookup(serversKey)
+	if !ok {
+		return nil, nil
+	}
+	if servers.Kind != jsonedit.Object {
+		line, column := doc.Position(servers.Start)
+		return nil, &MCPSchemaError{
+			File: file, Path: joinJSONPath("", serversKey), Line: line, Column: column,
+			Msg: fmt.Sprintf("must be an object mapping server names to definitions, got %s", servers.Kind),
+		}
+	}
+	return servers, nil
+}
+
+// servers validates an object of server definitions by key
+func (v *schemaValidator) servers(path string, value *jsonedit.Value) {
+	if value.Kind != jsonedit.Object {
+		v.report("", path, value, "must be an object mapping server names to definitions, got %s", value.Kind)
+		return
+	}
+	for _, member := range value.Members {
+		v.server(member.Key, joinJSONPath(path, member.Key), member.Value)
+	}
+}
+
+// server validates the field types of one definition, then the fields its transport needs
+func (v *schemaValidator) server(key, path string, value *jsonedit.Value) {
+	if value.Kind != jsonedit.Object {
+		v.report(key, path, value, "server definition must be an object, got %s", value.Kind)
+		return
+	}
+
+	before := len(v.problems)
+	for _, field := range value.Members {
+		fieldPath := joinJSONPath(path, field.Key)
+		switch field.Key {
+		case "type", "command", "url":
+			v.expectKind(key, fieldPath, field.Value, jsonedit.String)
+		case "args":
+			if v.expectKind(key, fieldPath, field.Value, jsonedit.Array) {
+				for i, element := range field.Value.Elements {
+					v.expectKind(key, fmt.Sprintf("%s[%d]", fieldPath, i), element, jsonedit.String)
+				}
+			}
+		case "env", "headers":
+			if v.expectKind(key, fieldPath, field.Value, jsonedit.Object) {
+				for _, entry := range field.Value.Members {
+					v.expectKind(key, joinJSONPath(fieldPath, entry.Key), entry.Value, jsonedit.String)
+				}
+			}
+		}
+	}
+	if len(v.problems) > before {
+		return
+	}
+
+	var definition MCPServerConfig
+	if err := json.Unmarshal(v.doc.Bytes()[value.Start:value.End], &definition); err != nil {
+		v.report(key, path, value, "%v", err)
+		return
+	}
+	for _, problem := range definition.Validate() {
+		v.report(key, path, value, "%s", problem)
+	}
+}
+
+// expectKind reports a value of the wrong type and returns whether it had the right one
+func (v *schemaValidator) expectKind(key, path string, value *jsonedit.Value, kind jsonedit.Kind) bool {
+	if value.Kind == kind {
+		return true
+	}
+	article := "a"
+	if kind == jsonedit.Object || kind == jsonedit.Array {
+		article = "an"
+	}
+	v.report(key, path, value, "must be %s %s, got %s", article, kind, value.Kind)
+	return false
+}
+
+// joinJSONPath appends an object key to a path, quoting keys that are not identifiers
+func joinJSONPath(path, key string) string {
+	if !identifierPattern.MatchString(key) {
+		quoted, _ := json.Marshal(key)
+		return fmt.Sprintf("%s[%s]", path, quoted)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// readMCPConfig reads a project's .mcp.json into the typed model; a missing file is an
+// empty config. A file that cannot be parsed or whose mcpServers is not an object fails.
+// Definitions with the wrong shape are kept as far as they decode and returned with
+// their problems, so callers can still list and report them.
+func readMCPConfig(mcpPath string) (MCPConfig, MCPSchemaErrors, error) {
+	data, err := os.ReadFile(mcpPath)
+	if os.IsNotExist(err) {
+		return MCPConfig{}, nil, nil
+	}
+	if err != nil {
+		return MCPConfig{}, nil, fmt.Errorf("failed to read .mcp.json: %w", err)
+	}
+
+	doc, problems := parseMCPDocument(data, config.MCPConfigFile)
+	if problems != nil {
+		return MCPConfig{}, nil, fmt.Errorf("failed to parse .mcp.json: %w", problems)
+	}
+	servers, problem := mcpServersValue(doc, config.MCPConfigFile)
+	if problem != nil {
+		return MCPConfig{}, nil, fmt.Errorf("invalid .mcp.json: %w", problem)
+	}
+
+	mcpConfig := MCPConfig{MCPServers: make(map[string]MCPServerConfig), raw: make(map[string]json.RawMessage)}
+	if servers == nil {
+		return mcpConfig, nil, nil
+	}
+
+	v := &schemaValidator{doc: doc, file: config.MCPConfigFile}
+	v.servers("mcpServers", servers)
+	for _, member := range servers.Members {
+		raw := json.RawMessage(data[member.Value.Start:member.Value.End])
+		var definition MCPServerConfig
+		_ = json.Unmarshal(raw, &definition)
+		mcpConfig.MCPServers[member.Key] = definition
+		mcpConfig.raw[member.Key] = raw
+	}
+
+	return mcpConfig, v.problems, nil
+}
+
+// Keys returns the configured server keys in order
+func (c MCPConfig) Keys() []string {
+	keys := make([]string, 0, len(c.MCPServers))
+	for key := range c.MCPServers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,100 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestValidateMCPConfig validates that problems are reported with their JSON path and
+// position
+func TestValidateMCPConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{"Valid", `{"mcpServers": {"a": {"command": "npx", "args": ["-y"]}, "b": {"type": "http", "url": "https://example.com"}}}`, nil},
+		{"NoServers", `{"other": true}`, nil},
+		{"SyntaxError", "{\n  \"mcpServers\": {,}\n}", []string{".mcp.json:2:18: expected string key"}},
+		{"RootArray", `[]`, []string{".mcp.json:1:1: must be an object, got array"}},
+		{"ServersArray", "{\n  \"mcpServers\": []\n}", []string{".mcp.json:2:17: mcpServers: must be an object mapping server names to definitions, got array"}},
+		{"ServerNotObject", `{"mcpServers": {"a": "npx"}}`, []string{`.mcp.json:1:22: mcpServers.a: server definition must be an object, got string`}},
+		{
+			"FieldTypes",
+			`{"mcpServers": {"my.server": {"command": "npx", "args": ["-y", 1], "env": {"TOKEN": true}}}}`,
+			[]string{
+				`.mcp.json:1:64: mcpServers["my.server"].args[1]: must be a string, got number`,
+				`.mcp.json:1:85: mcpServers["my.server"].env.TOKEN: must be a string, got boolean`,
+			},
+		},
+		{"Transport", `{"mcpServers": {"a": {"type": "sse", "command": "npx"}}}`, []string{
+			`.mcp.json:1:22: mcpServers.a: url is required for sse servers`,
+			`.mcp.json:1:22: mcpServers.a: command, args and env are not used by sse servers`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, problem := range ValidateMCPConfig([]byte(tt.data), ".mcp.json") {
+				got = append(got, problem.Error())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestInvalidMCPConfigFiles validates that malformed project and framework files fail
+// with positioned errors instead of being merged
+func TestInvalidMCPConfigFiles(t *testing.T) {
+	t.Run("ProjectServersArray", func(t *testing.T) {
+		repoPath := createTestFrameworkRepo(t)
+		targetDir := t.TempDir()
+		const original = "{\n  \"mcpServers\": [\"context7\"]\n}\n"
+		writeTestFiles(t, targetDir, map[string]string{".mcp.json": original})
+		mcpPath := filepath.Join(targetDir, ".mcp.json")
+
+		servers, err := DiscoverMCPServers(repoPath)
+		if err != nil {
+			t.Fatalf("DiscoverMCPServers failed: %v", err)
+		}
+		_, err = mergeMCPConfig(mcpPath, true, servers, repoPath, MCPConflictKeep, nil)
+		if err == nil || !strings.Contains(err.Error(), ".mcp.json:2:17: mcpServers: must be an object") {
+			t.Errorf("Expected a positioned error, got %v", err)
+		}
+		if _, err := readConfiguredMCPServers(mcpPath); err == nil {
+			t.Errorf("Expected reading the servers to fail")
+		}
+		if _, err := removeMCPConfigServers(mcpPath, []string{"context7"}); err == nil {
+			t.Errorf("Expected removing servers to fail")
+		}
+
+		data, err := os.ReadFile(mcpPath)
+		if err != nil || string(data) != original {
+			t.Errorf("Expected .mcp.json to be untouched, got %q (%v)", data, err)
+		}
+	})
+
+	t.Run("FrameworkConfig", func(t *testing.T) {
+		repoPath := createTestFrameworkRepo(t)
+		writeTestFiles(t, repoPath, map[string]string{
+			"SuperClaude/MCP/configs/context7.json": `{"context7": {"command": "npx", "args": "-y"}}`,
+		})
+
+		for name, load := range map[string]func() error{
+			"LoadMCPConfig":    func() error { _, err := LoadMCPConfig(repoPath, "context7.json"); return err },
+			"LoadMCPConfigRaw": func() error { _, err := LoadMCPConfigRaw(repoPath, "context7.json"); return err },
+		} {
+			err := load()
+			expected := "invalid MCP config context7.json: configs/context7.json:1:41: context7.args: must be an array, got string"
+			if err == nil || err.Error() != expected {
+				t.Errorf("%s: expected %q, got %v", name, expected, err)
+			}
+		}
+	})
+}
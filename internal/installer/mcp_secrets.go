@@ -0,0 +1,655 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
+)
+
+// envReferencePattern matches a Claude Code environment reference, ${VAR} or
+// ${VAR:-default}, capturing the name and the default
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// docEnvPattern matches secret-looking variable names mentioned in MCP_*.md files
+var docEnvPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*(?:_[A-Z0-9]+)*_(?:API_KEY|KEY|TOKEN|SECRET)\b`)
+
+// secretNamePattern matches env and header names that usually hold credentials, by a
+// whole word of the name so AUTHOR or KEYBOARD_LAYOUT don't match
+var secretNamePattern = regexp.MustCompile(`(?i)(^|[^a-z])(api_?key|key|token|secret|password|passwd|pwd|credentials?|auth|authorization)s?([^a-z]|$)`)
+
+// placeholderPattern matches whole placeholder values such as <your-api-key>,
+// your_token_here, api-key-here, changeme or xxxx
+var placeholderPattern = regexp.MustCompile(`(?i)^(<.+>|\{\{.+\}\}|your[-_ ].*|.*[-_ ]here|changeme|change[-_ ]me|replace[-_ ]?me|x{3,}|\*{3,})$`)
+
+// secretValuePattern matches well-known credential formats
+var secretValuePattern = regexp.MustCompile(`^(sk-[A-Za-z0-9_-]{16,}|gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|xox[abprs]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16}|AIza[0-9A-Za-z_-]{30,})`)
+
+// authSchemePattern matches the scheme kept in front of a credential in a header value
+var authSchemePattern = regexp.MustCompile(`^(?i:bearer|basic|token)\s+`)
+
+// genericEnvNames are prefixed with the server name so two servers can use different values
+var genericEnvNames = map[string]bool{"API_KEY": true, "KEY": true, "TOKEN": true, "SECRET": true, "ACCESS_TOKEN": true}
+
+// MCPEnvRequirement is an environment variable a server needs
+type MCPEnvRequirement struct {
+	Server   string // .mcp.json server key
+	EnvKey   string // Key in the server's env block
+	Header   string // Header of a remote server, when the variable is used there instead
+	Variable string // Variable referenced as ${Variable} in .mcp.json
+}
+
+// MCPEnvOptions supplies values for required environment variables
+type MCPEnvOptions struct {
+	Values  map[string]string // From --env VAR=value
+	EnvFile string            // From --env-file; the project .env is used when empty
+}
+
+// SecretFinding is a literal credential found in .mcp.json
+type SecretFinding struct {
+	Server string
+	Field  string // e.g. "env.API_KEY"
+	Line   int
+	Column int
+}
+
+// String formats the finding as .mcp.json:line:col
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s.%s looks like a literal secret", config.MCPConfigFile, f.Line, f.Column, f.Server, f.Field)
+}
+
+// ParseMCPEnvValues parses --env VAR=value arguments
+func ParseMCPEnvValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --env %q, expected VAR=value", pair)
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	return values, nil
+}
+
+// SecretsPath returns the gitignored file holding MCP secret values for a project
+func SecretsPath(targetDir string) string {
+	return filepath.Join(ProjectConfigDir(targetDir), config.SecretsFile)
+}
+
+// promptSecret asks for a variable's value without echoing it; overridden in tests.
+// It returns an empty value when there is no terminal to ask on.
+var promptSecret = func(requirement MCPEnvRequirement) (string, error) {
+	return promptSecretValue(fmt.Sprintf("Enter %s for %s (leave empty to set it later)", requirement.Variable, requirement.Server))
+}
+
+// detectMCPEnvRequirements finds the variables a server needs: env entries and secret
+// headers whose value is empty, a placeholder or already a ${VAR} reference, references
+// in args and the url, and secret names mentioned in the server's MCP_*.md file
+func detectMCPEnvRequirements(key string, raw json.RawMessage, doc string) []MCPEnvRequirement {
+	var definition MCPServerConfig
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var requirements []MCPEnvRequirement
+	add := func(requirement MCPEnvRequirement) {
+		if !seen[requirement.Variable] {
+			seen[requirement.Variable] = true
+			requirement.Server = key
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	for _, envKey := range sortedStringKeys(definition.Env) {
+		value := definition.Env[envKey]
+		if match := envReferencePattern.FindStringSubmatch(value); match != nil {
+			add(MCPEnvRequirement{EnvKey: envKey, Variable: match[1]})
+		} else if isPlaceholder(envKey, value) {
+			add(MCPEnvRequirement{EnvKey: envKey, Variable: envVariableName(key, envKey)})
+		}
+	}
+
+	for _, header := range sortedStringKeys(definition.Headers) {
+		value := definition.Headers[header]
+		credential := authSchemePattern.ReplaceAllString(value, "")
+		if matches := envReferencePattern.FindAllStringSubmatch(value, -1); matches != nil {
+			for _, match := range matches {
+				add(MCPEnvRequirement{Header: header, Variable: match[1]})
+			}
+		} else if isPlaceholder(header, credential) {
+			add(MCPEnvRequirement{Header: header, Variable: headerVariableName(key, header)})
+		}
+	}
+
+	for _, value := range append(definition.Args, definition.URL) {
+		for _, match := range envReferencePattern.FindAllStringSubmatch(value, -1) {
+			add(MCPEnvRequirement{Variable: match[1]})
+		}
+	}
+
+	for _, variable := range docEnvPattern.FindAllString(doc, -1) {
+		if _, inEnv := definition.Env[variable]; !inEnv && definition.Transport() == MCPTransportStdio {
+			add(MCPEnvRequirement{EnvKey: variable, Variable: variable})
+		}
+	}
+
+	return requirements
+}
+
+// isPlaceholder reports whether an upstream value under a credential name is meant to
+// be filled in by the user: empty or a placeholder. Other names keep their values.
+func isPlaceholder(name, value string) bool {
+	if !secretNamePattern.MatchString(name) {
+		return false
+	}
+	value = strings.TrimSpace(value)
+	return value == "" || isPlaceholderValue(value)
+}
+
+// isPlaceholderValue reports whether a value is a placeholder as a whole
+func isPlaceholderValue(value string) bool {
+	return placeholderPattern.MatchString(strings.TrimSpace(value))
+}
+
+// envVariableName returns the variable for a server's env key, prefixing generic
+// names such as API_KEY with the server name (magic → MAGIC_API_KEY)
+func envVariableName(serverKey, envKey string) string {
+	if !genericEnvNames[strings.ToUpper(envKey)] {
+		return envKey
+	}
+
+	prefix := strings.ToUpper(mcpDocNamePattern.ReplaceAllString(serverKey, "_"))
+	return strings.Trim(prefix, "_") + "_" + strings.ToUpper(envKey)
+}
+
+// headerVariableName returns the variable for a server's header: Authorization becomes
+// <SERVER>_TOKEN and other headers lose an X- prefix (X-API-Key → <SERVER>_API_KEY)
+func headerVariableName(serverKey, header string) string {
+	name := "TOKEN"
+	if !strings.EqualFold(header, "Authorization") {
+		trimmed := header
+		if len(trimmed) > 2 && strings.EqualFold(trimmed[:2], "x-") {
+			trimmed = trimmed[2:]
+		}
+		name = strings.Trim(strings.ToUpper(mcpDocNamePattern.ReplaceAllString(trimmed, "_")), "_")
+	}
+	return envVariableName(serverKey, name)
+}
+
+// sortedStringKeys returns the keys of m in order
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// referenceMCPEnv detects the variables a server definition needs and replaces their
+// env and header values with ${VAR:-} references, so .mcp.json never holds the values.
+// The empty default keeps Claude Code loading the file while a variable is unexported.
+// A header keeps its auth scheme, e.g. "Bearer ${VAR:-}".
+func referenceMCPEnv(key string, raw json.RawMessage, doc string) (json.RawMessage, []MCPEnvRequirement, error) {
+	requirements := detectMCPEnvRequirements(key, raw, doc)
+	if len(requirements) == 0 {
+		return raw, nil, nil
+	}
+
+	definition, err := jsonedit.Parse(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+	}
+	for _, requirement := range requirements {
+		path := []string{"env", requirement.EnvKey}
+		if requirement.Header != "" {
+			path = []string{"headers", requirement.Header}
+		} else if requirement.EnvKey == "" {
+			continue
+		}
+
+		// Keep references the definition already uses, including their defaults
+		current := ""
+		if value, ok := definition.Lookup(path...); ok {
+			current = rawString(definition.Bytes(), value)
+		}
+		if envReferencePattern.MatchString(current) {
+			continue
+		}
+		reference, err := json.Marshal(authSchemePattern.FindString(current) + "${" + requirement.Variable + ":-}")
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := definition.Set(path, reference); err != nil {
+			return nil, nil, fmt.Errorf("failed to reference %s for %s: %w", requirement.Variable, key, err)
+		}
+	}
+
+	return json.RawMessage(definition.Bytes()), requirements, nil
+}
+
+// envForEntry labels requirements with the .mcp.json key the change wrote
+func envForEntry(requirements []MCPEnvRequirement, change MCPServerChange) []MCPEnvRequirement {
+	key := change.Key
+	if change.Action == MCPServerRenamed {
+		key = change.RenamedTo
+	}
+
+	labeled := make([]MCPEnvRequirement, len(requirements))
+	for i, requirement := range requirements {
+		requirement.Server = key
+		labeled[i] = requirement
+	}
+	return labeled
+}
+
+// configureMCPSecrets makes sure the variables referenced by the written .mcp.json
+// entries have values, storing new ones in the gitignored secrets file
+func configureMCPSecrets(targetDir string, changes []MCPServerChange, options MCPEnvOptions, pause progressPause) error {
+	var requirements []MCPEnvRequirement
+	for _, change := range changes {
+		requirements = append(requirements, change.Env...)
+	}
+
+	missing, err := resolveMCPEnv(targetDir, requirements, options, pause)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		fmt.Printf("⚠️  MCP servers need %s; add them to %s or your environment before starting Claude Code\n",
+			strings.Join(missing, ", "), filepath.ToSlash(filepath.Join(config.ToolConfigDir, config.SecretsFile)))
+	}
+
+	unexported, err := UnexportedMCPSecrets(targetDir)
+	if err != nil {
+		return err
+	}
+	var needed []string
+	for _, variable := range unexported {
+		if slices.ContainsFunc(requirements, func(r MCPEnvRequirement) bool { return r.Variable == variable }) {
+			needed = append(needed, variable)
+		}
+	}
+	if len(needed) > 0 {
+		fmt.Printf("⚠️  Claude Code reads %s from its environment, not from the secrets file; export them before starting it: %s\n",
+			strings.Join(needed, ", "), MCPSecretsLoadCommand())
+	}
+
+	return nil
+}
+
+// MCPSecretsLoadCommand returns the shell command that exports the stored secrets
+func MCPSecretsLoadCommand() string {
+	return "set -a; . ./" + filepath.ToSlash(filepath.Join(config.ToolConfigDir, config.SecretsFile)) + "; set +a"
+}
+
+// UnexportedMCPSecrets returns the variables stored in the secrets file that are not
+// set in the environment, where .mcp.json references expand to empty values
+func UnexportedMCPSecrets(targetDir string) ([]string, error) {
+	stored, err := loadEnvFile(SecretsPath(targetDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var unexported []string
+	for _, variable := range sortedStringKeys(stored) {
+		if _, ok := os.LookupEnv(variable); !ok {
+			unexported = append(unexported, variable)
+		}
+	}
+	return unexported, nil
+}
+
+// resolveMCPEnv looks up each required variable in --env values, the env file, the
+// stored secrets, the process environment and finally a prompt. Values that did not
+// come from the environment are stored in the gitignored secrets file. It returns the
+// variables that are still unset.
+func resolveMCPEnv(targetDir string, requirements []MCPEnvRequirement, options MCPEnvOptions, pause progressPause) ([]string, error) {
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	envFile := options.EnvFile
+	if envFile == "" {
+		envFile = filepath.Join(targetDir, ".env")
+	}
+	fileValues, err := loadEnvFile(envFile)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := loadEnvFile(SecretsPath(targetDir))
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]string)
+	seen := make(map[string]bool)
+	var missing []string
+	for _, requirement := range requirements {
+		variable := requirement.Variable
+		if seen[variable] {
+			continue
+		}
+		seen[variable] = true
+
+		value, ok := options.Values[variable]
+		if !ok {
+			value, ok = fileValues[variable]
+		}
+		if !ok && (stored[variable] != "" || os.Getenv(variable) != "") {
+			continue
+		}
+		if !ok {
+			resume := pause.pause()
+			value, err = promptSecret(requirement)
+			resume()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if value == "" {
+			missing = append(missing, variable)
+			continue
+		}
+		if stored[variable] != value {
+			updates[variable] = value
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := saveSecrets(targetDir, updates); err != nil {
+			return nil, err
+		}
+		fmt.Printf("🔑 Stored %d MCP secret(s) in %s (gitignored)\n", len(updates), relativeToTarget(targetDir, SecretsPath(targetDir)))
+	}
+
+	return missing, nil
+}
+
+// storesMCPSecrets reports whether resolveMCPEnv may write the secrets file: a variable
+// gets a new value from --env or the env file, or is unset and would be asked for
+func storesMCPSecrets(targetDir string, requirements []MCPEnvRequirement, options MCPEnvOptions) (bool, error) {
+	if len(requirements) == 0 {
+		return false, nil
+	}
+
+	envFile := options.EnvFile
+	if envFile == "" {
+		envFile = filepath.Join(targetDir, ".env")
+	}
+	fileValues, err := loadEnvFile(envFile)
+	if err != nil {
+		return false, err
+	}
+	stored, err := loadEnvFile(SecretsPath(targetDir))
+	if err != nil {
+		return false, err
+	}
+
+	for _, requirement := range requirements {
+		variable := requirement.Variable
+		value, ok := options.Values[variable]
+		if !ok {
+			value, ok = fileValues[variable]
+		}
+		if !ok && (stored[variable] != "" || os.Getenv(variable) != "") {
+			continue
+		}
+		if !ok || (value != "" && stored[variable] != value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mcpEnvLookup returns a lookup of variable values from the process environment,
+// falling back to the project's secrets file
+func mcpEnvLookup(targetDir string) (func(string) (string, bool), error) {
+	secrets, err := loadEnvFile(SecretsPath(targetDir))
+	if err != nil {
+		return nil, err
+	}
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return value, true
+		}
+		value, ok := secrets[name]
+		return value, ok
+	}, nil
+}
+
+// loadEnvFile reads KEY=VALUE lines, ignoring comments and an optional "export " prefix.
+// A missing file has no values.
+func loadEnvFile(path string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, ok := shellUnquote(value); ok && strings.HasPrefix(value, "'") {
+			value = unquoted // Written by saveSecrets in single quotes
+		} else if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		} else if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+
+	return values, nil
+}
+
+// shellQuote quotes value for a POSIX shell. Nothing is expanded in single quotes, and
+// each single quote in value ends the quoting, is escaped and reopens it.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// shellUnquote reads a word of single-quoted parts and backslash-escaped characters, the
+// form shellQuote writes. It fails on anything else.
+func shellUnquote(word string) (string, bool) {
+	var b strings.Builder
+	for word != "" {
+		switch word[0] {
+		case '\'':
+			end := strings.IndexByte(word[1:], '\'')
+			if end < 0 {
+				return "", false
+			}
+			b.WriteString(word[1 : end+1])
+			word = word[end+2:]
+		case '\\':
+			if len(word) < 2 {
+				return "", false
+			}
+			b.WriteByte(word[1])
+			word = word[2:]
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// saveSecrets merges values into the secrets file and makes sure git ignores it
+func saveSecrets(targetDir string, updates map[string]string) error {
+	path := SecretsPath(targetDir)
+	values, err := loadEnvFile(path)
+	if err != nil {
+		return err
+	}
+	for key, value := range updates {
+		values[key] = value
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("# MCP server secrets written by super-claude-lite. Do not commit this file.\n")
+	b.WriteString("# Load it before starting Claude Code, e.g.: " + MCPSecretsLoadCommand() + "\n")
+	for _, key := range keys {
+		if strings.ContainsAny(values[key], "\r\n") {
+			return fmt.Errorf("value of %s spans several lines, which %s cannot hold", key, config.SecretsFile)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", key, shellQuote(values[key]))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return ensureSecretsIgnored(targetDir)
+}
+
+// ensureSecretsIgnored adds the secrets file to .superclaude-lite/.gitignore
+func ensureSecretsIgnored(targetDir string) error {
+	dir := ProjectConfigDir(targetDir)
+	if isGitignored(dir, config.SecretsFile) {
+		return nil
+	}
+
+	ignorePath := filepath.Join(dir, ".gitignore")
+	file, err := os.OpenFile(ignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", ignorePath, err)
+	}
+	if _, err := file.WriteString(config.SecretsFile + "\n"); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to update %s: %w", ignorePath, err)
+	}
+	return file.Close()
+}
+
+// DetectLiteralSecrets reports env and header values in .mcp.json that look like
+// credentials instead of ${VAR} references
+func DetectLiteralSecrets(mcpPath string) ([]SecretFinding, error) {
+	data, err := os.ReadFile(mcpPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .mcp.json: %w", err)
+	}
+
+	doc, err := jsonedit.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse .mcp.json: %w", err)
+	}
+
+	servers, ok := doc.Lookup("mcpServers")
+	if !ok || servers.Kind != jsonedit.Object {
+		return nil, nil
+	}
+
+	var findings []SecretFinding
+	for _, server := range servers.Members {
+		if server.Value.Kind != jsonedit.Object {
+			continue
+		}
+		for _, field := range server.Value.Members {
+			if (field.Key != "env" && field.Key != "headers") || field.Value.Kind != jsonedit.Object {
+				continue
+			}
+			for _, entry := range field.Value.Members {
+				value := authSchemePattern.ReplaceAllString(rawString(data, entry.Value), "")
+				if !looksLikeSecret(entry.Key, value) {
+					continue
+				}
+				line, column := doc.Position(entry.Value.Start)
+				findings = append(findings, SecretFinding{Server: server.Key, Field: field.Key + "." + entry.Key, Line: line, Column: column})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// looksLikeSecret reports whether a literal value under the given name is a credential
+func looksLikeSecret(name, value string) bool {
+	if value == "" || envReferencePattern.MatchString(value) || isPlaceholderValue(value) {
+		return false
+	}
+	return secretNamePattern.MatchString(name) || secretValuePattern.MatchString(value)
+}
+
+// rawString decodes a string value, returning "" for other kinds
+func rawString(data []byte, value *jsonedit.Value) string {
+	if value.Kind != jsonedit.String {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(data[value.Start:value.End], &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// warnLiteralSecrets prints a warning for each literal secret in .mcp.json
+func warnLiteralSecrets(targetDir string) {
+	findings, err := DetectLiteralSecrets(filepath.Join(targetDir, config.MCPConfigFile))
+	if err != nil || len(findings) == 0 {
+		return
+	}
+
+	fmt.Printf("⚠️  .mcp.json contains literal secrets that would be committed:\n")
+	for _, finding := range findings {
+		fmt.Printf("   %s\n", finding)
+	}
+	fmt.Printf("   Replace them with ${VAR} references and keep the values in %s\n",
+		filepath.ToSlash(filepath.Join(config.ToolConfigDir, config.SecretsFile)))
+}
+
+// relativeToTarget returns path relative to targetDir for display, or path itself when
+// it is outside targetDir
+func relativeToTarget(targetDir, path string) string {
+	rel, err := filepath.Rel(targetDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// readMCPDoc returns the MCP_*.md text for a server, or "" if it cannot be read
+func readMCPDoc(repoPath string, server MCPServer) string {
+	path := server.docSource(repoPath)
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- framework or registry instructions
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,125 @@
+package installer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestResolveMCPServers validates --mcp name matching, all and none
+func TestResolveMCPServers(t *testing.T) {
+	servers, err := DiscoverMCPServers(createTestFrameworkRepo(t))
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{"By_name", []string{"serena"}, []string{"Serena"}},
+		{"Case_insensitive", []string{"Context7", "SERENA"}, []string{"Context7", "Serena"}},
+		{"All", []string{"all"}, []string{"Context7", "Serena"}},
+		{"None", []string{"none"}, nil},
+		{"Empty", []string{}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := ResolveMCPServers(servers, tc.names)
+			if err != nil {
+				t.Fatalf("ResolveMCPServers failed: %v", err)
+			}
+
+			var got []string
+			for _, server := range selected {
+				got = append(got, server.Name)
+				if !server.Selected {
+					t.Errorf("Expected %s to be marked selected", server.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ResolveMCPServers(servers, []string{"context7", "playwright"})
+		if err == nil {
+			t.Fatalf("Expected error for unknown server")
+		}
+		if !strings.Contains(err.Error(), `"playwright"`) || !strings.Contains(err.Error(), "context7, serena") {
+			t.Errorf("Expected error naming the server and listing available ones, got: %v", err)
+		}
+	})
+}
+
+// TestCopyMCPFilesNonInteractive validates that --mcp bypasses the selector and that
+// the selector fails when it has no terminal and no piped answers
+func TestCopyMCPFilesNonInteractive(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+
+	originalSelector, originalTerminal, originalInput := selectMCPServers, stdinIsTerminal, promptInput
+	defer func() {
+		selectMCPServers, stdinIsTerminal, promptInput = originalSelector, originalTerminal, originalInput
+	}()
+
+	newContext := func(t *testing.T, names []string) *InstallContext {
+		t.Helper()
+		ctx, err := NewInstallContext(t.TempDir(), &InstallConfig{NoBackup: true, AddRecommendedMCP: true, MCPServers: names})
+		if err != nil {
+			t.Fatalf("Failed to create install context: %v", err)
+		}
+		ctx.RepoPath = repoPath
+		return ctx
+	}
+
+	t.Run("Named_servers", func(t *testing.T) {
+		selectMCPServers = func(servers []MCPServer, repoPath string) ([]MCPServer, error) {
+			t.Fatalf("Selector must not run when --mcp is given")
+			return nil, nil
+		}
+		defer func() { selectMCPServers = originalSelector }()
+
+		ctx := newContext(t, []string{"context7"})
+		if err := copyMCPFiles(ctx); err != nil {
+			t.Fatalf("copyMCPFiles failed: %v", err)
+		}
+
+		if len(ctx.SelectedMCPServers) != 1 || ctx.SelectedMCPServers[0].Name != "Context7" {
+			t.Errorf("Expected only Context7 selected, got %v", ctx.SelectedMCPServers)
+		}
+		if !fileExists(filepath.Join(ctx.TargetDir, ".superclaude", "MCP", "MCP_Context7.md")) {
+			t.Errorf("Expected MCP_Context7.md to be copied")
+		}
+		if fileExists(filepath.Join(ctx.TargetDir, ".superclaude", "MCP", "MCP_Serena.md")) {
+			t.Errorf("Expected MCP_Serena.md not to be copied")
+		}
+	})
+
+	t.Run("Unknown_server", func(t *testing.T) {
+		ctx := newContext(t, []string{"missing"})
+		if err := copyMCPFiles(ctx); err == nil || !strings.Contains(err.Error(), "available: context7, serena") {
+			t.Errorf("Expected unknown server error listing available servers, got: %v", err)
+		}
+	})
+
+	t.Run("No_terminal", func(t *testing.T) {
+		stdinIsTerminal = func() bool { return false }
+		promptInput = bufio.NewReader(strings.NewReader(""))
+
+		ctx := newContext(t, nil)
+		err := copyMCPFiles(ctx)
+		if err == nil || !strings.Contains(err.Error(), "--mcp") {
+			t.Errorf("Expected error pointing to --mcp without a terminal, got: %v", err)
+		}
+		if _, statErr := os.Stat(filepath.Join(ctx.TargetDir, ".superclaude", "MCP")); !os.IsNotExist(statErr) {
+			t.Errorf("Expected no MCP files to be copied")
+		}
+	})
+}
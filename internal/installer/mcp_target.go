@@ -0,0 +1,258 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
+)
+
+// MCPTarget is an MCP client, besides Claude Code, whose config file receives the
+// selected servers
+type MCPTarget string
+
+const (
+	// MCPTargetCursor writes .cursor/mcp.json in the project
+	MCPTargetCursor MCPTarget = "cursor"
+	// MCPTargetVSCode writes .vscode/mcp.json in the project
+	MCPTargetVSCode MCPTarget = "vscode"
+	// MCPTargetClaudeDesktop writes the user's claude_desktop_config.json
+	MCPTargetClaudeDesktop MCPTarget = "claude-desktop"
+)
+
+// newJSONFile seeds a created config file; it spans lines so members added with
+// jsonedit are indented rather than written on one line
+const newJSONFile = "{\n}\n"
+
+// errMCPServerUnsupported marks a server a client cannot run from its config file
+var errMCPServerUnsupported = errors.New("not supported by this client")
+
+// mcpClient describes where an MCP client keeps its server configuration and how
+// .mcp.json definitions are converted to its format
+type mcpClient struct {
+	Target     MCPTarget
+	Name       string
+	File       string // Config file for display, e.g. .cursor/mcp.json
+	ServersKey string // Top-level key holding the servers by name
+	Global     bool   // The file lives in the user's home and is shared by all projects
+	// Path returns the config file location for a project
+	Path func(targetDir string) (string, error)
+	// Convert rewrites a .mcp.json definition for the client, or returns
+	// errMCPServerUnsupported; nil keeps definitions as they are
+	Convert func(key string, raw json.RawMessage, lookup func(string) (string, bool)) (json.RawMessage, error)
+}
+
+// claudeCodeClient is the project's .mcp.json, read by Claude Code
+var claudeCodeClient = mcpClient{
+	Name:       "Claude Code",
+	File:       config.MCPConfigFile,
+	ServersKey: "mcpServers",
+	Path: func(targetDir string) (string, error) {
+		return filepath.Join(targetDir, config.MCPConfigFile), nil
+	},
+}
+
+// mcpClients are the clients --mcp-target can write to
+var mcpClients = map[MCPTarget]mcpClient{
+	MCPTargetCursor: {
+		Target:     MCPTargetCursor,
+		Name:       "Cursor",
+		File:       ".cursor/mcp.json",
+		ServersKey: "mcpServers",
+		Path: func(targetDir string) (string, error) {
+			return filepath.Join(targetDir, ".cursor", "mcp.json"), nil
+		},
+		Convert: func(_ string, raw json.RawMessage, _ func(string) (string, bool)) (json.RawMessage, error) {
+			return editorEnvReferences(raw), nil
+		},
+	},
+	MCPTargetVSCode: {
+		Target:     MCPTargetVSCode,
+		Name:       "VS Code",
+		File:       ".vscode/mcp.json",
+		ServersKey: "servers",
+		Path: func(targetDir string) (string, error) {
+			return filepath.Join(targetDir, ".vscode", "mcp.json"), nil
+		},
+		Convert: convertVSCodeServer,
+	},
+	MCPTargetClaudeDesktop: {
+		Target:     MCPTargetClaudeDesktop,
+		Name:       "Claude Desktop",
+		File:       "claude_desktop_config.json",
+		ServersKey: "mcpServers",
+		Global:     true,
+		Path: func(string) (string, error) {
+			dir, err := os.UserConfigDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to locate the Claude Desktop config: %w", err)
+			}
+			return filepath.Join(dir, "Claude", "claude_desktop_config.json"), nil
+		},
+		Convert: convertClaudeDesktopServer,
+	},
+}
+
+// ParseMCPTargets validates --mcp-target values, dropping duplicates
+func ParseMCPTargets(values []string) ([]MCPTarget, error) {
+	var targets []MCPTarget
+	seen := make(map[MCPTarget]bool)
+	for _, value := range values {
+		target := MCPTarget(strings.ToLower(strings.TrimSpace(value)))
+		if _, ok := mcpClients[target]; !ok {
+			return nil, fmt.Errorf("invalid --mcp-target %q, expected one of: %s", value, strings.Join(MCPTargetNames(), ", "))
+		}
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// MCPTargetNames returns the accepted --mcp-target values
+func MCPTargetNames() []string {
+	names := make([]string, 0, len(mcpClients))
+	for target := range mcpClients {
+		names = append(names, string(target))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// editorEnvReferences rewrites Claude Code's ${VAR} references as the ${env:VAR} form
+// Cursor and VS Code expand. Defaults (${VAR:-default}) are not supported there and
+// are dropped.
+func editorEnvReferences(raw json.RawMessage) json.RawMessage {
+	return json.RawMessage(envReferencePattern.ReplaceAll(raw, []byte("${env:$1}")))
+}
+
+// convertVSCodeServer adds the type VS Code requires and rewrites env references
+func convertVSCodeServer(key string, raw json.RawMessage, _ func(string) (string, bool)) (json.RawMessage, error) {
+	definition, err := jsonedit.Parse(editorEnvReferences(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+	}
+	if _, ok := definition.Lookup("type"); !ok {
+		if err := definition.Set([]string{"type"}, json.RawMessage(`"stdio"`)); err != nil {
+			return nil, fmt.Errorf("failed to convert %s for VS Code: %w", key, err)
+		}
+	}
+	return json.RawMessage(definition.Bytes()), nil
+}
+
+// convertClaudeDesktopServer expands references, because Claude Desktop does not, and
+// skips remote servers, which Claude Desktop adds as connectors instead
+func convertClaudeDesktopServer(key string, raw json.RawMessage, lookup func(string) (string, bool)) (json.RawMessage, error) {
+	if transport := mcpTransportOf(raw); transport != MCPTransportStdio {
+		return nil, fmt.Errorf("%s servers %w; add it as a connector in Claude Desktop's settings", transport, errMCPServerUnsupported)
+	}
+
+	var missing []string
+	expanded := envReferencePattern.ReplaceAllFunc(raw, func(reference []byte) []byte {
+		match := envReferencePattern.FindSubmatch(reference)
+		value, ok := "", false
+		if lookup != nil {
+			value, ok = lookup(string(match[1]))
+		}
+		switch {
+		case ok:
+			escaped, _ := json.Marshal(value)
+			return escaped[1 : len(escaped)-1]
+		case len(match[2]) > 0:
+			return match[2]
+		case match[2] != nil:
+			// An empty default, as referenceMCPEnv writes, stands for a value still to set
+			missing = append(missing, string(match[1]))
+			return match[2]
+		}
+		missing = append(missing, string(match[1]))
+		return reference
+	})
+
+	if len(missing) > 0 {
+		fmt.Printf("⚠️  Claude Desktop cannot expand %s for %s; set the values in its config\n", strings.Join(missing, ", "), key)
+	}
+	return json.RawMessage(expanded), nil
+}
+
+// writeMCPClientConfigs merges the selected servers into each target client's config
+// file, creating it when missing, and returns the files written and the changes made
+func writeMCPClientConfigs(targetDir string, targets []MCPTarget, selected []MCPServer, repoPath string, strategy MCPConflictStrategy, env MCPEnvOptions, pause progressPause) ([]string, []MCPServerChange, error) {
+	var written []string
+	var merged []MCPServerChange
+	for _, target := range targets {
+		client := mcpClients[target]
+		path, err := client.Path(targetDir)
+		if err != nil {
+			return written, merged, err
+		}
+
+		// Clients that cannot expand references need the values before writing
+		var lookup func(string) (string, bool)
+		if target == MCPTargetClaudeDesktop {
+			if err := configureMCPSecrets(targetDir, selectedMCPEnv(selected, repoPath), env, pause); err != nil {
+				return written, merged, err
+			}
+			if lookup, err = mcpEnvLookup(targetDir); err != nil {
+				return written, merged, err
+			}
+		}
+
+		if !fileExists(path) {
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return written, merged, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+			}
+			if err := os.WriteFile(path, []byte(newJSONFile), 0o600); err != nil {
+				return written, merged, fmt.Errorf("failed to create %s: %w", client.File, err)
+			}
+		}
+
+		changes, err := mergeMCPClientConfig(client, path, selected, repoPath, strategy, lookup, pause)
+		if err != nil {
+			return written, merged, err
+		}
+		if target != MCPTargetClaudeDesktop {
+			if err := configureMCPSecrets(targetDir, changes, env, pause); err != nil {
+				return written, merged, err
+			}
+		}
+		written = append(written, path)
+		merged = append(merged, changes...)
+	}
+	return written, merged, nil
+}
+
+// selectedMCPEnv returns the variables the selected servers reference, as changes
+// configureMCPSecrets can resolve
+func selectedMCPEnv(selected []MCPServer, repoPath string) []MCPServerChange {
+	var changes []MCPServerChange
+	for _, server := range selected {
+		serverConfig, err := server.LoadConfigRaw(repoPath)
+		if err != nil {
+			continue
+		}
+		for key, raw := range serverConfig {
+			changes = append(changes, MCPServerChange{Key: key, Env: detectMCPEnvRequirements(key, raw, readMCPDoc(repoPath, server))})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// mcpClientConfigPaths returns the config files of the targets, for backups
+func mcpClientConfigPaths(targetDir string, targets []MCPTarget) []string {
+	var paths []string
+	for _, target := range targets {
+		if path, err := mcpClients[target].Path(targetDir); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
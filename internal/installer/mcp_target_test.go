@@ -0,0 +1,204 @@
+package installer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseMCPTargets validates --mcp-target parsing
+func TestParseMCPTargets(t *testing.T) {
+	targets, err := ParseMCPTargets([]string{"cursor", " VSCode", "cursor", "claude-desktop"})
+	if err != nil {
+		t.Fatalf("ParseMCPTargets failed: %v", err)
+	}
+	expected := []MCPTarget{MCPTargetCursor, MCPTargetVSCode, MCPTargetClaudeDesktop}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected %v, got %v", expected, targets)
+	}
+
+	if _, err := ParseMCPTargets([]string{"zed"}); err == nil || !strings.Contains(err.Error(), "claude-desktop, cursor, vscode") {
+		t.Errorf("Expected an error listing the targets, got %v", err)
+	}
+}
+
+// TestWriteMCPClientConfigs validates that selected servers are written in each
+// client's format, using a fake home directory for Claude Desktop
+func TestWriteMCPClientConfigs(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{
+		"SuperClaude/MCP/MCP_Magic.md":       "# Magic\n",
+		"SuperClaude/MCP/configs/magic.json": `{"magic": {"command": "npx", "args": ["-y", "@21st-dev/magic-mcp@latest"], "env": {"TWENTYFIRST_API_KEY": ""}}}`,
+	})
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+	selected, err := ResolveMCPServers(servers, []string{"context7", "magic"})
+	if err != nil {
+		t.Fatalf("ResolveMCPServers failed: %v", err)
+	}
+	remote := MCPServer{Name: "Remote", Source: MCPSourceProject, Config: map[string]json.RawMessage{
+		"remote": json.RawMessage(`{"type": "http", "url": "https://mcp.example.com/mcp"}`),
+	}}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("TWENTYFIRST_API_KEY", "")
+
+	originalPrompt := promptSecret
+	defer func() { promptSecret = originalPrompt }()
+	promptSecret = func(MCPEnvRequirement) (string, error) { return "", nil }
+	env := MCPEnvOptions{Values: map[string]string{"TWENTYFIRST_API_KEY": "tf-\"secret\""}}
+
+	readServers := func(t *testing.T, path, serversKey string) map[string]map[string]interface{} {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		var doc map[string]json.RawMessage
+		var servers map[string]map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Failed to parse %s: %v\n%s", path, err, data)
+		}
+		if err := json.Unmarshal(doc[serversKey], &servers); err != nil {
+			t.Fatalf("Failed to parse %s in %s: %v", serversKey, path, err)
+		}
+		return servers
+	}
+
+	t.Run("CursorAndVSCode", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{
+			".vscode/mcp.json": `{"inputs": [], "servers": {"mine": {"type": "stdio", "command": "mine"}}}`,
+		})
+
+		written, _, err := writeMCPClientConfigs(targetDir, []MCPTarget{MCPTargetCursor, MCPTargetVSCode},
+			append(selected, remote), repoPath, MCPConflictKeep, env, nil)
+		if err != nil {
+			t.Fatalf("writeMCPClientConfigs failed: %v", err)
+		}
+		cursorPath := filepath.Join(targetDir, ".cursor", "mcp.json")
+		vscodePath := filepath.Join(targetDir, ".vscode", "mcp.json")
+		if !reflect.DeepEqual(written, []string{cursorPath, vscodePath}) {
+			t.Errorf("Expected both files to be written, got %v", written)
+		}
+
+		cursor := readServers(t, cursorPath, "mcpServers")
+		if got := cursor["magic"]["env"]; !reflect.DeepEqual(got, map[string]interface{}{"TWENTYFIRST_API_KEY": "${env:TWENTYFIRST_API_KEY}"}) {
+			t.Errorf("Expected Cursor's env reference, got %v", got)
+		}
+		if cursor["remote"]["url"] != "https://mcp.example.com/mcp" {
+			t.Errorf("Expected the remote server in Cursor, got %v", cursor["remote"])
+		}
+
+		vscode := readServers(t, vscodePath, "servers")
+		for _, key := range []string{"mine", "context7", "magic", "remote"} {
+			if vscode[key] == nil {
+				t.Errorf("Expected %s under servers, got %v", key, vscode)
+			}
+		}
+		if vscode["context7"]["type"] != "stdio" || vscode["remote"]["type"] != "http" {
+			t.Errorf("Expected VS Code types, got %v and %v", vscode["context7"], vscode["remote"])
+		}
+		data, err := os.ReadFile(vscodePath)
+		if err != nil || !strings.Contains(string(data), `"inputs": []`) {
+			t.Errorf("Expected other keys to be preserved, got %s (%v)", data, err)
+		}
+		if values, err := loadEnvFile(SecretsPath(targetDir)); err != nil || values["TWENTYFIRST_API_KEY"] != "tf-\"secret\"" {
+			t.Errorf("Expected the secret to be stored, got %v (%v)", values, err)
+		}
+
+		removed, err := removeMCPClientServers(mcpClients[MCPTargetVSCode], vscodePath, []string{"magic", "missing"})
+		if err != nil || !reflect.DeepEqual(removed, []string{"magic"}) {
+			t.Errorf("Expected magic to be removed, got %v (%v)", removed, err)
+		}
+		if vscode := readServers(t, vscodePath, "servers"); vscode["magic"] != nil || vscode["mine"] == nil {
+			t.Errorf("Expected only magic to be removed, got %v", vscode)
+		}
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		targetDir := t.TempDir()
+		writeTestFiles(t, targetDir, map[string]string{
+			".cursor/mcp.json": `{"mcpServers": {"context7": {"command": "custom"}}}`,
+		})
+		cursorPath := filepath.Join(targetDir, ".cursor", "mcp.json")
+
+		if _, _, err := writeMCPClientConfigs(targetDir, []MCPTarget{MCPTargetCursor}, selected[:1], repoPath, MCPConflictKeep, env, nil); err != nil {
+			t.Fatalf("writeMCPClientConfigs failed: %v", err)
+		}
+		if got := readServers(t, cursorPath, "mcpServers")["context7"]["command"]; got != "custom" {
+			t.Errorf("Expected keep to preserve the entry, got %v", got)
+		}
+
+		if _, _, err := writeMCPClientConfigs(targetDir, []MCPTarget{MCPTargetCursor}, selected[:1], repoPath, MCPConflictOverwrite, env, nil); err != nil {
+			t.Fatalf("writeMCPClientConfigs failed: %v", err)
+		}
+		if got := readServers(t, cursorPath, "mcpServers")["context7"]["command"]; got != "npx" {
+			t.Errorf("Expected overwrite to replace the entry, got %v", got)
+		}
+	})
+
+	t.Run("ClaudeDesktop", func(t *testing.T) {
+		targetDir := t.TempDir()
+		desktopPath := filepath.Join(home, ".config", "Claude", "claude_desktop_config.json")
+		writeTestFiles(t, filepath.Dir(desktopPath), map[string]string{
+			"claude_desktop_config.json": `{"globalShortcut": "Ctrl+Space", "mcpServers": {}}`,
+		})
+
+		written, _, err := writeMCPClientConfigs(targetDir, []MCPTarget{MCPTargetClaudeDesktop},
+			append(selected, remote), repoPath, MCPConflictKeep, env, nil)
+		if err != nil {
+			t.Fatalf("writeMCPClientConfigs failed: %v", err)
+		}
+		if !reflect.DeepEqual(written, []string{desktopPath}) {
+			t.Errorf("Expected %s, got %v", desktopPath, written)
+		}
+
+		desktop := readServers(t, desktopPath, "mcpServers")
+		if got := desktop["magic"]["env"]; !reflect.DeepEqual(got, map[string]interface{}{"TWENTYFIRST_API_KEY": "tf-\"secret\""}) {
+			t.Errorf("Expected the secret to be expanded, got %v", got)
+		}
+		if desktop["remote"] != nil {
+			t.Errorf("Expected the remote server to be skipped, got %v", desktop["remote"])
+		}
+		data, err := os.ReadFile(desktopPath)
+		if err != nil || !strings.Contains(string(data), `"globalShortcut": "Ctrl+Space"`) {
+			t.Errorf("Expected other settings to be preserved, got %s (%v)", data, err)
+		}
+	})
+}
+
+// TestBackupFileNameCollision validates that files sharing a name get distinct backups
+func TestBackupFileNameCollision(t *testing.T) {
+	targetDir := t.TempDir()
+	writeTestFiles(t, targetDir, map[string]string{
+		".cursor/mcp.json": `{"mcpServers": {}}`,
+		".vscode/mcp.json": `{"servers": {}}`,
+	})
+	bm := &BackupManager{BackupDir: filepath.Join(targetDir, "backup"), Files: make(map[string]string)}
+
+	for _, target := range []MCPTarget{MCPTargetCursor, MCPTargetVSCode} {
+		path, err := mcpClients[target].Path(targetDir)
+		if err != nil {
+			t.Fatalf("Path failed: %v", err)
+		}
+		if err := bm.BackupFile(path); err != nil {
+			t.Fatalf("BackupFile failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(bm.BackupDir, ".vscode_mcp.json"))
+	if err != nil || string(data) != `{"servers": {}}` {
+		t.Errorf("Expected a separate backup for .vscode/mcp.json, got %q (%v)", data, err)
+	}
+	if len(bm.Files) != 2 {
+		t.Errorf("Expected two backups, got %v", bm.Files)
+	}
+}
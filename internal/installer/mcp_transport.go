@@ -0,0 +1,128 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MCPTransport is how Claude Code talks to an MCP server
+type MCPTransport string
+
+const (
+	// MCPTransportStdio launches command with args and env and speaks over stdin/stdout
+	MCPTransportStdio MCPTransport = "stdio"
+	// MCPTransportHTTP connects to url using the streamable HTTP transport
+	MCPTransportHTTP MCPTransport = "http"
+	// MCPTransportSSE connects to url using the legacy server-sent events transport
+	MCPTransportSSE MCPTransport = "sse"
+)
+
+// MCPServerConfig is one entry of mcpServers in .mcp.json
+type MCPServerConfig struct {
+	Type    string            `json:"type,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Transport returns the configured transport; entries without a type are stdio
+func (c MCPServerConfig) Transport() MCPTransport {
+	if c.Type == "" {
+		return MCPTransportStdio
+	}
+	return MCPTransport(c.Type)
+}
+
+// Validate checks that the fields required by the transport are present and that
+// fields belonging to another transport are not
+func (c MCPServerConfig) Validate() []string {
+	var problems []string
+	switch c.Transport() {
+	case MCPTransportStdio:
+		if strings.TrimSpace(c.Command) == "" {
+			problems = append(problems, "command is required for stdio servers")
+		}
+		if c.URL != "" || c.Headers != nil {
+			problems = append(problems, `url and headers need type "http" or "sse"`)
+		}
+	case MCPTransportHTTP, MCPTransportSSE:
+		if c.URL == "" {
+			problems = append(problems, fmt.Sprintf("url is required for %s servers", c.Type))
+		} else if !validRemoteURL(c.URL) {
+			problems = append(problems, fmt.Sprintf("url %q must be an http or https URL", c.URL))
+		}
+		if c.Command != "" || c.Args != nil || c.Env != nil {
+			problems = append(problems, fmt.Sprintf("command, args and env are not used by %s servers", c.Type))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown type %q (expected stdio, http or sse)", c.Type))
+	}
+	return problems
+}
+
+// validRemoteURL accepts http(s) URLs, allowing ${VAR} references in any part. A URL
+// starting with a reference cannot be checked until the variable is set.
+func validRemoteURL(raw string) bool {
+	if match := envReferencePattern.FindStringIndex(raw); match != nil && match[0] == 0 {
+		return true
+	}
+	raw = envReferencePattern.ReplaceAllString(raw, "x")
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// parseMCPServerConfig decodes and validates one server definition
+func parseMCPServerConfig(key string, raw json.RawMessage) (MCPServerConfig, error) {
+	var serverConfig MCPServerConfig
+	if err := json.Unmarshal(raw, &serverConfig); err != nil {
+		return MCPServerConfig{}, fmt.Errorf("%s: %w", key, err)
+	}
+	if problems := serverConfig.Validate(); len(problems) > 0 {
+		return MCPServerConfig{}, fmt.Errorf("%s: %s", key, strings.Join(problems, "; "))
+	}
+	return serverConfig, nil
+}
+
+// validateMCPServerConfigs validates every definition in a config file's entries
+func validateMCPServerConfigs(entries map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := parseMCPServerConfig(key, entries[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mcpTransportOf returns the transport of a raw definition, or "" if it cannot be decoded
+func mcpTransportOf(raw json.RawMessage) MCPTransport {
+	var serverConfig MCPServerConfig
+	if err := json.Unmarshal(raw, &serverConfig); err != nil {
+		return ""
+	}
+	return serverConfig.Transport()
+}
+
+// readConfiguredMCPTransports returns the transport of each server in .mcp.json
+func readConfiguredMCPTransports(mcpPath string) (map[string]MCPTransport, error) {
+	mcpConfig, _, err := readMCPConfig(mcpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	transports := make(map[string]MCPTransport, len(mcpConfig.MCPServers))
+	for key, definition := range mcpConfig.MCPServers {
+		transports[key] = definition.Transport()
+	}
+	return transports, nil
+}
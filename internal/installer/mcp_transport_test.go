@@ -0,0 +1,112 @@
+package installer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMCPServerConfigValidate validates the required and forbidden fields per transport
+func TestMCPServerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string // Substring of the error, empty when valid
+	}{
+		{"Stdio", `{"command": "npx", "args": ["-y", "server"], "env": {"TOKEN": "${TOKEN}"}}`, ""},
+		{"ExplicitStdio", `{"type": "stdio", "command": "server"}`, ""},
+		{"HTTP", `{"type": "http", "url": "https://example.com/mcp", "headers": {"Authorization": "Bearer ${TOKEN}"}}`, ""},
+		{"SSEWithReference", `{"type": "sse", "url": "${SSE_URL}"}`, ""},
+		{"MissingCommand", `{"args": ["server"]}`, "command is required for stdio servers"},
+		{"URLWithoutType", `{"url": "https://example.com/mcp"}`, `url and headers need type "http" or "sse"`},
+		{"MissingURL", `{"type": "http"}`, "url is required for http servers"},
+		{"BadURL", `{"type": "sse", "url": "ftp://example.com"}`, "must be an http or https URL"},
+		{"CommandOnRemote", `{"type": "http", "url": "https://example.com", "command": "server"}`, "command, args and env are not used by http servers"},
+		{"UnknownType", `{"type": "websocket", "url": "wss://example.com"}`, `unknown type "websocket"`},
+		{"WrongFieldType", `{"command": ["server"]}`, "cannot unmarshal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMCPServerConfig("server", json.RawMessage(tt.raw))
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected a valid config, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestRemoteMCPServers validates that remote registry servers are listed with their
+// transport and that invalid .mcp.json entries are reported
+func TestRemoteMCPServers(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	targetDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude/CLAUDE.md": "# SuperClaude Entry Point\n",
+		".mcp.json":              `{"mcpServers": {"broken": {"type": "sse"}, "docs": {"type": "sse", "url": "https://docs.example.com/sse"}}}`,
+		".superclaude-lite/mcp-servers.json": `{"servers": [
+			{"name": "Tracker", "config": {"type": "http", "url": "https://tracker.example.com/mcp", "headers": {"Authorization": "Bearer "}}}
+		]}`,
+	})
+
+	manager, err := NewMCPManager(targetDir, repoPath)
+	if err != nil {
+		t.Fatalf("NewMCPManager failed: %v", err)
+	}
+	originalPrompt := promptSecret
+	defer func() { promptSecret = originalPrompt }()
+	promptSecret = func(MCPEnvRequirement) (string, error) { return "tracker-token", nil }
+
+	changes, err := manager.Add([]string{"tracker"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(changes) != 1 || !reflect.DeepEqual(changes[0].Env, []MCPEnvRequirement{{Server: "tracker", Header: "Authorization", Variable: "TRACKER_TOKEN"}}) {
+		t.Errorf("Expected the Authorization header to need TRACKER_TOKEN, got %+v", changes)
+	}
+	values, err := loadEnvFile(SecretsPath(targetDir))
+	if err != nil || values["TRACKER_TOKEN"] != "tracker-token" {
+		t.Errorf("Expected the token to be stored, got %v (%v)", values, err)
+	}
+
+	statuses, err := manager.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	transports := make(map[string]string)
+	for _, status := range statuses {
+		transports[status.Name] = status.Transport
+	}
+	expected := map[string]string{"context7": "stdio", "serena": "stdio", "tracker": "http", "broken": "sse", "docs": "sse"}
+	if !reflect.DeepEqual(transports, expected) {
+		t.Errorf("Expected transports %v, got %v", expected, transports)
+	}
+
+	problems, err := ValidateMCPConfigFile(targetDir)
+	if err != nil {
+		t.Fatalf("ValidateMCPConfigFile failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Error() != ".mcp.json:1:27: mcpServers.broken: url is required for sse servers" {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+
+	t.Run("InvalidRegistryTransport", func(t *testing.T) {
+		writeTestFiles(t, targetDir, map[string]string{
+			".superclaude-lite/mcp-servers.json": `{"servers": [{"name": "Tracker", "config": {"type": "http", "command": "tracker"}}]}`,
+		})
+		_, err := loadMCPRegistry(filepath.Join(ProjectConfigDir(targetDir), "mcp-servers.json"), MCPSourceProject)
+		if err == nil || !strings.Contains(err.Error(), "url is required for http servers") {
+			t.Errorf("Expected a transport validation error, got %v", err)
+		}
+	})
+}
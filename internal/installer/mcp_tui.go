@@ -3,20 +3,31 @@ package installer
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // Define key bindings
 var keys = struct {
-	up    key.Binding
-	down  key.Binding
-	space key.Binding
-	enter key.Binding
-	quit  key.Binding
-	help  key.Binding
+	up       key.Binding
+	down     key.Binding
+	pageUp   key.Binding
+	pageDown key.Binding
+	space    key.Binding
+	all      key.Binding
+	none     key.Binding
+	filter   key.Binding
+	preview  key.Binding
+	enter    key.Binding
+	quit     key.Binding
+	help     key.Binding
 }{
 	up: key.NewBinding(
 		key.WithKeys("up", "k"),
@@ -26,10 +37,34 @@ var keys = struct {
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "down"),
 	),
+	pageUp: key.NewBinding(
+		key.WithKeys("pgup", "ctrl+u"),
+		key.WithHelp("pgup", "scroll docs up"),
+	),
+	pageDown: key.NewBinding(
+		key.WithKeys("pgdown", "ctrl+d"),
+		key.WithHelp("pgdn", "scroll docs down"),
+	),
 	space: key.NewBinding(
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle"),
 	),
+	all: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "select all shown"),
+	),
+	none: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "deselect all shown"),
+	),
+	filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	preview: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "show docs (narrow terminals)"),
+	),
 	enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "confirm"),
@@ -61,28 +96,72 @@ var (
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#626262"))
 
+	previewStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderForeground(lipgloss.Color("#626262")).
+			PaddingLeft(1)
+
 	checkboxChecked   = "[✓]"
 	checkboxUnchecked = "[ ]"
 )
 
+const (
+	// mcpPreviewMinWidth is the terminal width from which the docs are shown beside the list
+	mcpPreviewMinWidth = 100
+	// mcpListWidth is the width of the server list when the docs are shown beside it
+	mcpListWidth = 48
+)
+
 // MCPSelectorModel represents the TUI state for MCP server selection
 type MCPSelectorModel struct {
-	servers   []MCPServer
-	cursor    int
-	selected  map[int]bool
+	servers  []MCPServer
+	repoPath string       // Where the MCP_*.md files for the preview are read from
+	selected map[int]bool // By index in servers
+
+	visible []int // Indexes of the servers matching the filter
+	cursor  int   // Position in visible
+	offset  int   // First row of visible on screen
+
+	filter     textinput.Model
+	filtering  bool
+	showHelp   bool
+	previewing bool // Narrow terminals show the docs instead of the list
+	preview    viewport.Model
+	previewOf  int            // Server shown in preview, -1 for none
+	docs       map[int]string // Rendered docs by server index
+
+	width     int
+	height    int
 	quitting  bool
 	confirmed bool
 }
 
-// NewMCPSelector creates a new MCP selector TUI model
-func NewMCPSelector(servers []MCPServer) MCPSelectorModel {
-	return MCPSelectorModel{
+// NewMCPSelector creates a new MCP selector TUI model. Servers marked Selected, such
+// as those already in .mcp.json, start checked.
+func NewMCPSelector(servers []MCPServer, repoPath string) MCPSelectorModel {
+	selected := make(map[int]bool)
+	for i, server := range servers {
+		if server.Selected {
+			selected[i] = true
+		}
+	}
+
+	filter := textinput.New()
+	filter.Prompt = "/ "
+	filter.Placeholder = "filter by name, description or tag"
+
+	m := MCPSelectorModel{
 		servers:   servers,
-		cursor:    0,
-		selected:  make(map[int]bool),
-		quitting:  false,
-		confirmed: false,
+		repoPath:  repoPath,
+		selected:  selected,
+		filter:    filter,
+		preview:   viewport.New(0, 0),
+		previewOf: -1,
+		docs:      make(map[int]string),
 	}
+	m.applyFilter()
+	return m
 }
 
 func (m MCPSelectorModel) Init() tea.Cmd {
@@ -91,25 +170,81 @@ func (m MCPSelectorModel) Init() tea.Cmd {
 
 func (m MCPSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		// Docs are wrapped to the pane width
+		m.docs = make(map[int]string)
+		m.previewOf = -1
+
 	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		if m.showHelp {
+			// Any key closes the help overlay
+			m.showHelp = false
+			return m, nil
+		}
+
 		switch {
 		case key.Matches(msg, keys.quit):
-			m.quitting = true
-			return m, tea.Quit
+			switch {
+			case msg.String() == "esc" && m.previewing:
+				m.previewing = false
+			case msg.String() == "esc" && m.filter.Value() != "":
+				m.filter.SetValue("")
+				m.applyFilter()
+			default:
+				m.quitting = true
+				return m, tea.Quit
+			}
+
+		case key.Matches(msg, keys.help):
+			m.showHelp = true
+
+		case key.Matches(msg, keys.filter):
+			m.filtering = true
+			m.previewing = false
+			return m, m.filter.Focus()
+
+		case key.Matches(msg, keys.preview):
+			m.previewing = !m.previewing && !m.wide()
 
 		case key.Matches(msg, keys.up):
-			if m.cursor > 0 {
+			if m.previewing {
+				m.preview.ScrollUp(1)
+			} else if m.cursor > 0 {
 				m.cursor--
 			}
 
 		case key.Matches(msg, keys.down):
-			if m.cursor < len(m.servers)-1 {
+			if m.previewing {
+				m.preview.ScrollDown(1)
+			} else if m.cursor < len(m.visible)-1 {
 				m.cursor++
 			}
 
+		case key.Matches(msg, keys.pageUp):
+			m.preview.HalfPageUp()
+
+		case key.Matches(msg, keys.pageDown):
+			m.preview.HalfPageDown()
+
 		case key.Matches(msg, keys.space):
 			// Toggle selection
-			m.selected[m.cursor] = !m.selected[m.cursor]
+			if index, ok := m.current(); ok {
+				m.selected[index] = !m.selected[index]
+			}
+
+		case key.Matches(msg, keys.all):
+			for _, index := range m.visible {
+				m.selected[index] = true
+			}
+
+		case key.Matches(msg, keys.none):
+			for _, index := range m.visible {
+				delete(m.selected, index)
+			}
 
 		case key.Matches(msg, keys.enter):
 			// Confirm selections
@@ -118,24 +253,189 @@ func (m MCPSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	m.sync()
 	return m, nil
 }
 
-func (m MCPSelectorModel) View() string {
-	if m.quitting {
-		return "\nCancelled MCP server selection.\n"
+// updateFilter handles keys while the filter input has focus: enter keeps the filter,
+// esc clears it and the arrows still move through the matches
+func (m MCPSelectorModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filter.Blur()
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.SetValue("")
+		m.applyFilter()
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown:
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+	default:
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
 	}
 
-	if m.confirmed {
-		selectedCount := 0
-		for _, isSelected := range m.selected {
-			if isSelected {
-				selectedCount++
-			}
+	m.sync()
+	return m, cmd
+}
+
+// applyFilter recomputes the servers matching the filter, keeping the cursor on the
+// same server when it still matches
+func (m *MCPSelectorModel) applyFilter() {
+	current, hasCurrent := m.current()
+
+	m.visible = nil
+	for i, server := range m.servers {
+		if matchMCPServer(m.filter.Value(), server) {
+			m.visible = append(m.visible, i)
 		}
-		return fmt.Sprintf("\nSelected %d MCP servers for installation.\n", selectedCount)
 	}
 
+	m.cursor, m.offset = 0, 0
+	for position, index := range m.visible {
+		if hasCurrent && index == current {
+			m.cursor = position
+		}
+	}
+}
+
+// sync scrolls the list to keep the cursor on screen and loads the docs of the
+// server under it into the preview
+func (m *MCPSelectorModel) sync() {
+	rows := m.listRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+
+	if !m.wide() && !m.previewing {
+		return
+	}
+	m.preview.Width, m.preview.Height = m.previewSize()
+	index, ok := m.current()
+	if !ok || index == m.previewOf {
+		return
+	}
+	if _, rendered := m.docs[index]; !rendered {
+		m.docs[index] = m.renderPreview(m.servers[index], m.preview.Width)
+	}
+	m.preview.SetContent(m.docs[index])
+	m.preview.GotoTop()
+	m.previewOf = index
+}
+
+// current returns the index in servers of the server under the cursor
+func (m MCPSelectorModel) current() (int, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return 0, false
+	}
+	return m.visible[m.cursor], true
+}
+
+// wide reports whether the docs fit beside the list
+func (m MCPSelectorModel) wide() bool {
+	return m.width >= mcpPreviewMinWidth
+}
+
+// listRows returns how many servers fit on screen; all of them before the terminal
+// size is known
+func (m MCPSelectorModel) listRows() int {
+	if m.height == 0 {
+		return max(len(m.visible), 1)
+	}
+	// The footer's last line has no newline, and two rows are kept for the more indicators
+	chrome := strings.Count(m.header(), "\n") + strings.Count(m.footer(), "\n") + 3
+	return max(m.height-chrome, 1)
+}
+
+// previewSize returns the size of the docs pane
+func (m MCPSelectorModel) previewSize() (int, int) {
+	if m.wide() {
+		return m.width - mcpListWidth - 3, m.listRows() + 2
+	}
+	return m.width, max(m.height-strings.Count(m.header(), "\n")-2, 1)
+}
+
+// renderPreview renders a server's details and MCP_*.md for the preview pane
+func (m MCPSelectorModel) renderPreview(server MCPServer, width int) string {
+	var b strings.Builder
+	b.WriteString(selectedStyle.Render(server.Name))
+	b.WriteString("\n")
+	for _, detail := range mcpServerDetails(server) {
+		b.WriteString(helpStyle.Render(detail))
+		b.WriteString("\n")
+	}
+
+	doc := readMCPDoc(m.repoPath, server)
+	if doc == "" {
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("No MCP_*.md instructions for this server."))
+		return b.String()
+	}
+	// The front matter is already shown as details
+	b.WriteString(renderMarkdown(parseMCPFrontMatter(doc, &mcpDocMetadata{}), width))
+	return b.String()
+}
+
+// renderMarkdown renders markdown for the terminal, falling back to the plain text
+func renderMarkdown(doc string, width int) string {
+	style := styles.DarkStyle
+	if noColor() {
+		style = styles.NoTTYStyle
+	}
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithWordWrap(max(width-2, 20)),
+	)
+	if err != nil {
+		return doc
+	}
+	rendered, err := renderer.Render(doc)
+	if err != nil {
+		return doc
+	}
+	return rendered
+}
+
+// matchMCPServer reports whether every word of the filter fuzzy-matches the server's
+// name or appears in its description, runtime or tags
+func matchMCPServer(filter string, server MCPServer) bool {
+	text := strings.ToLower(strings.Join(append([]string{server.DisplayName, server.Description, server.Runtime}, server.Tags...), " "))
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		if !fuzzyMatch(word, strings.ToLower(server.Name)) && !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in text in order
+func fuzzyMatch(pattern, text string) bool {
+	for _, r := range pattern {
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// header renders the title, instructions and filter
+func (m MCPSelectorModel) header() string {
 	var b strings.Builder
 
 	// Title
@@ -144,35 +444,157 @@ func (m MCPSelectorModel) View() string {
 
 	// Instructions
 	b.WriteString(helpStyle.Render("Use ↑/↓ to navigate, space to select/deselect, enter to confirm"))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	if m.filtering || m.filter.Value() != "" {
+		b.WriteString(m.filter.View())
+		b.WriteString(helpStyle.Render(fmt.Sprintf("  %d of %d", len(m.visible), len(m.servers))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// footer renders the details of the server under the cursor on narrow terminals
+// and the key help
+func (m MCPSelectorModel) footer() string {
+	var b strings.Builder
+
+	if !m.wide() {
+		if index, ok := m.current(); ok {
+			for _, detail := range mcpServerDetails(m.servers[index]) {
+				b.WriteString(helpStyle.Render("    " + detail))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	// Footer help
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press 'q' to quit, 'enter' to proceed with selection, '/' to filter, '?' for help"))
+
+	return b.String()
+}
+
+// list renders the servers on screen
+func (m MCPSelectorModel) list() string {
+	var b strings.Builder
+
+	if len(m.visible) == 0 {
+		b.WriteString(helpStyle.Render("No servers match the filter"))
+		b.WriteString("\n")
+	}
+
+	end := min(m.offset+m.listRows(), len(m.visible))
+	if m.offset > 0 {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("  ↑ %d more", m.offset)))
+		b.WriteString("\n")
+	}
+	for position := m.offset; position < end; position++ {
+		server := m.servers[m.visible[position]]
 
-	// Server list
-	for i, server := range m.servers {
 		// Determine checkbox state
 		checkbox := checkboxUnchecked
-		if m.selected[i] {
+		if m.selected[m.visible[position]] {
 			checkbox = checkboxChecked
 		}
 
 		// Determine style based on cursor position
 		style := unselectedStyle
-		if i == m.cursor {
+		if position == m.cursor {
 			style = selectedStyle
 		}
+		if m.wide() {
+			style = style.MaxWidth(mcpListWidth)
+		}
 
 		// Format line
-		line := fmt.Sprintf("%s %s", checkbox, server.DisplayName)
+		line := fmt.Sprintf("%s %s (%s)", checkbox, server.DisplayName, MCPSourceLabel(server.Source))
 		b.WriteString(style.Render(line))
 		b.WriteString("\n")
 	}
+	if end < len(m.visible) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.visible)-end)))
+		b.WriteString("\n")
+	}
 
-	// Footer help
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press 'q' to quit, 'enter' to proceed with selection"))
+	return b.String()
+}
 
+// helpView renders the help overlay listing every key
+func (m MCPSelectorModel) helpView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("MCP Selector Keys"))
+	b.WriteString("\n\n")
+	for _, binding := range []key.Binding{keys.up, keys.down, keys.space, keys.all, keys.none, keys.filter,
+		keys.pageUp, keys.pageDown, keys.preview, keys.enter, keys.quit, keys.help} {
+		help := binding.Help()
+		b.WriteString(fmt.Sprintf("  %-8s %s\n", help.Key, help.Desc))
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("In the filter, enter keeps it and esc clears it. Press any key to close this help."))
 	return b.String()
 }
 
+func (m MCPSelectorModel) View() string {
+	if m.quitting {
+		return "\nCancelled MCP server selection.\n"
+	}
+
+	if m.confirmed {
+		selectedCount := 0
+		for _, isSelected := range m.selected {
+			if isSelected {
+				selectedCount++
+			}
+		}
+		return fmt.Sprintf("\nSelected %d MCP servers for installation.\n", selectedCount)
+	}
+
+	if m.showHelp {
+		return m.helpView()
+	}
+
+	if m.previewing {
+		return m.header() + m.preview.View() + "\n" + helpStyle.Render("Press tab or esc to return to the list")
+	}
+
+	body := m.list()
+	if m.wide() {
+		body = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(mcpListWidth).Render(strings.TrimSuffix(body, "\n")),
+			previewStyle.Render(m.preview.View()),
+		) + "\n"
+	}
+
+	return m.header() + body + m.footer()
+}
+
+// mcpServerDetails returns the description and the runtime, env and tags lines shown
+// for a server in the selector
+func mcpServerDetails(server MCPServer) []string {
+	var details []string
+	if server.Description != "" && server.Description != server.DisplayName {
+		details = append(details, server.Description)
+	}
+
+	var facts []string
+	if server.Runtime != "" {
+		facts = append(facts, "runtime: "+server.Runtime)
+	}
+	if len(server.Env) > 0 {
+		facts = append(facts, "env: "+strings.Join(server.Env, ", "))
+	}
+	if len(server.Tags) > 0 {
+		facts = append(facts, "tags: "+strings.Join(server.Tags, ", "))
+	}
+	if len(facts) > 0 {
+		details = append(details, strings.Join(facts, " · "))
+	}
+	return details
+}
+
 // GetSelectedServers returns the selected servers with their selection state updated
 func (m MCPSelectorModel) GetSelectedServers() []MCPServer {
 	var selected []MCPServer
@@ -185,9 +607,15 @@ func (m MCPSelectorModel) GetSelectedServers() []MCPServer {
 	return selected
 }
 
-// ShowMCPSelector displays the TUI and returns the selected servers
-func ShowMCPSelector(servers []MCPServer) ([]MCPServer, error) {
-	model := NewMCPSelector(servers)
+// ShowMCPSelector displays the TUI and returns the selected servers. Without a capable
+// terminal it asks with a numbered list instead, and with --yes it keeps the preselected
+// servers.
+func ShowMCPSelector(servers []MCPServer, repoPath string) ([]MCPServer, error) {
+	if !useTUI() {
+		return selectMCPServersByNumber(servers)
+	}
+
+	model := NewMCPSelector(servers, repoPath)
 
 	program := tea.NewProgram(model)
 	finalModel, err := program.Run()
@@ -204,3 +632,31 @@ func ShowMCPSelector(servers []MCPServer) ([]MCPServer, error) {
 
 	return final.GetSelectedServers(), nil
 }
+
+// selectMCPServersByNumber asks for the servers with the line-based checklist
+func selectMCPServersByNumber(servers []MCPServer) ([]MCPServer, error) {
+	items := make([]string, len(servers))
+	checked := make([]bool, len(servers))
+	for i, server := range servers {
+		items[i] = server.Name
+		if server.Description != "" {
+			items[i] += " - " + server.Description
+		}
+		checked[i] = server.Selected
+	}
+
+	hint := fmt.Sprintf("pass --mcp with server names, all or none (available: %s)", strings.Join(mcpServerNames(servers), ", "))
+	chosen, err := promptMultiSelect("Select MCP servers to install:", items, checked, hint)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []MCPServer
+	for i, server := range servers {
+		if chosen[i] {
+			server.Selected = true
+			selected = append(selected, server)
+		}
+	}
+	return selected, nil
+}
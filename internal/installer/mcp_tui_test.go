@@ -0,0 +1,134 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestMCPSelector validates filtering, bulk selection, help, scrolling and the docs
+// preview of the MCP selector
+func TestMCPSelector(t *testing.T) {
+	press := func(t *testing.T, m MCPSelectorModel, keys ...string) MCPSelectorModel {
+		t.Helper()
+		for _, k := range keys {
+			var msg tea.KeyMsg
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			case "tab":
+				msg = tea.KeyMsg{Type: tea.KeyTab}
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			model, _ := m.Update(msg)
+			m = model.(MCPSelectorModel)
+		}
+		return m
+	}
+	names := func(servers []MCPServer) string {
+		var result []string
+		for _, server := range servers {
+			result = append(result, server.Name)
+		}
+		return strings.Join(result, ",")
+	}
+
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{
+		"SuperClaude/MCP/MCP_Magic.md":       "---\ndescription: UI components from 21st.dev\ntags: [ui, frontend]\n---\n# Magic MCP Server\n\n## Usage\n\nGenerate components.\n",
+		"SuperClaude/MCP/configs/magic.json": `{"magic": {"command": "npx", "args": ["-y", "@21st-dev/magic-mcp@latest"]}}`,
+	})
+	servers, err := DiscoverMCPServers(repoPath)
+	if err != nil {
+		t.Fatalf("DiscoverMCPServers failed: %v", err)
+	}
+
+	t.Run("PreselectConfigured", func(t *testing.T) {
+		mcpPath := filepath.Join(t.TempDir(), ".mcp.json")
+		writeTestFiles(t, filepath.Dir(mcpPath), map[string]string{".mcp.json": `{"mcpServers": {"serena": {"command": "uvx"}}}`})
+		preselected := append([]MCPServer(nil), servers...)
+		preselectConfiguredMCPServers(preselected, repoPath, mcpPath)
+
+		m := press(t, NewMCPSelector(preselected, repoPath), "enter")
+		if got := names(m.GetSelectedServers()); got != "Serena" {
+			t.Errorf("Expected Serena to start checked, got %q", got)
+		}
+	})
+
+	t.Run("FilterAndBulkSelect", func(t *testing.T) {
+		m := press(t, NewMCPSelector(servers, repoPath), "/", "f", "r", "o", "n", "t", "enter", "a")
+		if got := names(m.GetSelectedServers()); got != "Magic" {
+			t.Errorf("Expected the tag filter to match Magic only, got %q", got)
+		}
+		if view := m.View(); !strings.Contains(view, "1 of 3") {
+			t.Errorf("Expected the match count, got:\n%s", view)
+		}
+
+		m = press(t, m, "esc", "a")
+		if got := names(m.GetSelectedServers()); got != "Context7,Magic,Serena" {
+			t.Errorf("Expected all servers after clearing the filter, got %q", got)
+		}
+		m = press(t, m, "/", "c", "t", "x", "7", "enter", "n")
+		if got := names(m.GetSelectedServers()); got != "Magic,Serena" {
+			t.Errorf("Expected the fuzzy name match to be deselected, got %q", got)
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		m := press(t, NewMCPSelector(servers, repoPath), "?")
+		if view := m.View(); !strings.Contains(view, "select all shown") {
+			t.Errorf("Expected the help overlay, got:\n%s", view)
+		}
+		if m = press(t, m, "q"); m.quitting || m.showHelp {
+			t.Errorf("Expected a key to close the help without quitting")
+		}
+	})
+
+	t.Run("Scrolling", func(t *testing.T) {
+		var many []MCPServer
+		for i := range 30 {
+			many = append(many, MCPServer{Name: fmt.Sprintf("S%02d", i), DisplayName: fmt.Sprintf("Server %02d", i)})
+		}
+		model, _ := NewMCPSelector(many, repoPath).Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+		m := model.(MCPSelectorModel)
+		for range 25 {
+			m = press(t, m, "down")
+		}
+
+		view := m.View()
+		if strings.Contains(view, "Server 00") || !strings.Contains(view, "Server 25") || !strings.Contains(view, "↑") {
+			t.Errorf("Expected the list to scroll to the cursor, got:\n%s", view)
+		}
+		if lines := strings.Count(view, "\n") + 1; lines > 20 {
+			t.Errorf("Expected the view to fit the terminal, got %d lines", lines)
+		}
+	})
+
+	t.Run("Preview", func(t *testing.T) {
+		model, _ := NewMCPSelector(servers, repoPath).Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+		m := press(t, model.(MCPSelectorModel), "down")
+		if view := ansi.Strip(m.View()); !strings.Contains(view, "Generate components") || strings.Contains(view, "---") {
+			t.Errorf("Expected Magic's docs beside the list, got:\n%s", view)
+		}
+
+		model, _ = NewMCPSelector(servers, repoPath).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+		m = press(t, model.(MCPSelectorModel), "down", "tab")
+		if view := ansi.Strip(m.View()); !m.previewing || !strings.Contains(view, "Generate components") {
+			t.Errorf("Expected tab to show the docs on a narrow terminal, got:\n%s", view)
+		}
+		if m = press(t, m, "esc"); m.previewing || m.quitting {
+			t.Errorf("Expected esc to return to the list")
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package installer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StepEventKind is what happened to an installation step
+type StepEventKind int
+
+const (
+	// StepStarted is sent before a step executes
+	StepStarted StepEventKind = iota
+	// StepCompleted is sent after a step and its validation succeed
+	StepCompleted
+	// StepFailed is sent when a step or its validation returns an error
+	StepFailed
+	// StepPaused is sent when a running step hands the terminal to a prompt or selector
+	StepPaused
+	// StepResumed is sent when the prompt or selector returns
+	StepResumed
+)
+
+// StepEvent reports the progress of one step of Installer.Install
+type StepEvent struct {
+	Kind    StepEventKind
+	Step    string        // Step name, e.g. CloneRepository
+	Title   string        // Human-readable step title
+	Index   int           // Position in the execution order, from 1
+	Total   int           // Number of steps in the execution order
+	Elapsed time.Duration // Time spent in the step, less prompts (completed and failed only)
+	Files   int           // Files the step copied (completed only)
+	Err     error         // Failure (failed only)
+}
+
+// with returns a copy of the event of another kind
+func (e StepEvent) with(kind StepEventKind) StepEvent {
+	e.Kind = kind
+	return e
+}
+
+// StepObserver receives step events on the goroutine running the installation
+type StepObserver func(StepEvent)
+
+// progressPause pauses the running step's progress before a prompt or selector uses the
+// terminal and returns the function that resumes it. Install sets one per step; nil, as
+// passed by the mcp commands, does nothing.
+type progressPause func() (resume func())
+
+// pause calls p if it is set
+func (p progressPause) pause() (resume func()) {
+	if p == nil {
+		return func() {}
+	}
+	return p()
+}
+
+// logStepEvent is the observer used without SetObserver, keeping the log lines
+func logStepEvent(event StepEvent) {
+	switch event.Kind {
+	case StepStarted:
+		log.Printf("Executing step: %s", event.Step)
+	case StepCompleted:
+		log.Printf("Completed step: %s", event.Step)
+	}
+}
+
+var (
+	progressDoneStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#02BA84"))
+	progressFailedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+	progressSpinner     = spinner.Dot
+)
+
+// ProgressDisplay renders step events. Live, it animates a spinner on the running
+// step and prints the step's own output above it; otherwise it prints a line as each
+// step starts and finishes, for logs and pipes.
+type ProgressDisplay struct {
+	out  *os.File
+	live bool
+
+	mu      sync.Mutex
+	running *StepEvent // Step under the spinner, nil while idle or paused
+	started time.Time
+	frame   int
+	stop    chan struct{}
+	ticking sync.WaitGroup
+
+	capture   *os.File // Write end replacing os.Stdout while a step runs
+	stdout    *os.File // os.Stdout before the capture
+	forwarded sync.WaitGroup
+}
+
+// NewProgressDisplay creates a display writing to out, animated when live is set
+func NewProgressDisplay(out *os.File, live bool) *ProgressDisplay {
+	return &ProgressDisplay{out: out, live: live}
+}
+
+// Observe renders one step event; pass it to Installer.SetObserver
+func (d *ProgressDisplay) Observe(event StepEvent) {
+	if !d.live {
+		d.printPlain(event)
+		return
+	}
+
+	switch event.Kind {
+	case StepStarted, StepResumed:
+		d.startSpinner(event)
+		d.captureOutput()
+	case StepPaused:
+		d.releaseOutput()
+		d.stopSpinner()
+	case StepCompleted, StepFailed:
+		d.releaseOutput()
+		d.stopSpinner()
+		fmt.Fprintln(d.out, formatStepResult(event, true))
+	}
+}
+
+// printPlain writes the event as a line without escape codes
+func (d *ProgressDisplay) printPlain(event StepEvent) {
+	switch event.Kind {
+	case StepStarted:
+		fmt.Fprintf(d.out, "[%d/%d] %s...\n", event.Index, event.Total, event.Title)
+	case StepCompleted, StepFailed:
+		fmt.Fprintf(d.out, "[%d/%d] %s\n", event.Index, event.Total, formatStepResult(event, false))
+	}
+}
+
+// formatStepResult renders a finished step with its marker, elapsed time and file count
+func formatStepResult(event StepEvent, styled bool) string {
+	details := []string{formatElapsed(event.Elapsed)}
+	if event.Files > 0 {
+		details = append(details, fmt.Sprintf("%d files", event.Files))
+	}
+	line := fmt.Sprintf("%s (%s)", event.Title, strings.Join(details, ", "))
+
+	marker, style := "✓", progressDoneStyle
+	if event.Kind == StepFailed {
+		marker, style = "✗", progressFailedStyle
+		line += ": " + event.Err.Error()
+	}
+	if styled {
+		return style.Render(marker) + " " + line
+	}
+	return marker + " " + line
+}
+
+// formatElapsed rounds a duration for display, e.g. 12ms or 3.4s
+func formatElapsed(elapsed time.Duration) string {
+	switch {
+	case elapsed < time.Millisecond:
+		return "<1ms"
+	case elapsed < time.Second:
+		return elapsed.Round(time.Millisecond).String()
+	}
+	return elapsed.Round(100 * time.Millisecond).String()
+}
+
+// startSpinner animates the spinner line for the step until stopSpinner
+func (d *ProgressDisplay) startSpinner(event StepEvent) {
+	d.mu.Lock()
+	d.running = &event
+	if event.Kind == StepStarted {
+		d.started = time.Now()
+	}
+	d.stop = make(chan struct{})
+	d.drawSpinner()
+	d.mu.Unlock()
+
+	stop := d.stop
+	d.ticking.Add(1)
+	go func() {
+		defer d.ticking.Done()
+		ticker := time.NewTicker(progressSpinner.FPS)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.mu.Lock()
+				d.frame = (d.frame + 1) % len(progressSpinner.Frames)
+				d.drawSpinner()
+				d.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// stopSpinner stops the animation and clears the spinner line
+func (d *ProgressDisplay) stopSpinner() {
+	if d.stop == nil {
+		return
+	}
+	close(d.stop)
+	d.ticking.Wait()
+
+	d.mu.Lock()
+	d.stop = nil
+	d.running = nil
+	fmt.Fprint(d.out, "\r\x1b[K")
+	d.mu.Unlock()
+}
+
+// drawSpinner redraws the spinner line; the caller holds mu
+func (d *ProgressDisplay) drawSpinner() {
+	if d.running == nil {
+		return
+	}
+	fmt.Fprintf(d.out, "\r\x1b[K%s %s %s", progressSpinner.Frames[d.frame], d.running.Title,
+		helpStyle.Render(formatElapsed(time.Since(d.started))))
+}
+
+// captureOutput points os.Stdout at a pipe so the step's output is printed above the
+// spinner line instead of through it
+func (d *ProgressDisplay) captureOutput() {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return // Output stays on the terminal and may share the spinner line
+	}
+	d.capture, d.stdout = writer, os.Stdout
+	os.Stdout = writer
+
+	d.forwarded.Add(1)
+	go func() {
+		defer d.forwarded.Done()
+		defer func() { _ = reader.Close() }()
+		d.forward(reader)
+	}()
+}
+
+// forward prints each captured line above the spinner
+func (d *ProgressDisplay) forward(reader io.Reader) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		d.mu.Lock()
+		fmt.Fprintf(d.out, "\r\x1b[K%s\n", scanner.Text())
+		d.drawSpinner()
+		d.mu.Unlock()
+	}
+}
+
+// releaseOutput restores os.Stdout once the captured output has been printed
+func (d *ProgressDisplay) releaseOutput() {
+	if d.capture == nil {
+		return
+	}
+	os.Stdout = d.stdout
+	_ = d.capture.Close()
+	d.capture = nil
+	d.forwarded.Wait()
+}
@@ -0,0 +1,178 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestInstallStepEvents validates the events Install sends for each step, including
+// file counts, pauses around selectors and failures
+func TestInstallStepEvents(t *testing.T) {
+	repoPath := createTestFrameworkRepo(t)
+	writeTestFiles(t, repoPath, map[string]string{"SuperClaude/Agents/system-architect.md": "# System Architect\n"})
+
+	originalSelector := selectComponents
+	defer func() { selectComponents = originalSelector }()
+	selectComponents = func(components, preselected []Component) ([]Component, error) {
+		return preselected, nil
+	}
+
+	t.Run("Completed", func(t *testing.T) {
+		cfg := &InstallConfig{FrameworkDir: repoPath, Components: ComponentSelection{Interactive: true}}
+		installer, err := NewInstaller(t.TempDir(), cfg)
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		var events []StepEvent
+		installer.SetObserver(func(event StepEvent) { events = append(events, event) })
+		if err := installer.Install(); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		var trace []string
+		files := make(map[string]int)
+		for _, event := range events {
+			if event.Total != 17 || event.Title == "" {
+				t.Errorf("Expected a titled event out of 17 steps, got %+v", event)
+			}
+			switch event.Kind {
+			case StepStarted:
+				trace = append(trace, "start "+event.Step)
+			case StepPaused:
+				trace = append(trace, "pause "+event.Step)
+			case StepResumed:
+				trace = append(trace, "resume "+event.Step)
+			case StepCompleted:
+				trace = append(trace, "done "+event.Step)
+				files[event.Step] = event.Files
+			}
+		}
+		if len(trace) != 36 || trace[0] != "start CheckPrerequisites" || trace[35] != "done CleanupTempFiles" {
+			t.Errorf("Expected a start and done per step plus one pause, got %v", trace)
+		}
+		if !strings.Contains(strings.Join(trace, ","), "start CopyCoreFiles,pause CopyCoreFiles,resume CopyCoreFiles,done CopyCoreFiles") {
+			t.Errorf("Expected the component selector to pause CopyCoreFiles, got %v", trace)
+		}
+
+		expected := map[string]int{"CopyCoreFiles": 3, "CopyCommandFiles": 1, "CopyAgentFiles": 1, "CopyModeFiles": 5, "CloneRepository": 0}
+		for step, count := range expected {
+			if files[step] != count {
+				t.Errorf("Expected %d files for %s, got %d", count, step, files[step])
+			}
+		}
+	})
+
+	t.Run("PausedInsideStep", func(t *testing.T) {
+		writeTestFiles(t, repoPath, map[string]string{
+			"SuperClaude/MCP/MCP_Magic.md":       "# Magic\n",
+			"SuperClaude/MCP/configs/magic.json": `{"magic": {"command": "npx", "env": {"TWENTYFIRST_API_KEY": ""}}}`,
+		})
+		t.Setenv("TWENTYFIRST_API_KEY", "")
+		originalPrompt := promptSecret
+		defer func() { promptSecret = originalPrompt }()
+
+		var trace []string
+		promptSecret = func(requirement MCPEnvRequirement) (string, error) {
+			trace = append(trace, "prompt")
+			return "", nil
+		}
+
+		cfg := &InstallConfig{FrameworkDir: repoPath, AddRecommendedMCP: true, MCPServers: []string{"magic"}}
+		installer, err := NewInstaller(t.TempDir(), cfg)
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		installer.SetObserver(func(event StepEvent) {
+			if event.Step == "MergeOrCreateMCPConfig" {
+				trace = append(trace, [...]string{"start", "done", "failed", "pause", "resume"}[event.Kind])
+			}
+		})
+		if err := installer.Install(); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		if strings.Join(trace, ",") != "start,pause,prompt,resume,done" {
+			t.Errorf("Expected the secret prompt to pause the step, got %v", trace)
+		}
+		if installer.GetContext().pauseProgress != nil {
+			t.Errorf("Expected the pause hook to be cleared after Install")
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		cfg := &InstallConfig{FrameworkDir: filepath.Join(t.TempDir(), "missing")}
+		installer, err := NewInstaller(t.TempDir(), cfg)
+		if err != nil {
+			t.Fatalf("NewInstaller failed: %v", err)
+		}
+		var last StepEvent
+		installer.SetObserver(func(event StepEvent) { last = event })
+
+		err = installer.Install()
+		if err == nil || last.Kind != StepFailed || last.Step != "CloneRepository" || !errors.Is(last.Err, err) {
+			t.Errorf("Expected CloneRepository to fail with %v, got %+v", err, last)
+		}
+	})
+}
+
+// TestProgressDisplay validates the plain lines and the live output capture
+func TestProgressDisplay(t *testing.T) {
+	started := StepEvent{Kind: StepStarted, Step: "CopyModeFiles", Title: "Copy modes", Index: 10, Total: 17}
+	completed := started.with(StepCompleted)
+	completed.Elapsed, completed.Files = 1200*time.Millisecond, 5
+	failed := started.with(StepFailed)
+	failed.Elapsed, failed.Err = 12*time.Millisecond, fmt.Errorf("permission denied")
+
+	render := func(t *testing.T, live bool, events ...StepEvent) string {
+		t.Helper()
+		out, err := os.Create(filepath.Join(t.TempDir(), "out"))
+		if err != nil {
+			t.Fatalf("Failed to create output: %v", err)
+		}
+		defer func() { _ = out.Close() }()
+
+		display := NewProgressDisplay(out, live)
+		for _, event := range events {
+			display.Observe(event)
+			if event.Kind == StepStarted || event.Kind == StepResumed {
+				fmt.Printf("output of %s\n", event.Step)
+			}
+		}
+
+		data, err := os.ReadFile(out.Name())
+		if err != nil {
+			t.Fatalf("Failed to read output: %v", err)
+		}
+		return string(data)
+	}
+
+	t.Run("Plain", func(t *testing.T) {
+		expected := "[10/17] Copy modes...\n[10/17] ✓ Copy modes (1.2s, 5 files)\n[10/17] ✗ Copy modes (12ms): permission denied\n"
+		stdout := os.Stdout
+		os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		defer func() { os.Stdout = stdout }()
+
+		if got := render(t, false, started, completed, failed); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("Live", func(t *testing.T) {
+		stdout := os.Stdout
+		got := render(t, true, started, started.with(StepPaused), started.with(StepResumed), completed)
+		if os.Stdout != stdout {
+			t.Fatalf("Expected os.Stdout to be restored after the step")
+		}
+		if count := strings.Count(got, "output of CopyModeFiles\n"); count != 2 {
+			t.Errorf("Expected the step's output before and after the pause, got %q", got)
+		}
+		if !strings.HasSuffix(got, "\r\x1b[K✓ Copy modes (1.2s, 5 files)\n") {
+			t.Errorf("Expected the completed line to replace the spinner, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package installer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// PromptOptions controls how commands ask questions, from --yes, --no-input and the
+// environment
+type PromptOptions struct {
+	AssumeYes bool // Take the default answers, and yes for confirmations, without asking
+	NoInput   bool // Never read stdin; questions fail naming the flag that answers them
+}
+
+// promptMode is how questions reach the user
+type promptMode int
+
+const (
+	promptTUI  promptMode = iota // Full-screen selectors on a capable terminal
+	promptLine                   // Numbered menus and questions read a line at a time
+	promptNone                   // Nothing can be asked
+)
+
+var (
+	promptOptions PromptOptions
+
+	// promptInput is shared by all prompts so buffered piped answers aren't lost
+	promptInput = bufio.NewReader(os.Stdin)
+
+	// promptOutput is where questions go; stdout as it was before any progress capture
+	promptOutput io.Writer = os.Stdout
+)
+
+// ConfigurePrompts sets the prompt options for the rest of the command
+func ConfigurePrompts(options PromptOptions) {
+	promptOptions = options
+}
+
+// stdinIsTerminal reports whether standard input is an interactive terminal
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(os.Stdin.Fd())
+}
+
+// stdoutIsTerminal reports whether standard output is an interactive terminal
+var stdoutIsTerminal = func() bool {
+	return term.IsTerminal(os.Stdout.Fd())
+}
+
+// currentPromptMode uses the full-screen selectors only on a terminal that can show
+// them. Piped input, TERM=dumb and ACCESSIBLE (screen readers) get line prompts, and
+// --no-input or CI without a terminal gets none, so nothing waits on a closed stdin.
+func currentPromptMode() promptMode {
+	switch {
+	case promptOptions.NoInput:
+		return promptNone
+	case !stdinIsTerminal():
+		if os.Getenv("CI") != "" {
+			return promptNone
+		}
+		return promptLine
+	case !LiveOutput():
+		return promptLine
+	}
+	return promptTUI
+}
+
+// LiveOutput reports whether stdout is a terminal that can redraw lines, as the
+// full-screen selectors and the progress spinner do. TERM=dumb and ACCESSIBLE (screen
+// readers) get plain lines instead.
+func LiveOutput() bool {
+	return stdoutIsTerminal() && os.Getenv("TERM") != "dumb" && os.Getenv("ACCESSIBLE") == ""
+}
+
+// useTUI reports whether a question should open a full-screen selector
+func useTUI() bool {
+	return !promptOptions.AssumeYes && currentPromptMode() == promptTUI
+}
+
+// noColor reports whether NO_COLOR asks for output without colors
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// promptUnavailable explains that a question can't be asked and how to answer it instead
+func promptUnavailable(question, hint string) error {
+	reason := "without a terminal"
+	if promptOptions.NoInput {
+		reason = "with --no-input"
+	}
+	return fmt.Errorf("cannot ask %q %s: %s", question, reason, hint)
+}
+
+// PromptConfirm asks a yes/no question that defaults to no; --yes answers yes. hint
+// names the flag that confirms without asking.
+func PromptConfirm(question, hint string) (bool, error) {
+	if promptOptions.AssumeYes {
+		return true, nil
+	}
+	if currentPromptMode() == promptNone {
+		return false, promptUnavailable(question, hint)
+	}
+
+	answer, err := readPromptLine(question+" [y/N]: ", question, hint)
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// promptChoice asks for one of options by number or name; the first is the default and
+// the answer with --yes
+func promptChoice(question string, options []string, hint string) (int, error) {
+	if promptOptions.AssumeYes {
+		return 0, nil
+	}
+	if currentPromptMode() == promptNone {
+		return 0, promptUnavailable(question, hint)
+	}
+
+	fmt.Fprintf(promptOutput, "%s\n", question)
+	for i, option := range options {
+		suffix := ""
+		if i == 0 {
+			suffix = " (default)"
+		}
+		fmt.Fprintf(promptOutput, "  %d) %s%s\n", i+1, option, suffix)
+	}
+
+	for {
+		answer, err := readPromptLine(fmt.Sprintf("Choice [1-%d]: ", len(options)), question, hint)
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return 0, nil
+		}
+		if choice, ok := parseChoice(answer, options); ok {
+			return choice, nil
+		}
+		fmt.Fprintf(promptOutput, "Enter a number from 1 to %d\n", len(options))
+	}
+}
+
+// parseChoice matches an answer against the option numbers and the options' first words
+func parseChoice(answer string, options []string) (int, bool) {
+	if n, err := strconv.Atoi(answer); err == nil {
+		return n - 1, n >= 1 && n <= len(options)
+	}
+	answer = strings.ToLower(answer)
+	for i, option := range options {
+		word, _, _ := strings.Cut(strings.ToLower(option), " ")
+		if answer == word || (len(answer) == 1 && strings.HasPrefix(word, answer)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// promptMultiSelect shows a numbered checklist and returns the checked items, starting
+// from selected; --yes keeps selected
+func promptMultiSelect(title string, items []string, selected []bool, hint string) ([]bool, error) {
+	if promptOptions.AssumeYes {
+		return selected, nil
+	}
+	if currentPromptMode() == promptNone {
+		return nil, promptUnavailable(title, hint)
+	}
+
+	fmt.Fprintf(promptOutput, "%s\n", title)
+	for i, item := range items {
+		checkbox := checkboxUnchecked
+		if selected[i] {
+			checkbox = "[x]"
+		}
+		fmt.Fprintf(promptOutput, "  %d) %s %s\n", i+1, checkbox, item)
+	}
+
+	for {
+		answer, err := readPromptLine("Numbers separated by commas, all or none (enter keeps the [x] items): ", title, hint)
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return selected, nil
+		}
+		chosen, err := parseSelection(answer, len(items))
+		if err != nil {
+			fmt.Fprintf(promptOutput, "%v\n", err)
+			continue
+		}
+		return chosen, nil
+	}
+}
+
+// parseSelection reads "1,3", "1 3", "all" or "none" into a checklist of count items
+func parseSelection(answer string, count int) ([]bool, error) {
+	chosen := make([]bool, count)
+	switch strings.ToLower(answer) {
+	case "all":
+		for i := range chosen {
+			chosen[i] = true
+		}
+		return chosen, nil
+	case "none":
+		return chosen, nil
+	}
+
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > count {
+			return nil, fmt.Errorf("%q is not a number from 1 to %d", field, count)
+		}
+		chosen[n-1] = true
+	}
+	return chosen, nil
+}
+
+// promptSecretValue asks for a value without echoing it on a terminal. It returns an
+// empty value, to be set later, when nothing can be asked or with --yes.
+func promptSecretValue(question string) (string, error) {
+	if promptOptions.AssumeYes || currentPromptMode() == promptNone {
+		return "", nil
+	}
+
+	fmt.Fprintf(promptOutput, "%s: ", question)
+	if !stdinIsTerminal() {
+		// Piped secrets aren't echoed, and running out of input leaves the value for later
+		answer, err := readPromptInput(question, "")
+		if err != nil {
+			return "", nil
+		}
+		fmt.Fprintf(promptOutput, "\n")
+		return answer, nil
+	}
+
+	value, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintf(promptOutput, "\n")
+	if err != nil {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// readPromptLine prints prompt and reads one answer, echoing piped answers so logs
+// show what was chosen
+func readPromptLine(prompt, question, hint string) (string, error) {
+	fmt.Fprint(promptOutput, prompt)
+	answer, err := readPromptInput(question, hint)
+	if err != nil {
+		return "", err
+	}
+	if !stdinIsTerminal() {
+		fmt.Fprintln(promptOutput, answer)
+	}
+	return answer, nil
+}
+
+// readPromptInput reads one answer line; running out of piped input is an error
+// naming the flag that answers the question instead
+func readPromptInput(question, hint string) (string, error) {
+	line, err := promptInput.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		fmt.Fprintln(promptOutput)
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("no answer to %q on stdin: %s", question, hint)
+		}
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,154 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPrompts validates the prompt modes, the line-based menus and the errors naming
+// the flag to pass when nothing can be asked
+func TestPrompts(t *testing.T) {
+	originalOptions, originalInput, originalOutput := promptOptions, promptInput, promptOutput
+	originalStdin, originalStdout := stdinIsTerminal, stdoutIsTerminal
+	defer func() {
+		promptOptions, promptInput, promptOutput = originalOptions, originalInput, originalOutput
+		stdinIsTerminal, stdoutIsTerminal = originalStdin, originalStdout
+	}()
+
+	// answer pipes the answers to the next prompts and returns what they print
+	answer := func(t *testing.T, answers string) *bytes.Buffer {
+		t.Helper()
+		t.Setenv("CI", "")
+		stdinIsTerminal = func() bool { return false }
+		promptInput = bufio.NewReader(strings.NewReader(answers))
+		output := &bytes.Buffer{}
+		promptOutput = output
+		ConfigurePrompts(PromptOptions{})
+		return output
+	}
+
+	t.Run("Mode", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			options  PromptOptions
+			stdin    bool
+			stdout   bool
+			env      map[string]string
+			expected promptMode
+			live     bool // Progress spinner instead of plain lines
+		}{
+			{"Terminal", PromptOptions{}, true, true, nil, promptTUI, true},
+			{"PipedInput", PromptOptions{}, false, true, nil, promptLine, true},
+			{"PipedOutput", PromptOptions{}, true, false, nil, promptLine, false},
+			{"DumbTerminal", PromptOptions{}, true, true, map[string]string{"TERM": "dumb"}, promptLine, false},
+			{"ScreenReader", PromptOptions{}, true, true, map[string]string{"ACCESSIBLE": "1"}, promptLine, false},
+			{"CI", PromptOptions{}, false, false, map[string]string{"CI": "true"}, promptNone, false},
+			{"NoInput", PromptOptions{NoInput: true}, true, true, nil, promptNone, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Setenv("TERM", "xterm-256color")
+				t.Setenv("ACCESSIBLE", "")
+				t.Setenv("CI", "")
+				for key, value := range tt.env {
+					t.Setenv(key, value)
+				}
+				stdinIsTerminal = func() bool { return tt.stdin }
+				stdoutIsTerminal = func() bool { return tt.stdout }
+				ConfigurePrompts(tt.options)
+
+				if got := currentPromptMode(); got != tt.expected {
+					t.Errorf("Expected mode %d, got %d", tt.expected, got)
+				}
+				if got := LiveOutput(); got != tt.live {
+					t.Errorf("Expected live output %v, got %v", tt.live, got)
+				}
+			})
+		}
+	})
+
+	t.Run("NumberedMenus", func(t *testing.T) {
+		output := answer(t, "4\n2\nx\n1 3\n\n")
+
+		choice, err := promptChoice("Resolve \"serena\":", []string{"keep existing", "overwrite", "rename"}, "use --mcp-conflict")
+		if err != nil || choice != 1 {
+			t.Errorf("Expected a re-ask after 4 and then overwrite, got %d %v", choice, err)
+		}
+		if !strings.Contains(output.String(), "  1) keep existing (default)") || !strings.Contains(output.String(), "Enter a number from 1 to 3") {
+			t.Errorf("Expected the numbered menu and a hint, got:\n%s", output)
+		}
+
+		items := []string{"Context7", "Magic", "Serena"}
+		chosen, err := promptMultiSelect("Select MCP servers to install:", items, []bool{true, false, false}, "pass --mcp")
+		if err != nil || !reflect.DeepEqual(chosen, []bool{true, false, true}) {
+			t.Errorf("Expected items 1 and 3 after an invalid answer, got %v %v", chosen, err)
+		}
+		if !strings.Contains(output.String(), "  1) [x] Context7") || !strings.Contains(output.String(), "1 3\n") {
+			t.Errorf("Expected the checklist and the piped answer echoed, got:\n%s", output)
+		}
+
+		chosen, err = promptMultiSelect("Select MCP servers to install:", items, []bool{false, true, false}, "pass --mcp")
+		if err != nil || !reflect.DeepEqual(chosen, []bool{false, true, false}) {
+			t.Errorf("Expected enter to keep the defaults, got %v %v", chosen, err)
+		}
+	})
+
+	t.Run("SelectorFallback", func(t *testing.T) {
+		answer(t, "all\n")
+		servers, err := DiscoverMCPServers(createTestFrameworkRepo(t))
+		if err != nil {
+			t.Fatalf("DiscoverMCPServers failed: %v", err)
+		}
+		selected, err := ShowMCPSelector(servers, "")
+		if err != nil || len(selected) != len(servers) || !selected[0].Selected {
+			t.Errorf("Expected every server from the line menu, got %v %v", selected, err)
+		}
+	})
+
+	t.Run("InputRunsOut", func(t *testing.T) {
+		answer(t, "")
+		_, err := PromptConfirm("Continue?", "use --force or --yes")
+		if err == nil || !strings.Contains(err.Error(), "use --force or --yes") {
+			t.Errorf("Expected the flag to pass when stdin is empty, got %v", err)
+		}
+		if value, err := promptSecret(MCPEnvRequirement{Variable: "API_KEY", Server: "magic"}); value != "" || err != nil {
+			t.Errorf("Expected a secret to be left for later, got %q %v", value, err)
+		}
+	})
+
+	t.Run("NoInput", func(t *testing.T) {
+		answer(t, "y\n")
+		ConfigurePrompts(PromptOptions{NoInput: true})
+		_, err := promptMCPConflict("serena", nil)
+		if err == nil || !strings.Contains(err.Error(), "--no-input") || !strings.Contains(err.Error(), "--mcp-conflict") {
+			t.Errorf("Expected --no-input to fail naming --mcp-conflict, got %v", err)
+		}
+		if _, err := promptMCPPinBump(2); err == nil || !strings.Contains(err.Error(), "use --yes") {
+			t.Errorf("Expected --no-input to fail a pin bump naming --yes, got %v", err)
+		}
+	})
+
+	t.Run("AssumeYes", func(t *testing.T) {
+		output := answer(t, "")
+		ConfigurePrompts(PromptOptions{AssumeYes: true, NoInput: true})
+		if confirmed, err := PromptConfirm("Continue?", "use --yes"); !confirmed || err != nil {
+			t.Errorf("Expected --yes to confirm, got %v %v", confirmed, err)
+		}
+		if strategy, err := promptMCPConflict("serena", nil); strategy != MCPConflictKeep || err != nil {
+			t.Errorf("Expected --yes to keep the existing entry, got %q %v", strategy, err)
+		}
+		if confirmed, err := promptMCPPinBump(2); !confirmed || err != nil {
+			t.Errorf("Expected --yes to confirm pin bumps, got %v %v", confirmed, err)
+		}
+		if _, err := ShowInitWizard(t.TempDir(), InstallConfig{}, nil, nil); err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("Expected the wizard to name --yes as the reason it can't run, got %v", err)
+		}
+		if output.Len() != 0 {
+			t.Errorf("Expected --yes not to print prompts, got:\n%s", output)
+		}
+	})
+}
@@ -1,25 +1,56 @@
 package installer
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/dgnsrekt/super-claude-lite/internal/config"
 	"github.com/dgnsrekt/super-claude-lite/internal/git"
+	"github.com/dgnsrekt/super-claude-lite/internal/jsonedit"
 )
 
 // selectMCPServers is a function variable that can be overridden for testing
-var selectMCPServers = func(servers []MCPServer) ([]MCPServer, error) {
-	return ShowMCPSelector(servers)
+var selectMCPServers = func(servers []MCPServer, repoPath string) ([]MCPServer, error) {
+	return ShowMCPSelector(servers, repoPath)
+}
+
+// preselectConfiguredMCPServers marks the servers whose entries are already in
+// .mcp.json, so the selector starts with them checked
+func preselectConfiguredMCPServers(servers []MCPServer, repoPath, mcpPath string) {
+	configured, err := readConfiguredMCPServers(mcpPath)
+	if err != nil || len(configured) == 0 {
+		return
+	}
+	for i, server := range servers {
+		entries, err := server.LoadConfigRaw(repoPath)
+		if err != nil {
+			continue
+		}
+		for key := range entries {
+			if slices.Contains(configured, key) {
+				servers[i].Selected = true
+			}
+		}
+	}
+}
+
+// selectComponents is a function variable that can be overridden for testing
+var selectComponents = func(components, preselected []Component) ([]Component, error) {
+	return ShowComponentSelector(components, preselected)
 }
 
 // InstallStep represents a single step in the installation process
 type InstallStep struct {
 	Name     string
+	Title    string // Shown in the progress display
 	Execute  func(*InstallContext) error
 	Validate func(*InstallContext) error
 }
@@ -27,23 +58,23 @@ type InstallStep struct {
 // GetInstallSteps returns all installation steps
 func GetInstallSteps() map[string]*InstallStep {
 	return map[string]*InstallStep{
-		"CheckPrerequisites":       {Name: "CheckPrerequisites", Execute: checkPrerequisites, Validate: nil},
-		"ScanExistingFiles":        {Name: "ScanExistingFiles", Execute: scanExistingFiles, Validate: nil},
-		"CreateBackups":            {Name: "CreateBackups", Execute: createBackups, Validate: nil},
-		"CheckTargetDirectory":     {Name: "CheckTargetDirectory", Execute: checkTargetDirectory, Validate: nil},
-		"CloneRepository":          {Name: "CloneRepository", Execute: cloneRepository, Validate: validateRepoCloned},
-		"CreateDirectoryStructure": {Name: "CreateDirectoryStructure", Execute: createDirectoryStructure, Validate: nil},
-		"CopyCoreFiles":            {Name: "CopyCoreFiles", Execute: copyCoreFiles, Validate: validateCoreFiles},
-		"CopyCommandFiles":         {Name: "CopyCommandFiles", Execute: copyCommandFiles, Validate: validateCommandFiles},
-		"CopyAgentFiles":           {Name: "CopyAgentFiles", Execute: copyAgentFiles, Validate: validateAgentFiles},
-		"CopyModeFiles":            {Name: "CopyModeFiles", Execute: copyModeFiles, Validate: validateModeFiles},
-		"CopyMCPFiles":             {Name: "CopyMCPFiles", Execute: copyMCPFiles, Validate: nil},
-		"MergeOrCreateCLAUDEmd":    {Name: "MergeOrCreateCLAUDEmd", Execute: mergeOrCreateCLAUDEmd, Validate: nil},
-		"MergeOrCreateMCPConfig":   {Name: "MergeOrCreateMCPConfig", Execute: mergeOrCreateMCPConfig, Validate: nil},
-		"CreateCommandSymlink":     {Name: "CreateCommandSymlink", Execute: createCommandSymlink, Validate: nil},
-		"CreateAgentSymlink":       {Name: "CreateAgentSymlink", Execute: createAgentSymlink, Validate: nil},
-		"ValidateInstallation":     {Name: "ValidateInstallation", Execute: validateInstallation, Validate: nil},
-		"CleanupTempFiles":         {Name: "CleanupTempFiles", Execute: cleanupTempFiles, Validate: nil},
+		"CheckPrerequisites":       {Name: "CheckPrerequisites", Title: "Check prerequisites", Execute: checkPrerequisites, Validate: nil},
+		"ScanExistingFiles":        {Name: "ScanExistingFiles", Title: "Scan existing files", Execute: scanExistingFiles, Validate: nil},
+		"CreateBackups":            {Name: "CreateBackups", Title: "Back up existing files", Execute: createBackups, Validate: nil},
+		"CheckTargetDirectory":     {Name: "CheckTargetDirectory", Title: "Check target directory", Execute: checkTargetDirectory, Validate: nil},
+		"CloneRepository":          {Name: "CloneRepository", Title: "Clone SuperClaude Framework", Execute: cloneRepository, Validate: validateRepoCloned},
+		"CreateDirectoryStructure": {Name: "CreateDirectoryStructure", Title: "Create .superclaude directories", Execute: createDirectoryStructure, Validate: nil},
+		"CopyCoreFiles":            {Name: "CopyCoreFiles", Title: "Copy core files", Execute: copyCoreFiles, Validate: validateCoreFiles},
+		"CopyCommandFiles":         {Name: "CopyCommandFiles", Title: "Copy commands", Execute: copyCommandFiles, Validate: validateCommandFiles},
+		"CopyAgentFiles":           {Name: "CopyAgentFiles", Title: "Copy agents", Execute: copyAgentFiles, Validate: validateAgentFiles},
+		"CopyModeFiles":            {Name: "CopyModeFiles", Title: "Copy modes", Execute: copyModeFiles, Validate: validateModeFiles},
+		"CopyMCPFiles":             {Name: "CopyMCPFiles", Title: "Copy MCP server docs", Execute: copyMCPFiles, Validate: nil},
+		"MergeOrCreateCLAUDEmd":    {Name: "MergeOrCreateCLAUDEmd", Title: "Write the CLAUDE.md import", Execute: mergeOrCreateCLAUDEmd, Validate: nil},
+		"MergeOrCreateMCPConfig":   {Name: "MergeOrCreateMCPConfig", Title: "Write MCP configuration", Execute: mergeOrCreateMCPConfig, Validate: nil},
+		"CreateCommandSymlink":     {Name: "CreateCommandSymlink", Title: "Link .claude/commands/sc", Execute: createCommandSymlink, Validate: nil},
+		"CreateAgentSymlink":       {Name: "CreateAgentSymlink", Title: "Link .claude/agents/sc", Execute: createAgentSymlink, Validate: nil},
+		"ValidateInstallation":     {Name: "ValidateInstallation", Title: "Validate installation", Execute: validateInstallation, Validate: nil},
+		"CleanupTempFiles":         {Name: "CleanupTempFiles", Title: "Clean up temporary files", Execute: cleanupTempFiles, Validate: nil},
 	}
 }
 
@@ -77,10 +108,12 @@ func createBackups(ctx *InstallContext) error {
 
 	filesToBackup := []string{
 		filepath.Join(ctx.TargetDir, config.CLAUDEFile),
+		filepath.Join(ctx.TargetDir, config.ClaudeLocalFile),
 		filepath.Join(ctx.TargetDir, config.MCPConfigFile),
 		filepath.Join(ctx.TargetDir, config.SuperClaudeDir),
 		filepath.Join(ctx.TargetDir, config.ClaudeDir),
 	}
+	filesToBackup = append(filesToBackup, mcpClientConfigPaths(ctx.TargetDir, ctx.Config.MCPTargets)...)
 
 	for _, file := range filesToBackup {
 		if err := ctx.BackupManager.BackupFile(file); err != nil {
@@ -106,15 +139,28 @@ func cloneRepository(ctx *InstallContext) error {
 		return nil
 	}
 
-	tempDir, err := git.GetTempCloneDir()
-	if err != nil {
-		return err
-	}
+	// The wizard has already fetched the framework; its caller removes the checkout
+	if ctx.Config.FrameworkDir != "" {
+		ctx.RepoPath = ctx.Config.FrameworkDir
+	} else {
+		tempDir, err := git.GetTempCloneDir()
+		if err != nil {
+			return err
+		}
 
-	ctx.TempDir = tempDir
-	ctx.RepoPath = tempDir
+		ctx.TempDir = tempDir
+		ctx.RepoPath = tempDir
 
-	return git.CloneRepository(tempDir)
+		if err := git.CloneRepositoryAt(tempDir, ctx.Config.FrameworkRef); err != nil {
+			return err
+		}
+	}
+
+	// A branch moves on, so record the commit it points at now
+	if commit, err := git.HeadCommit(ctx.RepoPath); err == nil {
+		ctx.FrameworkCommit = commit
+	}
+	return nil
 }
 
 func createDirectoryStructure(ctx *InstallContext) error {
@@ -151,37 +197,98 @@ func createDirectoryStructure(ctx *InstallContext) error {
 func copyCoreFiles(ctx *InstallContext) error {
 	if ctx.DryRun {
 		fmt.Printf("[DRY RUN] Would copy core files from %s\n", config.CoreSourcePath)
+		fmt.Printf("[DRY RUN] Would generate .superclaude/CLAUDE.md from the selected core files and modes\n")
 		return nil
 	}
 
 	corePath, _ := git.GetSourcePaths(ctx.RepoPath)
 	targetPath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir)
 
-	if err := copyMarkdownFiles(corePath, targetPath); err != nil {
+	if err := ctx.copyFrameworkFiles(corePath, targetPath); err != nil {
+		return err
+	}
+
+	if err := selectFrameworkComponents(ctx); err != nil {
 		return err
 	}
+	if ctx.skipSuperClaudeDir() {
+		return nil
+	}
+
+	// Generate CLAUDE.md with v4 import structure from the selected components
+	if err := writeSuperClaudeCLAUDEmd(ctx); err != nil {
+		return err
+	}
+
+	fmt.Printf("Importing %d core files and %d modes from .superclaude/CLAUDE.md\n", len(ctx.SelectedCore), len(ctx.SelectedModes))
+	return nil
+}
 
-	// Create CLAUDE.md file with v4 import structure
-	claudePath := filepath.Join(targetPath, "CLAUDE.md")
-	if !fileExists(claudePath) {
-		claudeContent := `# The superclaude CLAUDE.md file uses an import system to load multiple context files:
+// writeSuperClaudeCLAUDEmd renders .superclaude/CLAUDE.md from the superclaude.md.tmpl template
+func writeSuperClaudeCLAUDEmd(ctx *InstallContext) error {
+	content, err := ctx.Templates.RenderFile(SuperClaudeTemplate, newTemplateData(ctx))
+	if err != nil {
+		return err
+	}
 
-*MANDATORY*
-@FLAGS.md # Flag definitions and triggers
-@RULES.md # Core behavioral rules
-@PRINCIPLES.md # Guiding principles
-*CRITICAL*
-@Modes/MODE_Brainstorming.md # Collaborative discovery mode
-@Modes/MODE_Introspection.md # Transparent reasoning mode
-@Modes/MODE_Task_Management.md # Task orchestration mode
-@Modes/MODE_Orchestration.md # Tool coordination mode
-@Modes/MODE_Token_Efficiency.md # Compressed communication mode
-`
-		if err := os.WriteFile(claudePath, []byte(claudeContent), 0644); err != nil {
-			return fmt.Errorf("failed to create CLAUDE.md: %w", err)
+	claudePath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, config.CLAUDEFile)
+	if err := os.WriteFile(claudePath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to create CLAUDE.md: %w", err)
+	}
+
+	return nil
+}
+
+// selectFrameworkComponents resolves which core files and modes .superclaude/CLAUDE.md
+// imports, from flags, the interactive picker or the previously recorded selection
+func selectFrameworkComponents(ctx *InstallContext) error {
+	availableCore, err := DiscoverCoreFiles(ctx.RepoPath)
+	if err != nil {
+		return err
+	}
+	availableModes, err := DiscoverModes(ctx.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	selection := ctx.Config.Components
+	var previousCore, previousModes []string
+	if ctx.PreviousManifest != nil {
+		previousCore, previousModes = ctx.PreviousManifest.Core, ctx.PreviousManifest.Modes
+	}
+
+	core, err := resolveComponents(availableCore, selection.Core, selection.ExcludeCore, previousCore, ComponentCore)
+	if err != nil {
+		return err
+	}
+	modes, err := resolveComponents(availableModes, selection.Modes, selection.ExcludeMode, previousModes, ComponentMode)
+	if err != nil {
+		return err
+	}
+
+	if selection.Interactive {
+		available := append(append([]Component{}, availableCore...), availableModes...)
+		preselected := append(append([]Component{}, core...), modes...)
+
+		resume := ctx.pauseProgress.pause()
+		chosen, err := selectComponents(available, preselected)
+		resume()
+		if err != nil {
+			return fmt.Errorf("failed to select components: %w", err)
+		}
+
+		core, modes = nil, nil
+		for _, component := range chosen {
+			if component.Kind == ComponentMode {
+				modes = append(modes, component)
+			} else {
+				core = append(core, component)
+			}
 		}
 	}
 
+	ctx.SelectedCore = core
+	ctx.SelectedModes = modes
 	return nil
 }
 
@@ -194,7 +301,7 @@ func copyCommandFiles(ctx *InstallContext) error {
 	_, commandsPath := git.GetSourcePaths(ctx.RepoPath)
 	targetPath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, "Commands")
 
-	return copyMarkdownFiles(commandsPath, targetPath)
+	return ctx.copyFrameworkFiles(commandsPath, targetPath)
 }
 
 func copyAgentFiles(ctx *InstallContext) error {
@@ -206,7 +313,7 @@ func copyAgentFiles(ctx *InstallContext) error {
 	agentsPath := filepath.Join(ctx.RepoPath, config.AgentsSourcePath)
 	targetPath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, "Agents")
 
-	return copyMarkdownFiles(agentsPath, targetPath)
+	return ctx.copyFrameworkFiles(agentsPath, targetPath)
 }
 
 func copyModeFiles(ctx *InstallContext) error {
@@ -218,7 +325,7 @@ func copyModeFiles(ctx *InstallContext) error {
 	modesPath := filepath.Join(ctx.RepoPath, config.ModesSourcePath)
 	targetPath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, "Modes")
 
-	return copyMarkdownFiles(modesPath, targetPath)
+	return ctx.copyFrameworkFiles(modesPath, targetPath)
 }
 
 func copyMCPFiles(ctx *InstallContext) error {
@@ -232,8 +339,8 @@ func copyMCPFiles(ctx *InstallContext) error {
 		return nil
 	}
 
-	// Discover available MCP servers
-	servers, err := DiscoverMCPServers(ctx.RepoPath)
+	// Discover available MCP servers, including those from the user and project registries
+	servers, err := DiscoverAllMCPServers(ctx.RepoPath, ctx.TargetDir)
 	if err != nil {
 		return fmt.Errorf("failed to discover MCP servers: %w", err)
 	}
@@ -243,11 +350,22 @@ func copyMCPFiles(ctx *InstallContext) error {
 		return nil
 	}
 
-	// Show TUI for server selection
-	fmt.Printf("Select MCP servers to install:\n")
-	selectedServers, err := selectMCPServers(servers)
-	if err != nil {
-		return fmt.Errorf("failed to select MCP servers: %w", err)
+	// Servers named with --mcp skip the TUI
+	var selectedServers []MCPServer
+	if ctx.Config.MCPServers != nil {
+		selectedServers, err = ResolveMCPServers(servers, ctx.Config.MCPServers)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Select MCP servers to install:\n")
+		preselectConfiguredMCPServers(servers, ctx.RepoPath, filepath.Join(ctx.TargetDir, config.MCPConfigFile))
+		resume := ctx.pauseProgress.pause()
+		selectedServers, err = selectMCPServers(servers, ctx.RepoPath)
+		resume()
+		if err != nil {
+			return fmt.Errorf("failed to select MCP servers: %w", err)
+		}
 	}
 
 	if len(selectedServers) == 0 {
@@ -258,6 +376,22 @@ func copyMCPFiles(ctx *InstallContext) error {
 	// Store selected servers in context for later use
 	ctx.SelectedMCPServers = selectedServers
 
+	// Make sure npx, uvx and friends can start the servers
+	ctx.MCPRuntimeIssues, err = checkSelectedMCPRuntimes(ctx.RepoPath, selectedServers)
+	if err != nil {
+		return err
+	}
+	if ctx.Config.StrictMCP && len(ctx.MCPRuntimeIssues) > 0 {
+		return fmt.Errorf("MCP server runtimes are missing (--strict-mcp):\n%s", formatRuntimeIssues(ctx.MCPRuntimeIssues))
+	}
+
+	if err := ctx.resolveSuperClaudeDir(); err != nil {
+		return err
+	}
+	if ctx.skipSuperClaudeDir() {
+		return nil
+	}
+
 	// Create MCP target directory
 	mcpTargetDir := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, "MCP")
 	if err := os.MkdirAll(mcpTargetDir, 0750); err != nil {
@@ -265,31 +399,27 @@ func copyMCPFiles(ctx *InstallContext) error {
 	}
 
 	// Copy selected MCP files
-	mcpSourceDir := filepath.Join(ctx.RepoPath, "SuperClaude", "MCP")
-	for _, server := range selectedServers {
-		srcFile := filepath.Join(mcpSourceDir, server.MDFile)
-		dstFile := filepath.Join(mcpTargetDir, server.MDFile)
-
-		if err := copyFile(srcFile, dstFile); err != nil {
-			return fmt.Errorf("failed to copy MCP file %s: %w", server.MDFile, err)
-		}
+	if err := copyMCPDocs(ctx.RepoPath, mcpTargetDir, selectedServers); err != nil {
+		return err
 	}
 
+	ctx.copiedFiles += len(selectedServers)
 	fmt.Printf("Copied %d MCP server files\n", len(selectedServers))
 	return nil
 }
 
 func mergeOrCreateCLAUDEmd(ctx *InstallContext) error {
-	// Main project CLAUDE.md (imports from .superclaude)
-	mainClaudePath := filepath.Join(ctx.TargetDir, config.CLAUDEFile)
+	// Memory file that carries the import (CLAUDE.md, .claude/CLAUDE.md or CLAUDE.local.md)
+	importLocation := ctx.ImportLocation
+	mainClaudePath := importLocation.Path(ctx.TargetDir)
 	// SuperClaude internal CLAUDE.md (gets MCP imports added)
 	superClaudePath := filepath.Join(ctx.TargetDir, config.SuperClaudeDir, config.CLAUDEFile)
 
 	if ctx.DryRun {
-		if ctx.ExistingFiles.CLAUDEmd {
-			fmt.Printf("[DRY RUN] Would merge SuperClaude import into existing CLAUDE.md\n")
+		if ctx.ExistingFiles.ImportFile {
+			fmt.Printf("[DRY RUN] Would merge SuperClaude import into existing %s\n", importLocation.RelPath())
 		} else {
-			fmt.Printf("[DRY RUN] Would create new CLAUDE.md\n")
+			fmt.Printf("[DRY RUN] Would create new %s\n", importLocation.RelPath())
 		}
 
 		if len(ctx.SelectedMCPServers) > 0 {
@@ -298,19 +428,152 @@ func mergeOrCreateCLAUDEmd(ctx *InstallContext) error {
 		return nil
 	}
 
-	// Handle main project CLAUDE.md
-	if ctx.ExistingFiles.CLAUDEmd {
-		if err := mergeCLAUDEmd(mainClaudePath); err != nil { // No MCP imports in main file
+	// Handle the memory file holding the import
+	data := newTemplateData(ctx)
+	importBlock, err := ctx.Templates.RenderImportBlock(data)
+	if err != nil {
+		return err
+	}
+
+	action := ConflictMerge
+	hadImport := false
+	if ctx.ExistingFiles.ImportFile {
+		if content, err := os.ReadFile(mainClaudePath); err == nil {
+			hadImport = importLocation.HasSuperClaudeImport(string(content))
+		}
+		if action, err = ctx.resolveImportFileConflict(mainClaudePath, importLocation, importBlock); err != nil {
 			return err
 		}
-	} else {
-		if err := createCLAUDEmd(mainClaudePath); err != nil { // No MCP imports in main file
+	}
+
+	// Record the import as written so clean removes exactly that text. An import that
+	// was already there came from an earlier run, whose record still applies.
+	switch {
+	case hadImport && action != ConflictOverwrite:
+		ctx.ImportBlock, ctx.ImportSkeleton = ctx.PreviousManifest.importRemoval(importLocation)
+	case action == ConflictMerge && ctx.ExistingFiles.ImportFile:
+		ctx.ImportBlock = importBlock
+	case action != ConflictSkip:
+		ctx.ImportBlock, ctx.ImportSkeleton = importBlock, generatedSkeleton(ctx.Templates, data)
+	}
+
+	switch {
+	case action == ConflictSkip:
+		fmt.Printf("Left %s unchanged\n", importLocation.RelPath())
+	case action == ConflictMerge && ctx.ExistingFiles.ImportFile:
+		if err := mergeCLAUDEmd(mainClaudePath, importLocation, importBlock); err != nil { // No MCP imports in main file
 			return err
 		}
+	default:
+		data.ImportBlock = importBlock
+		content, err := ctx.Templates.RenderFile(ProjectClaudeTemplate, data)
+		if err != nil {
+			return err
+		}
+		if err := createCLAUDEmd(mainClaudePath, importLocation, content); err != nil { // No MCP imports in main file
+			return err
+		}
+	}
+
+	// Move the import: drop it from any other location so Claude Code doesn't load it twice.
+	// A skipped memory file didn't receive it, so the other locations keep theirs.
+	for _, other := range FindSuperClaudeImports(ctx.TargetDir) {
+		if other == importLocation || action == ConflictSkip {
+			continue
+		}
+		if _, err := RemoveSuperClaudeImport(ctx.TargetDir, other, ctx.PreviousManifest); err != nil {
+			return err
+		}
+		fmt.Printf("Moved SuperClaude import from %s to %s\n", other.RelPath(), importLocation.RelPath())
+	}
+
+	if importLocation == ImportIntoLocal && !isGitignored(ctx.TargetDir, config.ClaudeLocalFile) {
+		fmt.Printf("Note: %s is not listed in .gitignore; add it to keep the import personal\n", config.ClaudeLocalFile)
+	}
+
+	if ctx.skipSuperClaudeDir() {
+		return nil
+	}
+
+	// Handle .superclaude/CLAUDE.md (add MCP imports here). It is rendered again because
+	// the selected MCP servers are only known after CopyMCPFiles.
+	if err := writeSuperClaudeCLAUDEmd(ctx); err != nil {
+		return err
+	}
+	if err := updateSuperClaudeMCPImports(superClaudePath, ctx.SelectedMCPServers); err != nil {
+		return err
+	}
+
+	// Record the choices so re-running init keeps them
+	return saveInstallManifest(ctx)
+}
+
+// saveInstallManifest records the import location and component selection of this run
+func saveInstallManifest(ctx *InstallContext) error {
+	manifest := &InstallManifest{
+		FrameworkCommit: ctx.frameworkCommit(),
+		FrameworkRef:    ctx.Config.FrameworkRef,
+		ImportInto:      ctx.ImportLocation,
+		ImportBlock:     ctx.ImportBlock,
+		ImportSkeleton:  ctx.ImportSkeleton,
+		Core:            componentNames(ctx.SelectedCore),
+		Modes:           componentNames(ctx.SelectedModes),
+		MCPApprovals:    ctx.mcpApprovals(),
+		MCPServers:      ctx.installedMCP(),
+	}
+
+	return manifest.Save(ctx.TargetDir)
+}
+
+// installedMCP returns the MCP servers recorded earlier together with this run's
+func (ctx *InstallContext) installedMCP() []InstalledMCP {
+	record := &InstallManifest{}
+	if ctx.PreviousManifest != nil {
+		for _, server := range ctx.PreviousManifest.MCPServers {
+			server.Entries = slices.Clone(server.Entries)
+			record.MCPServers = append(record.MCPServers, server)
+		}
 	}
+	record.recordMCPChanges(ctx.SelectedMCPServers, append(slices.Clone(ctx.MCPChanges), ctx.mcpClientChanges...))
+	return record.MCPServers
+}
 
-	// Handle .superclaude/CLAUDE.md (add MCP imports here)
-	return updateSuperClaudeMCPImports(superClaudePath, ctx.SelectedMCPServers)
+// updateManifestMCP records the MCP servers and approvals of this run in the manifest,
+// which MergeOrCreateCLAUDEmd may have written already
+func (ctx *InstallContext) updateManifestMCP() error {
+	if ctx.skipSuperClaudeDir() {
+		return nil
+	}
+	manifest, err := LoadInstallManifest(ctx.TargetDir)
+	if err != nil || manifest == nil {
+		return err
+	}
+	manifest.MCPApprovals = ctx.mcpApprovals()
+	manifest.MCPServers = ctx.installedMCP()
+	return manifest.Save(ctx.TargetDir)
+}
+
+// mcpApprovals returns the approvals recorded earlier together with this run's
+func (ctx *InstallContext) mcpApprovals() []MCPApproval {
+	var previous []MCPApproval
+	if ctx.PreviousManifest != nil {
+		previous = ctx.PreviousManifest.MCPApprovals
+	}
+	if ctx.MCPApproval == nil {
+		return previous
+	}
+	return mergeMCPApprovals(previous, *ctx.MCPApproval)
+}
+
+// approveSelectedMCPServers pre-approves the merged servers in Claude Code's settings
+// and records the approval in the manifest
+func approveSelectedMCPServers(ctx *InstallContext) error {
+	approval, err := approveMCPServers(ctx.TargetDir, ctx.Config.MCPSettings, ctx.Config.ApproveMCP, approvedMCPKeys(ctx.MCPChanges))
+	if err != nil {
+		return err
+	}
+	ctx.MCPApproval = &approval
+	return ctx.updateManifestMCP()
 }
 
 func updateSuperClaudeMCPImports(superClaudePath string, selectedMCPServers []MCPServer) error {
@@ -323,13 +586,15 @@ func updateSuperClaudeMCPImports(superClaudePath string, selectedMCPServers []MC
 	contentStr := string(content)
 
 	// Remove any existing MCP import section first
-	contentStr = removeMCPImportsSection(contentStr)
+	contentStr = strings.TrimRight(removeMCPImportsSection(contentStr), "\n") + "\n"
 
 	// Add MCP imports if any servers were selected
 	if len(selectedMCPServers) > 0 {
 		mcpSection := "\n*MCP_INTEGRATIONS*\n"
 		for _, server := range selectedMCPServers {
-			mcpSection += fmt.Sprintf("@MCP/%s\n", server.MDFile)
+			if server.MDFile != "" {
+				mcpSection += fmt.Sprintf("@MCP/%s\n", server.MDFile)
+			}
 		}
 		contentStr += mcpSection
 	}
@@ -387,14 +652,60 @@ func mergeOrCreateMCPConfig(ctx *InstallContext) error {
 		} else {
 			fmt.Printf("[DRY RUN] Would create new .mcp.json with recommended servers\n")
 		}
+		for _, target := range ctx.Config.MCPTargets {
+			fmt.Printf("[DRY RUN] Would write the selected servers to %s\n", mcpClients[target].File)
+		}
+		if ctx.Config.ApproveMCP != MCPApprovalNone {
+			fmt.Printf("[DRY RUN] Would approve the MCP servers in %s\n", ctx.Config.MCPSettings.File())
+		}
 		return nil
 	}
 
+	selected := ctx.SelectedMCPServers
+	if ctx.Config.PinMCP {
+		versions, err := LoadMCPVersions(ctx.TargetDir, ctx.Config.MCPVersionsFile)
+		if err != nil {
+			return err
+		}
+		if selected, err = pinMCPServers(selected, ctx.RepoPath, versions); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	action := ConflictMerge
 	if ctx.ExistingFiles.MCPConfig {
-		return mergeMCPConfig(mcpPath, ctx.Config.AddRecommendedMCP, ctx.SelectedMCPServers, ctx.RepoPath)
+		if action, err = ctx.resolveMCPConfigConflict(mcpPath, selected); err != nil {
+			return err
+		}
 	}
 
-	return createMCPConfigWithSelected(mcpPath, ctx.SelectedMCPServers, ctx.RepoPath)
+	switch {
+	case action == ConflictSkip:
+		fmt.Printf("Left %s unchanged\n", config.MCPConfigFile)
+	case action == ConflictMerge && ctx.ExistingFiles.MCPConfig:
+		ctx.MCPChanges, err = mergeMCPConfig(mcpPath, ctx.Config.AddRecommendedMCP, selected, ctx.RepoPath, ctx.Config.MCPConflict, ctx.pauseProgress)
+	default:
+		ctx.MCPChanges, err = createMCPConfigWithSelected(mcpPath, selected, ctx.RepoPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := configureMCPSecrets(ctx.TargetDir, ctx.MCPChanges, ctx.Config.MCPEnv, ctx.pauseProgress); err != nil {
+		return err
+	}
+	warnLiteralSecrets(ctx.TargetDir)
+
+	ctx.MCPClientFiles, ctx.mcpClientChanges, err = writeMCPClientConfigs(ctx.TargetDir, ctx.Config.MCPTargets, selected, ctx.RepoPath, ctx.Config.MCPConflict, ctx.Config.MCPEnv, ctx.pauseProgress)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Config.ApproveMCP == MCPApprovalNone {
+		return ctx.updateManifestMCP()
+	}
+	return approveSelectedMCPServers(ctx)
 }
 
 func createCommandSymlink(ctx *InstallContext) error {
@@ -404,21 +715,7 @@ func createCommandSymlink(ctx *InstallContext) error {
 	}
 
 	targetPath := filepath.Join(ctx.TargetDir, config.ClaudeDir, "commands", "sc")
-
-	// Remove existing symlink if it exists
-	if _, err := os.Lstat(targetPath); err == nil {
-		if err := os.Remove(targetPath); err != nil {
-			return fmt.Errorf("failed to remove existing symlink: %w", err)
-		}
-	}
-
-	// Create symlink using relative path for portability
-	relPath := "../../.superclaude/Commands"
-	if err := os.Symlink(relPath, targetPath); err != nil {
-		return fmt.Errorf("failed to create command symlink: %w", err)
-	}
-
-	return nil
+	return ctx.linkFrameworkDir(targetPath, "../../.superclaude/Commands", "command")
 }
 
 func createAgentSymlink(ctx *InstallContext) error {
@@ -428,18 +725,33 @@ func createAgentSymlink(ctx *InstallContext) error {
 	}
 
 	targetPath := filepath.Join(ctx.TargetDir, config.ClaudeDir, "agents", "sc")
+	return ctx.linkFrameworkDir(targetPath, "../../.superclaude/Agents", "agent")
+}
 
-	// Remove existing symlink if it exists
-	if _, err := os.Lstat(targetPath); err == nil {
-		if err := os.Remove(targetPath); err != nil {
-			return fmt.Errorf("failed to remove existing agent symlink: %w", err)
+// linkFrameworkDir points targetPath at relPath (relative for portability), replacing
+// what is there as resolveLinkConflict decides. Only a directory the user chose to
+// overwrite under --interactive is deleted with its contents; otherwise removing a
+// non-empty directory fails.
+func (ctx *InstallContext) linkFrameworkDir(targetPath, relPath, kind string) error {
+	if info, err := os.Lstat(targetPath); err == nil {
+		action, err := ctx.resolveLinkConflict(targetPath, relPath, info)
+		if err != nil {
+			return err
+		}
+		if action == ConflictSkip {
+			return nil
+		}
+		remove := os.Remove
+		if ctx.Config.Interactive && action == ConflictOverwrite && info.IsDir() {
+			remove = os.RemoveAll
+		}
+		if err := remove(targetPath); err != nil {
+			return fmt.Errorf("failed to remove existing %s symlink: %w", kind, err)
 		}
 	}
 
-	// Create symlink using relative path for portability
-	relPath := "../../.superclaude/Agents"
 	if err := os.Symlink(relPath, targetPath); err != nil {
-		return fmt.Errorf("failed to create agent symlink: %w", err)
+		return fmt.Errorf("failed to create %s symlink: %w", kind, err)
 	}
 
 	return nil
@@ -454,7 +766,7 @@ func validateInstallation(ctx *InstallContext) error {
 	// Check that core files exist
 	requiredFiles := []string{
 		filepath.Join(ctx.TargetDir, config.SuperClaudeDir, "CLAUDE.md"),
-		filepath.Join(ctx.TargetDir, config.CLAUDEFile),
+		ctx.ImportLocation.Path(ctx.TargetDir),
 	}
 
 	for _, file := range requiredFiles {
@@ -633,7 +945,21 @@ func checkWritePermissions(dir string) error {
 	return os.Remove(testFile)
 }
 
-func copyMarkdownFiles(srcDir, dstDir string) error {
+// copyFrameworkFiles copies the .md files under srcDir into .superclaude, unless an
+// existing .superclaude is to be left unchanged
+func (ctx *InstallContext) copyFrameworkFiles(srcDir, dstDir string) error {
+	if err := ctx.resolveSuperClaudeDir(); err != nil {
+		return err
+	}
+	if ctx.skipSuperClaudeDir() {
+		return nil
+	}
+	return ctx.copyMarkdownFiles(srcDir, dstDir)
+}
+
+// copyMarkdownFiles copies the .md files under srcDir to dstDir, counting them for the
+// step's progress
+func (ctx *InstallContext) copyMarkdownFiles(srcDir, dstDir string) error {
 	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -660,96 +986,188 @@ func copyMarkdownFiles(srcDir, dstDir string) error {
 			return err
 		}
 
-		return copyFile(path, dstPath)
+		if err := copyFile(path, dstPath); err != nil {
+			return err
+		}
+		ctx.copiedFiles++
+		return nil
 	})
 }
 
-func mergeCLAUDEmd(claudePath string) error {
+func mergeCLAUDEmd(claudePath string, location ImportLocation, importBlock string) error {
 	content, err := os.ReadFile(claudePath)
 	if err != nil {
-		return fmt.Errorf("failed to read existing CLAUDE.md: %w", err)
+		return fmt.Errorf("failed to read existing %s: %w", location.RelPath(), err)
 	}
 
 	contentStr := string(content)
 
 	// Check if SuperClaude import already exists
-	if strings.Contains(contentStr, "@./.superclaude/CLAUDE.md") {
+	if location.HasSuperClaudeImport(contentStr) {
 		return nil // Already imported
 	}
 
-	// Append SuperClaude section
-	newContent := contentStr + "\n\n" + config.SuperClaudeImport + "\n"
-
-	return os.WriteFile(claudePath, []byte(newContent), 0o600)
+	return os.WriteFile(claudePath, []byte(mergedCLAUDEmd(contentStr, importBlock)), 0o600)
 }
 
-func createCLAUDEmd(claudePath string) error {
-	content := `# Claude Code Instructions
+// mergedCLAUDEmd appends the SuperClaude section to a memory file's content
+func mergedCLAUDEmd(content, importBlock string) string {
+	return content + "\n\n" + importBlock + "\n"
+}
 
-` + config.SuperClaudeImport + "\n"
+func createCLAUDEmd(claudePath string, location ImportLocation, content string) error {
+	// .claude/CLAUDE.md may be requested even when the .claude directory is skipped
+	if err := os.MkdirAll(filepath.Dir(claudePath), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", location.RelPath(), err)
+	}
 
 	return os.WriteFile(claudePath, []byte(content), 0o600)
 }
 
-func mergeMCPConfig(mcpPath string, addRecommended bool, selectedServers []MCPServer, repoPath string) error {
-	data, err := os.ReadFile(mcpPath)
+// mergeMCPConfig adds the selected servers to an existing .mcp.json. Edits are made in
+// place so the user's key order and formatting are preserved.
+func mergeMCPConfig(mcpPath string, addRecommended bool, selectedServers []MCPServer, repoPath string, strategy MCPConflictStrategy, pause progressPause) ([]MCPServerChange, error) {
+	if !addRecommended {
+		selectedServers = nil
+	}
+	return mergeMCPClientConfig(claudeCodeClient, mcpPath, selectedServers, repoPath, strategy, nil, pause)
+}
+
+// mergeMCPClientConfig adds the selected servers to a client's existing config file,
+// converting each definition to the client's format. lookup supplies values for
+// clients that cannot expand ${VAR} references themselves.
+func mergeMCPClientConfig(client mcpClient, path string, selectedServers []MCPServer, repoPath string, strategy MCPConflictStrategy, lookup func(string) (string, bool), pause progressPause) ([]MCPServerChange, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read existing .mcp.json: %w", err)
+		return nil, fmt.Errorf("failed to read existing %s: %w", client.File, err)
 	}
 
-	var existing map[string]interface{}
-	if err := json.Unmarshal(data, &existing); err != nil {
-		return fmt.Errorf("failed to parse existing .mcp.json: %w", err)
+	doc, problems := parseMCPDocument(data, client.File)
+	if problems != nil {
+		return nil, fmt.Errorf("failed to parse existing %s: %w", client.File, problems)
+	}
+	existing, problem := serversValue(doc, client.File, client.ServersKey)
+	if problem != nil {
+		return nil, fmt.Errorf("cannot merge into existing %s: %w", client.File, problem)
 	}
 
-	// Ensure mcpServers exists
-	if _, ok := existing["mcpServers"]; !ok {
-		existing["mcpServers"] = make(map[string]interface{})
+	// Ensure the servers object exists
+	if existing == nil {
+		if err := doc.Set([]string{client.ServersKey}, json.RawMessage("{}")); err != nil {
+			return nil, fmt.Errorf("failed to add %s to %s: %w", client.ServersKey, client.File, err)
+		}
 	}
 
 	// Add selected servers if requested
-	if addRecommended && len(selectedServers) > 0 {
-		servers := existing["mcpServers"].(map[string]interface{})
+	var changes []MCPServerChange
+	if len(selectedServers) > 0 {
+		rawServers, _ := doc.Raw(client.ServersKey)
+		var servers map[string]interface{}
+		if err := json.Unmarshal(rawServers, &servers); err != nil {
+			return nil, fmt.Errorf("failed to parse %s in %s: %w", client.ServersKey, client.File, err)
+		}
 
 		for _, mcpServer := range selectedServers {
-			serverConfig, err := LoadMCPConfig(repoPath, mcpServer.ConfigFile)
+			serverConfig, err := mcpServer.LoadConfigRaw(repoPath)
 			if err != nil {
-				return fmt.Errorf("failed to load MCP config for %s: %w", mcpServer.Name, err)
+				return nil, fmt.Errorf("failed to load MCP config for %s: %w", mcpServer.Name, err)
 			}
 
-			// Merge the loaded config, but don't overwrite existing ones
-			for key, value := range serverConfig {
-				if _, exists := servers[key]; !exists {
-					servers[key] = value
+			// Merge the loaded config, resolving entries that already exist
+			keys := make([]string, 0, len(serverConfig))
+			for key := range serverConfig {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				raw, requirements, err := referenceMCPEnv(key, serverConfig[key], readMCPDoc(repoPath, mcpServer))
+				if err != nil {
+					return nil, err
+				}
+				if client.Convert != nil {
+					if raw, err = client.Convert(key, raw, lookup); errors.Is(err, errMCPServerUnsupported) {
+						fmt.Printf("⚠️  Skipped %s for %s: %v\n", key, client.Name, err)
+						continue
+					} else if err != nil {
+						return nil, err
+					}
 				}
+				change, err := mergeMCPServerRaw(doc, client, servers, key, raw, strategy, pause)
+				if err != nil {
+					return nil, err
+				}
+				change.Server, change.File = mcpServer.Name, client.File
+				if change.Action != MCPServerKept {
+					change.Env = envForEntry(requirements, change)
+					change.written = raw
+				}
+				changes = append(changes, change)
 			}
 		}
 	}
 
-	// Write merged config
-	output, err := json.MarshalIndent(existing, "", "    ")
+	if bytes.Equal(doc.Bytes(), data) {
+		return changes, nil
+	}
+
+	return changes, os.WriteFile(path, doc.Bytes(), 0o600)
+}
+
+// mergeMCPServerRaw resolves one server key and applies the outcome to the document
+func mergeMCPServerRaw(doc *jsonedit.Document, client mcpClient, servers map[string]interface{}, key string, raw json.RawMessage, strategy MCPConflictStrategy, pause progressPause) (MCPServerChange, error) {
+	var incoming interface{}
+	if err := json.Unmarshal(raw, &incoming); err != nil {
+		return MCPServerChange{}, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+	}
+
+	change, err := mergeMCPServer(servers, client.File, key, incoming, strategy, pause)
 	if err != nil {
-		return fmt.Errorf("failed to marshal .mcp.json: %w", err)
+		return MCPServerChange{}, err
 	}
 
-	return os.WriteFile(mcpPath, output, 0o600)
+	switch change.Action {
+	case MCPServerAdded, MCPServerReplaced:
+		err = doc.Set([]string{client.ServersKey, key}, raw)
+	case MCPServerRenamed:
+		err = doc.Set([]string{client.ServersKey, change.RenamedTo}, raw)
+	}
+	if err != nil {
+		return MCPServerChange{}, fmt.Errorf("failed to update %s in %s: %w", key, client.File, err)
+	}
+
+	return change, nil
 }
 
-func createMCPConfigWithSelected(mcpPath string, selectedServers []MCPServer, repoPath string) error {
+func createMCPConfigWithSelected(mcpPath string, selectedServers []MCPServer, repoPath string) ([]MCPServerChange, error) {
 	mcpServers := make(map[string]interface{})
+	var changes []MCPServerChange
 
 	// Add only the selected servers by loading their config files
 	for _, mcpServer := range selectedServers {
-		serverConfig, err := LoadMCPConfig(repoPath, mcpServer.ConfigFile)
+		serverConfig, err := mcpServer.LoadConfigRaw(repoPath)
 		if err != nil {
-			return fmt.Errorf("failed to load MCP config for %s: %w", mcpServer.Name, err)
+			return nil, fmt.Errorf("failed to load MCP config for %s: %w", mcpServer.Name, err)
 		}
 
-		// Merge the loaded config into mcpServers
-		for key, value := range serverConfig {
+		// Merge the loaded config into mcpServers, referencing secrets as ${VAR}
+		for key, raw := range serverConfig {
+			referenced, requirements, err := referenceMCPEnv(key, raw, readMCPDoc(repoPath, mcpServer))
+			if err != nil {
+				return nil, err
+			}
+			var value interface{}
+			if err := json.Unmarshal(referenced, &value); err != nil {
+				return nil, fmt.Errorf("failed to parse MCP config for %s: %w", key, err)
+			}
 			mcpServers[key] = value
+
+			change := MCPServerChange{Key: key, Action: MCPServerAdded, Server: mcpServer.Name, File: config.MCPConfigFile, written: referenced}
+			change.Env = envForEntry(requirements, change)
+			changes = append(changes, change)
 		}
 	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
 
 	mcpConfig := map[string]interface{}{
 		"mcpServers": mcpServers,
@@ -757,8 +1175,8 @@ func createMCPConfigWithSelected(mcpPath string, selectedServers []MCPServer, re
 
 	output, err := json.MarshalIndent(mcpConfig, "", "    ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal .mcp.json: %w", err)
+		return nil, fmt.Errorf("failed to marshal .mcp.json: %w", err)
 	}
 
-	return os.WriteFile(mcpPath, output, 0o600)
+	return changes, os.WriteFile(mcpPath, output, 0o600)
 }
@@ -0,0 +1,144 @@
+package installer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/dgnsrekt/super-claude-lite/internal/config"
+)
+
+// Built-in templates for the generated CLAUDE.md content
+//
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+const (
+	// ProjectClaudeTemplate renders a new memory file (CLAUDE.md, .claude/CLAUDE.md or CLAUDE.local.md)
+	ProjectClaudeTemplate = "claude.md.tmpl"
+	// ImportTemplate renders the SuperClaude import section added to the memory file
+	ImportTemplate = "import.md.tmpl"
+	// SuperClaudeTemplate renders .superclaude/CLAUDE.md
+	SuperClaudeTemplate = "superclaude.md.tmpl"
+)
+
+// TemplateData holds the variables available to CLAUDE.md templates
+type TemplateData struct {
+	ProjectName     string      // Base name of the project directory
+	FrameworkRepo   string      // SuperClaude Framework repository URL
+	FrameworkCommit string      // Framework commit being installed
+	ImportFile      string      // Memory file holding the import, e.g. "CLAUDE.local.md"
+	ImportPath      string      // @ path to .superclaude/CLAUDE.md relative to ImportFile
+	ImportBlock     string      // Rendered import section (ProjectClaudeTemplate only)
+	Core            []Component // Selected core files
+	Modes           []Component // Selected modes
+	MCPServers      []MCPServer // Selected MCP servers
+}
+
+// TemplateRenderer renders installer templates. A template in the project's
+// .superclaude-lite/templates directory wins over one in the user's config directory,
+// which wins over the built-in default.
+type TemplateRenderer struct {
+	searchDirs []string
+}
+
+// NewTemplateRenderer creates a renderer that looks for overrides for the given project
+func NewTemplateRenderer(targetDir string) *TemplateRenderer {
+	searchDirs := []string{filepath.Join(ProjectConfigDir(targetDir), config.TemplatesDir)}
+	if userDir, err := UserConfigDir(); err == nil {
+		searchDirs = append(searchDirs, filepath.Join(userDir, config.TemplatesDir))
+	}
+
+	return &TemplateRenderer{searchDirs: searchDirs}
+}
+
+// Source returns the override file used for a template, or "built-in"
+func (r *TemplateRenderer) Source(name string) string {
+	for _, dir := range r.searchDirs {
+		path := filepath.Join(dir, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return "built-in"
+}
+
+// Render executes the named template with the given data
+func (r *TemplateRenderer) Render(name string, data TemplateData) (string, error) {
+	text, err := r.load(name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s (%s): %w", name, r.Source(name), err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s (%s): %w", name, r.Source(name), err)
+	}
+
+	return out.String(), nil
+}
+
+func (r *TemplateRenderer) load(name string) (string, error) {
+	if source := r.Source(name); source != "built-in" {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template %s: %w", source, err)
+		}
+		return string(data), nil
+	}
+
+	data, err := builtinTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", fmt.Errorf("unknown template %s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// RenderImportBlock renders the import section for a location. The result must contain
+// the @ import line so later runs and clean can find it.
+func (r *TemplateRenderer) RenderImportBlock(data TemplateData) (string, error) {
+	block, err := r.Render(ImportTemplate, data)
+	if err != nil {
+		return "", err
+	}
+
+	block = strings.TrimRight(block, "\n")
+	if findImportLine(strings.Split(block, "\n"), data.ImportPath) < 0 {
+		return "", fmt.Errorf("template %s (%s) must contain a line starting with @{{ .ImportPath }}",
+			ImportTemplate, r.Source(ImportTemplate))
+	}
+
+	return block, nil
+}
+
+// RenderFile renders a template for a whole file, ending it with exactly one newline
+func (r *TemplateRenderer) RenderFile(name string, data TemplateData) (string, error) {
+	content, err := r.Render(name, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(content, "\n") + "\n", nil
+}
+
+// newTemplateData collects the template variables for the current installation
+func newTemplateData(ctx *InstallContext) TemplateData {
+	return TemplateData{
+		ProjectName:     filepath.Base(ctx.TargetDir),
+		FrameworkRepo:   config.RepoURL,
+		FrameworkCommit: ctx.frameworkCommit(),
+		ImportFile:      ctx.ImportLocation.RelPath(),
+		ImportPath:      ctx.ImportLocation.ImportPath(),
+		Core:            ctx.SelectedCore,
+		Modes:           ctx.SelectedModes,
+		MCPServers:      ctx.SelectedMCPServers,
+	}
+}
@@ -0,0 +1,140 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// builtinImportBlock renders the built-in import section for a location
+func builtinImportBlock(t *testing.T, location ImportLocation) string {
+	t.Helper()
+
+	block, err := (&TemplateRenderer{}).RenderImportBlock(location.templateData("/project"))
+	if err != nil {
+		t.Fatalf("Failed to render import block: %v", err)
+	}
+	return block
+}
+
+// TestBuiltinTemplates validates the default CLAUDE.md content generated by the installer
+func TestBuiltinTemplates(t *testing.T) {
+	renderer := &TemplateRenderer{}
+
+	t.Run("Import_block", func(t *testing.T) {
+		expected := "## SuperClaude Instructions\n\n" +
+			"**Import SuperClaude Core, treat as if import is in the main CLAUDE.md file.**\n" +
+			"@../.superclaude/CLAUDE.md"
+		if got := builtinImportBlock(t, ImportIntoClaudeDir); got != expected {
+			t.Errorf("Unexpected import block:\n%s", got)
+		}
+	})
+
+	t.Run("SuperClaude_CLAUDE_md", func(t *testing.T) {
+		data := TemplateData{
+			Core: []Component{{Name: "flags", Kind: ComponentCore, File: "FLAGS.md", Description: "Flag definitions and triggers"}},
+			Modes: []Component{
+				{Name: "brainstorming", Kind: ComponentMode, File: "MODE_Brainstorming.md", Description: "Collaborative discovery mode"},
+				{Name: "custom", Kind: ComponentMode, File: "MODE_Custom.md"},
+			},
+		}
+		content, err := renderer.RenderFile(SuperClaudeTemplate, data)
+		if err != nil {
+			t.Fatalf("RenderFile failed: %v", err)
+		}
+
+		for _, line := range []string{
+			"*MANDATORY*\n@FLAGS.md # Flag definitions and triggers\n",
+			"*CRITICAL*\n@Modes/MODE_Brainstorming.md # Collaborative discovery mode\n@Modes/MODE_Custom.md\n",
+		} {
+			if !strings.Contains(content, line) {
+				t.Errorf("Expected content to contain %q, got:\n%s", line, content)
+			}
+		}
+		if !strings.HasSuffix(content, "\n") || strings.HasSuffix(content, "\n\n") {
+			t.Errorf("Expected exactly one trailing newline, got %q", content)
+		}
+	})
+
+	t.Run("No_modes", func(t *testing.T) {
+		content, err := renderer.RenderFile(SuperClaudeTemplate, TemplateData{})
+		if err != nil {
+			t.Fatalf("RenderFile failed: %v", err)
+		}
+		if strings.Contains(content, "*CRITICAL*") || strings.Contains(content, "*MANDATORY*") {
+			t.Errorf("Expected no import sections without components, got:\n%s", content)
+		}
+	})
+}
+
+// TestTemplateOverrides validates that project templates win over user templates
+func TestTemplateOverrides(t *testing.T) {
+	userConfig := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userConfig)
+	targetDir := filepath.Join(t.TempDir(), "my-app")
+
+	writeTestFiles(t, userConfig, map[string]string{
+		"super-claude-lite/templates/claude.md.tmpl": "# User template for {{ .ProjectName }}\n\n{{ .ImportBlock }}\n",
+		"super-claude-lite/templates/import.md.tmpl": "<!-- framework {{ .FrameworkCommit }} -->\n@{{ .ImportPath }}\n",
+	})
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude-lite/templates/claude.md.tmpl": "# {{ .ProjectName }}\n\n{{ .ImportBlock }}\n",
+	})
+
+	renderer := NewTemplateRenderer(targetDir)
+	data := ImportIntoProject.templateData(targetDir)
+
+	block, err := renderer.RenderImportBlock(data)
+	if err != nil {
+		t.Fatalf("RenderImportBlock failed: %v", err)
+	}
+	if !strings.HasPrefix(block, "<!-- framework ") {
+		t.Errorf("Expected user import template to be used, got:\n%s", block)
+	}
+
+	data.ImportBlock = block
+	content, err := renderer.RenderFile(ProjectClaudeTemplate, data)
+	if err != nil {
+		t.Fatalf("RenderFile failed: %v", err)
+	}
+	if !strings.HasPrefix(content, "# my-app\n") {
+		t.Errorf("Expected project template to win over user template, got:\n%s", content)
+	}
+
+	if source := renderer.Source(SuperClaudeTemplate); source != "built-in" {
+		t.Errorf("Expected built-in superclaude template, got %s", source)
+	}
+
+	// An import template without the @ line cannot be found again by clean
+	writeTestFiles(t, targetDir, map[string]string{
+		".superclaude-lite/templates/import.md.tmpl": "SuperClaude is installed\n",
+	})
+	if _, err := renderer.RenderImportBlock(data); err == nil {
+		t.Errorf("Expected error for import template without the @ import line")
+	}
+
+	// Clean removes a file generated from the overridden templates entirely
+	if err := os.WriteFile(filepath.Join(targetDir, ".superclaude-lite/templates/import.md.tmpl"),
+		[]byte("@{{ .ImportPath }}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to update template: %v", err)
+	}
+	block, err = renderer.RenderImportBlock(data)
+	if err != nil {
+		t.Fatalf("RenderImportBlock failed: %v", err)
+	}
+	data.ImportBlock = block
+	content, err = renderer.RenderFile(ProjectClaudeTemplate, data)
+	if err != nil {
+		t.Fatalf("RenderFile failed: %v", err)
+	}
+	if err := createCLAUDEmd(ImportIntoProject.Path(targetDir), ImportIntoProject, content); err != nil {
+		t.Fatalf("createCLAUDEmd failed: %v", err)
+	}
+	if _, err := RemoveSuperClaudeImport(targetDir, ImportIntoProject, nil); err != nil {
+		t.Fatalf("RemoveSuperClaudeImport failed: %v", err)
+	}
+	if fileExists(ImportIntoProject.Path(targetDir)) {
+		t.Errorf("Expected generated CLAUDE.md to be removed")
+	}
+}
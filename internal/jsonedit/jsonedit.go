@@ -0,0 +1,356 @@
+// Package jsonedit edits JSON documents in place. Changes are applied as insertions
+// and deletions in the original text, so key order, indentation, trailing newlines
+// and untouched sections stay exactly as the user wrote them.
+package jsonedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// defaultIndent is used when the document has no indented member to copy the style from
+const defaultIndent = "    "
+
+// Kind is the JSON type of a value
+type Kind int
+
+// JSON value kinds
+const (
+	Object Kind = iota
+	Array
+	String
+	Number
+	Bool
+	Null
+)
+
+// String returns the JSON name of the kind
+func (k Kind) String() string {
+	return [...]string{"object", "array", "string", "number", "boolean", "null"}[k]
+}
+
+// Value is a parsed JSON value with its byte range in the source
+type Value struct {
+	Kind     Kind
+	Start    int // Offset of the first byte
+	End      int // Offset just past the last byte
+	Members  []*Member
+	Elements []*Value
+}
+
+// Member is a key/value pair of an object
+type Member struct {
+	Key      string
+	KeyStart int // Offset of the opening quote of the key
+	KeyEnd   int // Offset just past the closing quote of the key
+	Value    *Value
+}
+
+// Document is a JSON text and its parse tree
+type Document struct {
+	src  []byte
+	root *Value
+}
+
+// Parse parses a JSON document, keeping byte offsets for editing
+func Parse(data []byte) (*Document, error) {
+	p := &parser{src: data}
+	p.skipSpace()
+	root, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after top-level value", p.src[p.pos])
+	}
+
+	return &Document{src: data, root: root}, nil
+}
+
+// Bytes returns the current document text
+func (d *Document) Bytes() []byte {
+	return d.src
+}
+
+// Root returns the top-level value
+func (d *Document) Root() *Value {
+	return d.root
+}
+
+// Position converts a byte offset into a 1-based line and column
+func (d *Document) Position(offset int) (line, column int) {
+	return position(d.src, offset)
+}
+
+// Lookup returns the value at the given object key path
+func (d *Document) Lookup(path ...string) (*Value, bool) {
+	value := d.root
+	for _, key := range path {
+		if value.Kind != Object {
+			return nil, false
+		}
+		member := value.member(key)
+		if member == nil {
+			return nil, false
+		}
+		value = member.Value
+	}
+	return value, true
+}
+
+// Raw returns the source text of the value at the given path
+func (d *Document) Raw(path ...string) (json.RawMessage, bool) {
+	value, ok := d.Lookup(path...)
+	if !ok {
+		return nil, false
+	}
+	return json.RawMessage(d.src[value.Start:value.End]), true
+}
+
+// Keys returns the member names of the object at the given path in document order
+func (d *Document) Keys(path ...string) []string {
+	value, ok := d.Lookup(path...)
+	if !ok || value.Kind != Object {
+		return nil
+	}
+
+	keys := make([]string, 0, len(value.Members))
+	for _, member := range value.Members {
+		keys = append(keys, member.Key)
+	}
+	return keys
+}
+
+// Set replaces the value at path, or appends it as the last member of its parent
+// object. Missing parent objects are created. The raw value is re-indented to match
+// the surrounding document.
+func (d *Document) Set(path []string, raw json.RawMessage) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	if !json.Valid(raw) {
+		return fmt.Errorf("invalid JSON value for %s", strings.Join(path, "."))
+	}
+
+	// Build the value for any missing parents from the inside out
+	parent, depth := d.root, 0
+	for ; depth < len(path)-1; depth++ {
+		if parent.Kind != Object {
+			return fmt.Errorf("%s is a %s, not an object", strings.Join(path[:depth], "."), parent.Kind)
+		}
+		member := parent.member(path[depth])
+		if member == nil {
+			break
+		}
+		parent = member.Value
+	}
+	if parent.Kind != Object {
+		return fmt.Errorf("%s is a %s, not an object", strings.Join(path[:depth], "."), parent.Kind)
+	}
+
+	key := path[depth]
+	for i := len(path) - 1; i > depth; i-- {
+		wrapped, err := json.Marshal(map[string]json.RawMessage{path[i]: raw})
+		if err != nil {
+			return fmt.Errorf("failed to build %s: %w", strings.Join(path[:i+1], "."), err)
+		}
+		raw = wrapped
+	}
+
+	if member := parent.member(key); member != nil {
+		old := member.Value
+		text := d.format(raw, d.lineIndent(member.KeyStart))
+		// Keep values written on a single line that way
+		if d.compact() || (len(old.Members)+len(old.Elements) > 0 && !d.spansLines(old.Start, old.End)) {
+			text = inlineJSON(raw, d.spaced(old.Start, old.End))
+		}
+		return d.replace(old.Start, old.End, text)
+	}
+	return d.insert(parent, key, raw)
+}
+
+// Delete removes the member at path, together with its separating comma. It reports
+// whether the member existed.
+func (d *Document) Delete(path ...string) (bool, error) {
+	if len(path) == 0 {
+		return false, fmt.Errorf("empty path")
+	}
+
+	parent, ok := d.Lookup(path[:len(path)-1]...)
+	if !ok || parent.Kind != Object {
+		return false, nil
+	}
+
+	key := path[len(path)-1]
+	for i, member := range parent.Members {
+		if member.Key != key {
+			continue
+		}
+
+		var start, end int
+		switch {
+		case i+1 < len(parent.Members):
+			// Up to the next key, so it takes over this member's position
+			start, end = member.KeyStart, parent.Members[i+1].KeyStart
+		case i > 0:
+			// From the end of the previous value, dropping its comma
+			start, end = parent.Members[i-1].Value.End, member.Value.End
+		default:
+			// Only member: leave an empty object
+			start, end = parent.Start+1, parent.End-1
+		}
+		return true, d.replace(start, end, "")
+	}
+
+	return false, nil
+}
+
+// insert appends a member to an object, following the layout of the existing members
+func (d *Document) insert(object *Value, key string, raw json.RawMessage) error {
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key %q: %w", key, err)
+	}
+
+	if len(object.Members) == 0 {
+		if d.compact() {
+			spaced := d.spaced(d.root.Start, d.root.End)
+			return d.replace(object.Start+1, object.End-1, string(keyJSON)+inlineSeparator(":", spaced)+inlineJSON(raw, spaced))
+		}
+		parentIndent := d.lineIndent(object.Start)
+		memberIndent := parentIndent + d.indentUnit()
+		text := "\n" + memberIndent + string(keyJSON) + ": " + d.format(raw, memberIndent) + "\n" + parentIndent
+		return d.replace(object.Start+1, object.End-1, text)
+	}
+
+	last := object.Members[len(object.Members)-1]
+	separator := string(d.src[last.KeyEnd:last.Value.Start])
+
+	// Members on the same line as the opening brace stay on one line
+	if !d.spansLines(object.Start, last.KeyStart) {
+		spaced := d.spaced(object.Start, object.End)
+		text := inlineSeparator(",", spaced) + string(keyJSON) + separator + inlineJSON(raw, spaced)
+		return d.replace(last.Value.End, last.Value.End, text)
+	}
+
+	memberIndent := d.lineIndent(last.KeyStart)
+	text := ",\n" + memberIndent + string(keyJSON) + separator + d.format(raw, memberIndent)
+	return d.replace(last.Value.End, last.Value.End, text)
+}
+
+// replace swaps src[start:end] for text and re-parses the document
+func (d *Document) replace(start, end int, text string) error {
+	updated := make([]byte, 0, len(d.src)-(end-start)+len(text))
+	updated = append(updated, d.src[:start]...)
+	updated = append(updated, text...)
+	updated = append(updated, d.src[end:]...)
+
+	parsed, err := Parse(updated)
+	if err != nil {
+		return fmt.Errorf("edit produced invalid JSON: %w", err)
+	}
+	*d = *parsed
+	return nil
+}
+
+// format indents a raw value for a member whose line starts with prefix
+func (d *Document) format(raw json.RawMessage, prefix string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, prefix, d.indentUnit()); err != nil {
+		return string(raw)
+	}
+	return out.String()
+}
+
+// indentUnit returns the indentation of the first nested member, the document's
+// indentation step
+func (d *Document) indentUnit() string {
+	if d.root.Kind == Object && len(d.root.Members) > 0 {
+		rootIndent := d.lineIndent(d.root.Start)
+		if indent := d.lineIndent(d.root.Members[0].KeyStart); len(indent) > len(rootIndent) && d.onOwnLine(d.root.Members[0].KeyStart) {
+			return indent[len(rootIndent):]
+		}
+	}
+	return defaultIndent
+}
+
+// compact reports whether the whole document is written on a single line
+func (d *Document) compact() bool {
+	return !bytes.Contains(bytes.TrimSpace(d.src), []byte("\n"))
+}
+
+// spansLines reports whether src[start:end] contains a line break
+func (d *Document) spansLines(start, end int) bool {
+	return bytes.Contains(d.src[start:end], []byte("\n"))
+}
+
+// spaced reports whether single-line JSON in src[start:end] puts a space after
+// colons, the usual `{"key": "value"}` style
+func (d *Document) spaced(start, end int) bool {
+	return bytes.Contains(d.src[start:end], []byte(": ")) || bytes.Contains(d.src[start:end], []byte(", "))
+}
+
+// lineIndent returns the leading whitespace of the line containing offset
+func (d *Document) lineIndent(offset int) string {
+	lineStart := bytes.LastIndexByte(d.src[:offset], '\n') + 1
+	end := lineStart
+	for end < len(d.src) && (d.src[end] == ' ' || d.src[end] == '\t') {
+		end++
+	}
+	return string(d.src[lineStart:end])
+}
+
+// onOwnLine reports whether only whitespace precedes offset on its line
+func (d *Document) onOwnLine(offset int) bool {
+	lineStart := bytes.LastIndexByte(d.src[:offset], '\n') + 1
+	return len(bytes.TrimSpace(d.src[lineStart:offset])) == 0
+}
+
+func (v *Value) member(key string) *Member {
+	for _, member := range v.Members {
+		if member.Key == key {
+			return member
+		}
+	}
+	return nil
+}
+
+// inlineJSON formats a valid raw value on a single line, with a space after colons
+// and commas when spaced is set
+func inlineJSON(raw json.RawMessage, spaced bool) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	if !spaced {
+		return compact.String()
+	}
+
+	var out strings.Builder
+	inString, escaped := false, false
+	for _, c := range compact.Bytes() {
+		out.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ':' || c == ','):
+			out.WriteByte(' ')
+		}
+	}
+	return out.String()
+}
+
+// inlineSeparator returns a separator for single-line JSON
+func inlineSeparator(separator string, spaced bool) string {
+	if spaced {
+		return separator + " "
+	}
+	return separator
+}
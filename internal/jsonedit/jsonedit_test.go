@@ -0,0 +1,145 @@
+package jsonedit
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files in testdata")
+
+const context7Server = `{"command": "npx", "args": ["-y", "@upstash/context7-mcp@latest"]}`
+
+// TestEditGolden applies edits to files in testdata and compares the result with the
+// matching .golden.json file. Run with -update to regenerate the golden files.
+func TestEditGolden(t *testing.T) {
+	setContext7 := func(d *Document) error {
+		return d.Set([]string{"mcpServers", "context7"}, json.RawMessage(context7Server))
+	}
+	deleteServers := func(keys ...string) func(*Document) error {
+		return func(d *Document) error {
+			for _, key := range keys {
+				removed, err := d.Delete("mcpServers", key)
+				if err != nil {
+					return err
+				}
+				if !removed {
+					return errors.New("server not found: " + key)
+				}
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		edit  func(*Document) error
+	}{
+		{"indent2_insert", "indent2", setContext7},
+		{"tabs_insert", "tabs", setContext7},
+		{"compact_insert", "compact", setContext7},
+		{"empty_insert", "empty", setContext7},
+		{"missing_parent_insert", "missing", setContext7},
+		{"replace_multiline", "replace", setContext7},
+		{"replace_single_line", "replace", func(d *Document) error {
+			return d.Set([]string{"mcpServers", "github"}, json.RawMessage(`{"command": "gh-mcp", "args": ["stdio"]}`))
+		}},
+		{"delete_first", "delete", deleteServers("context7")},
+		{"delete_last", "delete", deleteServers("github")},
+		{"delete_all", "delete", deleteServers("github", "context7")},
+		{"tabs_delete_only", "tabs", deleteServers("github")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join("testdata", tc.input+".input.json"))
+			if err != nil {
+				t.Fatalf("Failed to read input: %v", err)
+			}
+
+			doc, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if err := tc.edit(doc); err != nil {
+				t.Fatalf("Edit failed: %v", err)
+			}
+
+			got := doc.Bytes()
+			if !json.Valid(got) {
+				t.Fatalf("Edit produced invalid JSON:\n%s", got)
+			}
+
+			goldenPath := filepath.Join("testdata", tc.name+".golden.json")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatalf("Failed to write golden file: %v", err)
+				}
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+			}
+			if string(got) != string(expected) {
+				t.Errorf("Result does not match %s\n--- got ---\n%s\n--- expected ---\n%s", goldenPath, got, expected)
+			}
+		})
+	}
+}
+
+// TestUnchangedRoundTrip validates that parsing alone never changes the text
+func TestUnchangedRoundTrip(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.input.json"))
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+
+	for _, path := range inputs {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		doc, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse %s failed: %v", path, err)
+		}
+		if string(doc.Bytes()) != string(input) {
+			t.Errorf("Expected %s to round-trip unchanged", path)
+		}
+		if keys := doc.Keys("mcpServers"); path == filepath.Join("testdata", "replace.input.json") &&
+			(len(keys) != 2 || keys[0] != "context7" || keys[1] != "github") {
+			t.Errorf("Expected keys in document order, got %v", keys)
+		}
+	}
+}
+
+// TestSyntaxErrorPosition validates line and column reporting for invalid documents
+func TestSyntaxErrorPosition(t *testing.T) {
+	testCases := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"{\n  \"a\": 1,\n  \"b\" 2\n}", 3, 7},
+		{"{\n  \"a\": [1, 2,]\n}", 2, 14},
+		{"{\"a\": tru}", 1, 7},
+		{"{\"a\": 1}\n}", 2, 1},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse([]byte(tc.input))
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected SyntaxError for %q, got %v", tc.input, err)
+			continue
+		}
+		if syntaxErr.Line != tc.line || syntaxErr.Column != tc.column {
+			t.Errorf("Expected %q to fail at %d:%d, got %v", tc.input, tc.line, tc.column, err)
+		}
+	}
+}
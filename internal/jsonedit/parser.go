@@ -0,0 +1,231 @@
+package jsonedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SyntaxError is a JSON parse error located by line and column
+type SyntaxError struct {
+	Offset int
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error formats the error as "line L, column C: message"
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+type parser struct {
+	src []byte
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line, column := position(p.src, p.pos)
+	return &SyntaxError{Offset: p.pos, Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) parseValue() (*Value, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.src[p.pos]; {
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"':
+		start := p.pos
+		if _, err := p.parseString(); err != nil {
+			return nil, err
+		}
+		return &Value{Kind: String, Start: start, End: p.pos}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	default:
+		return p.parseLiteral()
+	}
+}
+
+func (p *parser) parseObject() (*Value, error) {
+	object := &Value{Kind: Object, Start: p.pos}
+	p.pos++ // {
+	p.skipSpace()
+
+	if p.pos < len(p.src) && p.src[p.pos] == '}' {
+		p.pos++
+		object.End = p.pos
+		return object, nil
+	}
+
+	for {
+		member, err := p.parseMember()
+		if err != nil {
+			return nil, err
+		}
+		object.Members = append(object.Members, member)
+
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unexpected end of input, expected ',' or '}'")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+			p.skipSpace()
+		case '}':
+			p.pos++
+			object.End = p.pos
+			return object, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' after object member, found %q", p.src[p.pos])
+		}
+	}
+}
+
+func (p *parser) parseMember() (*Member, error) {
+	if p.pos >= len(p.src) || p.src[p.pos] != '"' {
+		return nil, p.errorf("expected string key")
+	}
+
+	member := &Member{KeyStart: p.pos}
+	key, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+	member.Key = key
+	member.KeyEnd = p.pos
+
+	p.skipSpace()
+	if p.pos >= len(p.src) || p.src[p.pos] != ':' {
+		return nil, p.errorf("expected ':' after key %q", key)
+	}
+	p.pos++
+	p.skipSpace()
+
+	if member.Value, err = p.parseValue(); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (p *parser) parseArray() (*Value, error) {
+	array := &Value{Kind: Array, Start: p.pos}
+	p.pos++ // [
+	p.skipSpace()
+
+	if p.pos < len(p.src) && p.src[p.pos] == ']' {
+		p.pos++
+		array.End = p.pos
+		return array, nil
+	}
+
+	for {
+		element, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array.Elements = append(array.Elements, element)
+
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unexpected end of input, expected ',' or ']'")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+			p.skipSpace()
+		case ']':
+			p.pos++
+			array.End = p.pos
+			return array, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' after array element, found %q", p.src[p.pos])
+		}
+	}
+}
+
+// parseString scans a string literal and returns its decoded value
+func (p *parser) parseString() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '\\':
+			p.pos += 2
+		case c == '"':
+			p.pos++
+			var value string
+			if err := json.Unmarshal(p.src[start:p.pos], &value); err != nil {
+				p.pos = start
+				return "", p.errorf("invalid string literal")
+			}
+			return value, nil
+		case c < 0x20:
+			return "", p.errorf("control character in string literal")
+		default:
+			p.pos++
+		}
+	}
+
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseNumber() (*Value, error) {
+	start := p.pos
+	for p.pos < len(p.src) && bytes.IndexByte([]byte("+-0123456789.eE"), p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(p.src[start:p.pos], &number); err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	return &Value{Kind: Number, Start: start, End: p.pos}, nil
+}
+
+func (p *parser) parseLiteral() (*Value, error) {
+	literals := []struct {
+		text string
+		kind Kind
+	}{{"true", Bool}, {"false", Bool}, {"null", Null}}
+
+	for _, literal := range literals {
+		if bytes.HasPrefix(p.src[p.pos:], []byte(literal.text)) {
+			start := p.pos
+			p.pos += len(literal.text)
+			return &Value{Kind: literal.kind, Start: start, End: p.pos}, nil
+		}
+	}
+
+	return nil, p.errorf("unexpected character %q", p.src[p.pos])
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(src []byte, offset int) (line, column int) {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	line = bytes.Count(src[:offset], []byte("\n")) + 1
+	column = offset - (bytes.LastIndexByte(src[:offset], '\n') + 1) + 1
+	return line, column
+}